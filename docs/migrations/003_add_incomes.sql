@@ -0,0 +1,36 @@
+-- Add incomes table and kind of category to separate income categories from expense ones
+ALTER TABLE "categories" ADD COLUMN "kind" varchar(16) NOT NULL DEFAULT 'expense';
+
+CREATE TABLE "incomes" (
+	"incomeId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"categoryId" int4,
+	"amount" int8 NOT NULL,
+	"description" text NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"updatedAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	PRIMARY KEY("incomeId")
+);
+
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_categories" FOREIGN KEY ("categoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
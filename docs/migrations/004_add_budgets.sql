@@ -0,0 +1,33 @@
+-- Add monthly budgets for expense categories
+CREATE TABLE "budgets" (
+	"budgetId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"categoryId" int4 NOT NULL,
+	"amount" int8 NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"updatedAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("budgetId")
+);
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_categories" FOREIGN KEY ("categoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
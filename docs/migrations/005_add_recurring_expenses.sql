@@ -0,0 +1,35 @@
+-- Add recurring expenses created automatically by schedule
+CREATE TABLE "recurringExpenses" (
+	"recurringExpenseId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"categoryId" int4,
+	"amount" int8 NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	"description" text NOT NULL,
+	"dayOfMonth" int4 NOT NULL,
+	"nextRunAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("recurringExpenseId")
+);
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_categories" FOREIGN KEY ("categoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
@@ -0,0 +1,16 @@
+-- Add cache of daily exchange rates and base currency of user for statistics
+ALTER TABLE "users" ADD COLUMN "baseCurrency" varchar(12) NOT NULL DEFAULT 'RUB';
+
+CREATE TABLE "exchangeRates" (
+	"exchangeRateId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"date" date NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	"rate" float8 NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("exchangeRateId")
+);
+
+CREATE UNIQUE INDEX "UX_exchangeRates_date_currency" ON "exchangeRates" USING BTREE (
+	"date",
+	"currency"
+);
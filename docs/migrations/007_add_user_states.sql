@@ -0,0 +1,16 @@
+-- Keep conversation state of telegram users across restarts, rows are removed when they expire
+CREATE TABLE "userStates" (
+	"userStateId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"telegramId" int8 NOT NULL,
+	"data" jsonb NOT NULL,
+	"expiresAt" timestamp with time zone NOT NULL,
+	PRIMARY KEY("userStateId")
+);
+
+CREATE UNIQUE INDEX "UX_userStates_telegramId" ON "userStates" USING BTREE (
+	"telegramId"
+);
+
+CREATE INDEX "IX_userStates_expiresAt" ON "userStates" USING BTREE (
+	"expiresAt"
+);
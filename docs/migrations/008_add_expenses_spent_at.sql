@@ -0,0 +1,4 @@
+-- Add date when money was actually spent, statistics filter expenses by it instead of createdAt
+ALTER TABLE "expenses" ADD COLUMN "spentAt" timestamp with time zone NOT NULL DEFAULT NOW();
+
+UPDATE "expenses" SET "spentAt" = "createdAt";
@@ -0,0 +1,2 @@
+-- Add language of bot interface, it is detected from Telegram language of new users on /start
+ALTER TABLE "users" ADD COLUMN "language" varchar(8) NOT NULL DEFAULT 'ru';
@@ -0,0 +1,5 @@
+-- Add user settings: time zone for dates and periods, currency of amounts without currency
+-- and first day of week (0 is Sunday, 1 is Monday)
+ALTER TABLE "users" ADD COLUMN "timezone" varchar(64) NOT NULL DEFAULT 'Europe/Moscow';
+ALTER TABLE "users" ADD COLUMN "defaultCurrency" varchar(12) NOT NULL DEFAULT 'RUB';
+ALTER TABLE "users" ADD COLUMN "weekStart" int4 NOT NULL DEFAULT 1;
@@ -0,0 +1,137 @@
+-- Add ledgers: expenses, incomes, categories, budgets and recurring expenses belong to a ledger,
+-- every user has a personal ledger, a group chat has a shared ledger of its members
+CREATE TABLE "ledgers" (
+	"ledgerId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"title" varchar(255) NOT NULL,
+	"telegramChatId" int8 NOT NULL,
+	"inviteCode" varchar(32) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("ledgerId")
+);
+
+CREATE UNIQUE INDEX "UX_ledgers_telegramChatId" ON "ledgers" USING BTREE (
+	"telegramChatId"
+);
+
+CREATE UNIQUE INDEX "UX_ledgers_inviteCode" ON "ledgers" USING BTREE (
+	"inviteCode"
+);
+
+CREATE TABLE "ledgerMembers" (
+	"ledgerMemberId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"ledgerId" int4 NOT NULL,
+	"userId" int4 NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("ledgerMemberId")
+);
+
+CREATE UNIQUE INDEX "UX_ledgerMembers_ledgerId_userId" ON "ledgerMembers" USING BTREE (
+	"ledgerId",
+	"userId"
+);
+
+ALTER TABLE "ledgers" ADD CONSTRAINT "Ref_ledgers_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "ledgerMembers" ADD CONSTRAINT "Ref_ledgerMembers_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "ledgerMembers" ADD CONSTRAINT "Ref_ledgerMembers_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+-- personal ledger of existing users, private chat id equals telegram id of user
+INSERT INTO "ledgers" ("title", "telegramChatId", "inviteCode", "statusId")
+SELECT "telegramUsername", "telegramId", substr(md5(random()::text || "userId"::text), 1, 16), 1
+FROM "users";
+
+INSERT INTO "ledgerMembers" ("ledgerId", "userId")
+SELECT l."ledgerId", u."userId"
+FROM "users" u
+JOIN "ledgers" l ON l."telegramChatId" = u."telegramId";
+
+-- active ledger of private chat after joining a shared ledger, NULL is personal ledger
+ALTER TABLE "users" ADD COLUMN "ledgerId" int4;
+
+ALTER TABLE "users" ADD CONSTRAINT "Ref_users_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "categories" ADD COLUMN "ledgerId" int4;
+ALTER TABLE "expenses" ADD COLUMN "ledgerId" int4;
+ALTER TABLE "incomes" ADD COLUMN "ledgerId" int4;
+ALTER TABLE "budgets" ADD COLUMN "ledgerId" int4;
+ALTER TABLE "recurringExpenses" ADD COLUMN "ledgerId" int4;
+
+UPDATE "categories" t SET "ledgerId" = l."ledgerId" FROM "users" u JOIN "ledgers" l ON l."telegramChatId" = u."telegramId" WHERE t."userId" = u."userId";
+UPDATE "expenses" t SET "ledgerId" = l."ledgerId" FROM "users" u JOIN "ledgers" l ON l."telegramChatId" = u."telegramId" WHERE t."userId" = u."userId";
+UPDATE "incomes" t SET "ledgerId" = l."ledgerId" FROM "users" u JOIN "ledgers" l ON l."telegramChatId" = u."telegramId" WHERE t."userId" = u."userId";
+UPDATE "budgets" t SET "ledgerId" = l."ledgerId" FROM "users" u JOIN "ledgers" l ON l."telegramChatId" = u."telegramId" WHERE t."userId" = u."userId";
+UPDATE "recurringExpenses" t SET "ledgerId" = l."ledgerId" FROM "users" u JOIN "ledgers" l ON l."telegramChatId" = u."telegramId" WHERE t."userId" = u."userId";
+
+ALTER TABLE "categories" ALTER COLUMN "ledgerId" SET NOT NULL;
+ALTER TABLE "expenses" ALTER COLUMN "ledgerId" SET NOT NULL;
+ALTER TABLE "incomes" ALTER COLUMN "ledgerId" SET NOT NULL;
+ALTER TABLE "budgets" ALTER COLUMN "ledgerId" SET NOT NULL;
+ALTER TABLE "recurringExpenses" ALTER COLUMN "ledgerId" SET NOT NULL;
+
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "expenses" ADD CONSTRAINT "Ref_expenses_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+-- conversation state is kept per chat, user has separate state in private and group chats
+ALTER TABLE "userStates" ADD COLUMN "chatId" int8;
+UPDATE "userStates" SET "chatId" = "telegramId";
+ALTER TABLE "userStates" ALTER COLUMN "chatId" SET NOT NULL;
+
+DROP INDEX "UX_userStates_telegramId";
+CREATE UNIQUE INDEX "UX_userStates_telegramId_chatId" ON "userStates" USING BTREE (
+	"telegramId",
+	"chatId"
+);
@@ -0,0 +1,9 @@
+-- Add parent category: categories form two-level tree, e.g. "Транспорт" holds "Такси" and "Метро"
+ALTER TABLE "categories" ADD COLUMN "parentId" int4;
+
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_categories" FOREIGN KEY ("parentId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
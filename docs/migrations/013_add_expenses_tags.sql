@@ -0,0 +1,6 @@
+-- Add hashtag tags of expenses: "#отпуск такси 3000" is tagged "отпуск" regardless of category
+ALTER TABLE "expenses" ADD COLUMN "tags" text[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX "IX_expenses_tags" ON "expenses" USING GIN (
+	"tags"
+);
@@ -0,0 +1,4 @@
+-- Add full-text index of expense descriptions for /find, configuration must match db.FullTextConfig
+CREATE INDEX "IX_expenses_description" ON "expenses" USING GIN (
+	to_tsvector('russian', "description")
+);
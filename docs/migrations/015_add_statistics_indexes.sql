@@ -0,0 +1,11 @@
+-- Add indexes of statistics queries: expenses and incomes are filtered by ledger and period in database.
+-- Ledger is personal ledger of user or ledger of group chat, member filter narrows rows of the same range.
+CREATE INDEX "IX_expenses_ledgerId_spentAt" ON "expenses" USING BTREE (
+	"ledgerId",
+	"spentAt"
+);
+
+CREATE INDEX "IX_incomes_ledgerId_createdAt" ON "incomes" USING BTREE (
+	"ledgerId",
+	"createdAt"
+);
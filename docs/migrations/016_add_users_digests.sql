@@ -0,0 +1,4 @@
+-- Add schedule of weekly and monthly digests: time of next digest in UTC, NULL if user is not subscribed.
+-- Digests are delivered on Monday and on the 1st day of month in the morning of user's time zone.
+ALTER TABLE "users" ADD COLUMN "weeklyDigestAt" timestamp with time zone;
+ALTER TABLE "users" ADD COLUMN "monthlyDigestAt" timestamp with time zone;
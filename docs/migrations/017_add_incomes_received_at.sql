@@ -0,0 +1,11 @@
+-- Add date when money was actually received, incomes are filtered by it instead of createdAt
+ALTER TABLE "incomes" ADD COLUMN "receivedAt" timestamp with time zone NOT NULL DEFAULT NOW();
+
+UPDATE "incomes" SET "receivedAt" = "createdAt";
+
+DROP INDEX "IX_incomes_ledgerId_createdAt";
+
+CREATE INDEX "IX_incomes_ledgerId_receivedAt" ON "incomes" USING BTREE (
+	"ledgerId",
+	"receivedAt"
+);
@@ -24,6 +24,14 @@ CREATE TABLE "users" (
 	"telegramUsername" varchar(255) NOT NULL,
 	"teleramFirstName" varchar(255),
 	"telegramLastName" varchar(255),
+	"baseCurrency" varchar(12) NOT NULL DEFAULT 'RUB',
+	"language" varchar(8) NOT NULL DEFAULT 'ru',
+	"timezone" varchar(64) NOT NULL DEFAULT 'Europe/Moscow',
+	"defaultCurrency" varchar(12) NOT NULL DEFAULT 'RUB',
+	"weekStart" int4 NOT NULL DEFAULT 1,
+	"ledgerId" int4,
+	"weeklyDigestAt" timestamp with time zone,
+	"monthlyDigestAt" timestamp with time zone,
 	CONSTRAINT "users_pkey" PRIMARY KEY("userId")
 );
 
@@ -35,6 +43,7 @@ CREATE INDEX "IX_FK_users_statusId_users" ON "users" USING BTREE (
 CREATE TABLE "expenses" (
 	"expenseId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
 	"userId" int4 NOT NULL,
+	"ledgerId" int4 NOT NULL,
 	"categoryId" int4,
 	"amount" int8 NOT NULL,
 	"description" text NOT NULL,
@@ -42,21 +51,151 @@ CREATE TABLE "expenses" (
 	"updatedAt" timestamp with time zone NOT NULL DEFAULT NOW(),
 	"statusId" int4 NOT NULL,
 	"currency" varchar(12) NOT NULL,
+	"spentAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"tags" text[] NOT NULL DEFAULT '{}',
 	PRIMARY KEY("expenseId")
 );
 
+CREATE INDEX "IX_expenses_tags" ON "expenses" USING GIN (
+	"tags"
+);
+
+CREATE INDEX "IX_expenses_description" ON "expenses" USING GIN (
+	to_tsvector('russian', "description")
+);
+
+CREATE INDEX "IX_expenses_ledgerId_spentAt" ON "expenses" USING BTREE (
+	"ledgerId",
+	"spentAt"
+);
+
 CREATE TABLE "categories" (
 	"categoryId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
 	"userId" int4 NOT NULL,
+	"ledgerId" int4 NOT NULL,
 	"title" text NOT NULL,
 	"alias" text,
 	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
 	"updatedAt" timestamp with time zone NOT NULL DEFAULT NOW(),
 	"statusId" int4 NOT NULL,
 	"emoji" varchar(10),
+	"kind" varchar(16) NOT NULL DEFAULT 'expense',
+	"parentId" int4,
 	PRIMARY KEY("categoryId")
 );
 
+CREATE TABLE "incomes" (
+	"incomeId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"ledgerId" int4 NOT NULL,
+	"categoryId" int4,
+	"amount" int8 NOT NULL,
+	"description" text NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"updatedAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	"receivedAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("incomeId")
+);
+
+CREATE INDEX "IX_incomes_ledgerId_receivedAt" ON "incomes" USING BTREE (
+	"ledgerId",
+	"receivedAt"
+);
+
+CREATE TABLE "budgets" (
+	"budgetId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"ledgerId" int4 NOT NULL,
+	"categoryId" int4 NOT NULL,
+	"amount" int8 NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"updatedAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("budgetId")
+);
+
+CREATE TABLE "recurringExpenses" (
+	"recurringExpenseId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"ledgerId" int4 NOT NULL,
+	"categoryId" int4,
+	"amount" int8 NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	"description" text NOT NULL,
+	"dayOfMonth" int4 NOT NULL,
+	"nextRunAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("recurringExpenseId")
+);
+
+CREATE TABLE "exchangeRates" (
+	"exchangeRateId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"date" date NOT NULL,
+	"currency" varchar(12) NOT NULL,
+	"rate" float8 NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("exchangeRateId")
+);
+
+CREATE UNIQUE INDEX "UX_exchangeRates_date_currency" ON "exchangeRates" USING BTREE (
+	"date",
+	"currency"
+);
+
+CREATE TABLE "userStates" (
+	"userStateId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"telegramId" int8 NOT NULL,
+	"chatId" int8 NOT NULL,
+	"data" jsonb NOT NULL,
+	"expiresAt" timestamp with time zone NOT NULL,
+	PRIMARY KEY("userStateId")
+);
+
+CREATE UNIQUE INDEX "UX_userStates_telegramId_chatId" ON "userStates" USING BTREE (
+	"telegramId",
+	"chatId"
+);
+
+CREATE INDEX "IX_userStates_expiresAt" ON "userStates" USING BTREE (
+	"expiresAt"
+);
+
+
+CREATE TABLE "ledgers" (
+	"ledgerId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"title" varchar(255) NOT NULL,
+	"telegramChatId" int8 NOT NULL,
+	"inviteCode" varchar(32) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("ledgerId")
+);
+
+CREATE UNIQUE INDEX "UX_ledgers_telegramChatId" ON "ledgers" USING BTREE (
+	"telegramChatId"
+);
+
+CREATE UNIQUE INDEX "UX_ledgers_inviteCode" ON "ledgers" USING BTREE (
+	"inviteCode"
+);
+
+CREATE TABLE "ledgerMembers" (
+	"ledgerMemberId" int4 NOT NULL GENERATED ALWAYS AS IDENTITY,
+	"ledgerId" int4 NOT NULL,
+	"userId" int4 NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("ledgerMemberId")
+);
+
+CREATE UNIQUE INDEX "UX_ledgerMembers_ledgerId_userId" ON "ledgerMembers" USING BTREE (
+	"ledgerId",
+	"userId"
+);
+
 
 ALTER TABLE "users" ADD CONSTRAINT "FK_users_statusId" FOREIGN KEY ("statusId")
 	REFERENCES "statuses"("statusId")
@@ -100,4 +239,135 @@ ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_users" FOREIGN KEY ("
 	ON UPDATE NO ACTION
 	NOT DEFERRABLE;
 
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
 
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_categories" FOREIGN KEY ("categoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_categories" FOREIGN KEY ("categoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_categories" FOREIGN KEY ("categoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "ledgers" ADD CONSTRAINT "Ref_ledgers_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "ledgerMembers" ADD CONSTRAINT "Ref_ledgerMembers_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "ledgerMembers" ADD CONSTRAINT "Ref_ledgerMembers_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "users" ADD CONSTRAINT "Ref_users_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_categories" FOREIGN KEY ("parentId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "expenses" ADD CONSTRAINT "Ref_expenses_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "incomes" ADD CONSTRAINT "Ref_incomes_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "Ref_budgets_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "recurringExpenses" ADD CONSTRAINT "Ref_recurringExpenses_to_ledgers" FOREIGN KEY ("ledgerId")
+	REFERENCES "ledgers"("ledgerId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
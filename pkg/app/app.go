@@ -6,6 +6,7 @@ import (
 
 	"saldo/pkg/db"
 	"saldo/pkg/saldo"
+	"saldo/pkg/services"
 	"saldo/pkg/telegram"
 
 	"github.com/go-pg/pg/v10"
@@ -33,6 +34,12 @@ type Config struct {
 	Groq struct {
 		Token string
 	}
+	Rates struct {
+		URL string
+	}
+	Import struct {
+		CSV []services.CSVMapping
+	}
 }
 
 type App struct {
@@ -55,12 +62,17 @@ func New(ctx context.Context, appName string, sl embedlog.Logger, cfg Config, db
 	}
 
 	if cfg.Telegram.Token != "" {
-		saldoService := saldo.NewManager(dbc, sl)
+		var emoji services.EmojiPicker
+		if cfg.Groq.Token != "" {
+			emoji = saldo.NewGroq(cfg.Groq.Token)
+		}
+		saldoService := saldo.NewManager(dbc, sl, saldo.NewCBR(cfg.Rates.URL), emoji)
 
 		tgBot, err := telegram.New(ctx, telegram.Config{
-			Token:     cfg.Telegram.Token,
-			Debug:     cfg.Telegram.Debug,
-			GroqToken: cfg.Groq.Token,
+			Token:       cfg.Telegram.Token,
+			Debug:       cfg.Telegram.Debug,
+			GroqToken:   cfg.Groq.Token,
+			CSVMappings: cfg.Import.CSV,
 		}, saldoService, sl)
 		if err != nil {
 			return nil, err
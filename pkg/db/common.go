@@ -21,19 +21,37 @@ func NewCommonRepo(db orm.DB) CommonRepo {
 	return CommonRepo{
 		db: db,
 		filters: map[string][]Filter{
-			Tables.User.Name:     {StatusFilter},
-			Tables.Category.Name: {StatusFilter},
-			Tables.Expense.Name:  {StatusFilter},
+			Tables.User.Name:             {StatusFilter},
+			Tables.Category.Name:         {StatusFilter},
+			Tables.Expense.Name:          {StatusFilter},
+			Tables.Income.Name:           {StatusFilter},
+			Tables.Budget.Name:           {StatusFilter},
+			Tables.RecurringExpense.Name: {StatusFilter},
+			Tables.Ledger.Name:           {StatusFilter},
 		},
 		sort: map[string][]SortField{
-			Tables.User.Name:     {{Column: Columns.User.CreatedAt, Direction: SortDesc}},
-			Tables.Category.Name: {{Column: Columns.Category.CreatedAt, Direction: SortDesc}},
-			Tables.Expense.Name:  {{Column: Columns.Expense.CreatedAt, Direction: SortDesc}},
+			Tables.User.Name:             {{Column: Columns.User.CreatedAt, Direction: SortDesc}},
+			Tables.Category.Name:         {{Column: Columns.Category.CreatedAt, Direction: SortDesc}},
+			Tables.Expense.Name:          {{Column: Columns.Expense.CreatedAt, Direction: SortDesc}},
+			Tables.Income.Name:           {{Column: Columns.Income.CreatedAt, Direction: SortDesc}},
+			Tables.Budget.Name:           {{Column: Columns.Budget.CreatedAt, Direction: SortDesc}},
+			Tables.RecurringExpense.Name: {{Column: Columns.RecurringExpense.CreatedAt, Direction: SortDesc}},
+			Tables.ExchangeRate.Name:     {{Column: Columns.ExchangeRate.CreatedAt, Direction: SortDesc}},
+			Tables.UserState.Name:        {{Column: Columns.UserState.ID, Direction: SortDesc}},
+			Tables.Ledger.Name:           {{Column: Columns.Ledger.CreatedAt, Direction: SortDesc}},
+			Tables.LedgerMember.Name:     {{Column: Columns.LedgerMember.CreatedAt, Direction: SortDesc}},
 		},
 		join: map[string][]string{
-			Tables.User.Name:     {TableColumns},
-			Tables.Category.Name: {TableColumns, Columns.Category.User},
-			Tables.Expense.Name:  {TableColumns, Columns.Expense.User, Columns.Expense.Category},
+			Tables.User.Name:             {TableColumns},
+			Tables.Category.Name:         {TableColumns, Columns.Category.User, Columns.Category.Parent},
+			Tables.Expense.Name:          {TableColumns, Columns.Expense.User, Columns.Expense.Category},
+			Tables.Income.Name:           {TableColumns, Columns.Income.User, Columns.Income.Category},
+			Tables.Budget.Name:           {TableColumns, Columns.Budget.User, Columns.Budget.Category},
+			Tables.RecurringExpense.Name: {TableColumns, Columns.RecurringExpense.User, Columns.RecurringExpense.Category},
+			Tables.ExchangeRate.Name:     {TableColumns},
+			Tables.UserState.Name:        {TableColumns},
+			Tables.Ledger.Name:           {TableColumns},
+			Tables.LedgerMember.Name:     {TableColumns, Columns.LedgerMember.Ledger, Columns.LedgerMember.User},
 		},
 	}
 }
@@ -284,3 +302,547 @@ func (cr CommonRepo) DeleteExpense(ctx context.Context, id int) (deleted bool, e
 
 	return cr.UpdateExpense(ctx, expense, WithColumns(Columns.Expense.StatusID))
 }
+
+/*** Income ***/
+
+// FullIncome returns full joins with all columns
+func (cr CommonRepo) FullIncome() OpFunc {
+	return WithColumns(cr.join[Tables.Income.Name]...)
+}
+
+// DefaultIncomeSort returns default sort.
+func (cr CommonRepo) DefaultIncomeSort() OpFunc {
+	return WithSort(cr.sort[Tables.Income.Name]...)
+}
+
+// IncomeByID is a function that returns Income by ID(s) or nil.
+func (cr CommonRepo) IncomeByID(ctx context.Context, id int, ops ...OpFunc) (*Income, error) {
+	return cr.OneIncome(ctx, &IncomeSearch{ID: &id}, ops...)
+}
+
+// OneIncome is a function that returns one Income by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneIncome(ctx context.Context, search *IncomeSearch, ops ...OpFunc) (*Income, error) {
+	obj := &Income{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.Income.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// IncomesByFilters returns Income list.
+func (cr CommonRepo) IncomesByFilters(ctx context.Context, search *IncomeSearch, pager Pager, ops ...OpFunc) (incomes []Income, err error) {
+	err = buildQuery(ctx, cr.db, &incomes, search, cr.filters[Tables.Income.Name], pager, ops...).Select()
+	return
+}
+
+// CountIncomes returns count
+func (cr CommonRepo) CountIncomes(ctx context.Context, search *IncomeSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &Income{}, search, cr.filters[Tables.Income.Name], PagerOne, ops...).Count()
+}
+
+// AddIncome adds Income to DB.
+func (cr CommonRepo) AddIncome(ctx context.Context, income *Income, ops ...OpFunc) (*Income, error) {
+	q := cr.db.ModelContext(ctx, income)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Income.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return income, err
+}
+
+// UpdateIncome updates Income in DB.
+func (cr CommonRepo) UpdateIncome(ctx context.Context, income *Income, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, income).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Income.ID, Columns.Income.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteIncome set statusId to deleted in DB.
+func (cr CommonRepo) DeleteIncome(ctx context.Context, id int) (deleted bool, err error) {
+	income := &Income{ID: id, StatusID: StatusDeleted}
+
+	return cr.UpdateIncome(ctx, income, WithColumns(Columns.Income.StatusID))
+}
+
+/*** Budget ***/
+
+// FullBudget returns full joins with all columns
+func (cr CommonRepo) FullBudget() OpFunc {
+	return WithColumns(cr.join[Tables.Budget.Name]...)
+}
+
+// DefaultBudgetSort returns default sort.
+func (cr CommonRepo) DefaultBudgetSort() OpFunc {
+	return WithSort(cr.sort[Tables.Budget.Name]...)
+}
+
+// BudgetByID is a function that returns Budget by ID(s) or nil.
+func (cr CommonRepo) BudgetByID(ctx context.Context, id int, ops ...OpFunc) (*Budget, error) {
+	return cr.OneBudget(ctx, &BudgetSearch{ID: &id}, ops...)
+}
+
+// OneBudget is a function that returns one Budget by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneBudget(ctx context.Context, search *BudgetSearch, ops ...OpFunc) (*Budget, error) {
+	obj := &Budget{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.Budget.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// BudgetsByFilters returns Budget list.
+func (cr CommonRepo) BudgetsByFilters(ctx context.Context, search *BudgetSearch, pager Pager, ops ...OpFunc) (budgets []Budget, err error) {
+	err = buildQuery(ctx, cr.db, &budgets, search, cr.filters[Tables.Budget.Name], pager, ops...).Select()
+	return
+}
+
+// CountBudgets returns count
+func (cr CommonRepo) CountBudgets(ctx context.Context, search *BudgetSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &Budget{}, search, cr.filters[Tables.Budget.Name], PagerOne, ops...).Count()
+}
+
+// AddBudget adds Budget to DB.
+func (cr CommonRepo) AddBudget(ctx context.Context, budget *Budget, ops ...OpFunc) (*Budget, error) {
+	q := cr.db.ModelContext(ctx, budget)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Budget.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return budget, err
+}
+
+// UpdateBudget updates Budget in DB.
+func (cr CommonRepo) UpdateBudget(ctx context.Context, budget *Budget, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, budget).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Budget.ID, Columns.Budget.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteBudget set statusId to deleted in DB.
+func (cr CommonRepo) DeleteBudget(ctx context.Context, id int) (deleted bool, err error) {
+	budget := &Budget{ID: id, StatusID: StatusDeleted}
+
+	return cr.UpdateBudget(ctx, budget, WithColumns(Columns.Budget.StatusID))
+}
+
+/*** RecurringExpense ***/
+
+// FullRecurringExpense returns full joins with all columns
+func (cr CommonRepo) FullRecurringExpense() OpFunc {
+	return WithColumns(cr.join[Tables.RecurringExpense.Name]...)
+}
+
+// DefaultRecurringExpenseSort returns default sort.
+func (cr CommonRepo) DefaultRecurringExpenseSort() OpFunc {
+	return WithSort(cr.sort[Tables.RecurringExpense.Name]...)
+}
+
+// RecurringExpenseByID is a function that returns RecurringExpense by ID(s) or nil.
+func (cr CommonRepo) RecurringExpenseByID(ctx context.Context, id int, ops ...OpFunc) (*RecurringExpense, error) {
+	return cr.OneRecurringExpense(ctx, &RecurringExpenseSearch{ID: &id}, ops...)
+}
+
+// OneRecurringExpense is a function that returns one RecurringExpense by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneRecurringExpense(ctx context.Context, search *RecurringExpenseSearch, ops ...OpFunc) (*RecurringExpense, error) {
+	obj := &RecurringExpense{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.RecurringExpense.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// RecurringExpensesByFilters returns RecurringExpense list.
+func (cr CommonRepo) RecurringExpensesByFilters(ctx context.Context, search *RecurringExpenseSearch, pager Pager, ops ...OpFunc) (recurringExpenses []RecurringExpense, err error) {
+	err = buildQuery(ctx, cr.db, &recurringExpenses, search, cr.filters[Tables.RecurringExpense.Name], pager, ops...).Select()
+	return
+}
+
+// CountRecurringExpenses returns count
+func (cr CommonRepo) CountRecurringExpenses(ctx context.Context, search *RecurringExpenseSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &RecurringExpense{}, search, cr.filters[Tables.RecurringExpense.Name], PagerOne, ops...).Count()
+}
+
+// AddRecurringExpense adds RecurringExpense to DB.
+func (cr CommonRepo) AddRecurringExpense(ctx context.Context, recurringExpense *RecurringExpense, ops ...OpFunc) (*RecurringExpense, error) {
+	q := cr.db.ModelContext(ctx, recurringExpense)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.RecurringExpense.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return recurringExpense, err
+}
+
+// UpdateRecurringExpense updates RecurringExpense in DB.
+func (cr CommonRepo) UpdateRecurringExpense(ctx context.Context, recurringExpense *RecurringExpense, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, recurringExpense).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.RecurringExpense.ID, Columns.RecurringExpense.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteRecurringExpense set statusId to deleted in DB.
+func (cr CommonRepo) DeleteRecurringExpense(ctx context.Context, id int) (deleted bool, err error) {
+	recurringExpense := &RecurringExpense{ID: id, StatusID: StatusDeleted}
+
+	return cr.UpdateRecurringExpense(ctx, recurringExpense, WithColumns(Columns.RecurringExpense.StatusID))
+}
+
+/*** ExchangeRate ***/
+
+// FullExchangeRate returns full joins with all columns
+func (cr CommonRepo) FullExchangeRate() OpFunc {
+	return WithColumns(cr.join[Tables.ExchangeRate.Name]...)
+}
+
+// DefaultExchangeRateSort returns default sort.
+func (cr CommonRepo) DefaultExchangeRateSort() OpFunc {
+	return WithSort(cr.sort[Tables.ExchangeRate.Name]...)
+}
+
+// ExchangeRateByID is a function that returns ExchangeRate by ID(s) or nil.
+func (cr CommonRepo) ExchangeRateByID(ctx context.Context, id int, ops ...OpFunc) (*ExchangeRate, error) {
+	return cr.OneExchangeRate(ctx, &ExchangeRateSearch{ID: &id}, ops...)
+}
+
+// OneExchangeRate is a function that returns one ExchangeRate by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneExchangeRate(ctx context.Context, search *ExchangeRateSearch, ops ...OpFunc) (*ExchangeRate, error) {
+	obj := &ExchangeRate{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.ExchangeRate.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// ExchangeRatesByFilters returns ExchangeRate list.
+func (cr CommonRepo) ExchangeRatesByFilters(ctx context.Context, search *ExchangeRateSearch, pager Pager, ops ...OpFunc) (exchangeRates []ExchangeRate, err error) {
+	err = buildQuery(ctx, cr.db, &exchangeRates, search, cr.filters[Tables.ExchangeRate.Name], pager, ops...).Select()
+	return
+}
+
+// CountExchangeRates returns count
+func (cr CommonRepo) CountExchangeRates(ctx context.Context, search *ExchangeRateSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &ExchangeRate{}, search, cr.filters[Tables.ExchangeRate.Name], PagerOne, ops...).Count()
+}
+
+// AddExchangeRate adds ExchangeRate to DB.
+func (cr CommonRepo) AddExchangeRate(ctx context.Context, exchangeRate *ExchangeRate, ops ...OpFunc) (*ExchangeRate, error) {
+	q := cr.db.ModelContext(ctx, exchangeRate)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.ExchangeRate.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return exchangeRate, err
+}
+
+// UpdateExchangeRate updates ExchangeRate in DB.
+func (cr CommonRepo) UpdateExchangeRate(ctx context.Context, exchangeRate *ExchangeRate, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, exchangeRate).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.ExchangeRate.ID, Columns.ExchangeRate.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteExchangeRate deletes ExchangeRate from DB.
+func (cr CommonRepo) DeleteExchangeRate(ctx context.Context, id int) (deleted bool, err error) {
+	exchangeRate := &ExchangeRate{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, exchangeRate).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** UserState ***/
+
+// FullUserState returns full joins with all columns
+func (cr CommonRepo) FullUserState() OpFunc {
+	return WithColumns(cr.join[Tables.UserState.Name]...)
+}
+
+// DefaultUserStateSort returns default sort.
+func (cr CommonRepo) DefaultUserStateSort() OpFunc {
+	return WithSort(cr.sort[Tables.UserState.Name]...)
+}
+
+// UserStateByID is a function that returns UserState by ID(s) or nil.
+func (cr CommonRepo) UserStateByID(ctx context.Context, id int, ops ...OpFunc) (*UserState, error) {
+	return cr.OneUserState(ctx, &UserStateSearch{ID: &id}, ops...)
+}
+
+// OneUserState is a function that returns one UserState by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneUserState(ctx context.Context, search *UserStateSearch, ops ...OpFunc) (*UserState, error) {
+	obj := &UserState{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.UserState.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// UserStatesByFilters returns UserState list.
+func (cr CommonRepo) UserStatesByFilters(ctx context.Context, search *UserStateSearch, pager Pager, ops ...OpFunc) (userStates []UserState, err error) {
+	err = buildQuery(ctx, cr.db, &userStates, search, cr.filters[Tables.UserState.Name], pager, ops...).Select()
+	return
+}
+
+// CountUserStates returns count
+func (cr CommonRepo) CountUserStates(ctx context.Context, search *UserStateSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &UserState{}, search, cr.filters[Tables.UserState.Name], PagerOne, ops...).Count()
+}
+
+// AddUserState adds UserState to DB.
+func (cr CommonRepo) AddUserState(ctx context.Context, userState *UserState, ops ...OpFunc) (*UserState, error) {
+	q := cr.db.ModelContext(ctx, userState)
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return userState, err
+}
+
+// UpdateUserState updates UserState in DB.
+func (cr CommonRepo) UpdateUserState(ctx context.Context, userState *UserState, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, userState).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.UserState.ID)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteUserState deletes UserState from DB.
+func (cr CommonRepo) DeleteUserState(ctx context.Context, id int) (deleted bool, err error) {
+	userState := &UserState{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, userState).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** Ledger ***/
+
+// FullLedger returns full joins with all columns
+func (cr CommonRepo) FullLedger() OpFunc {
+	return WithColumns(cr.join[Tables.Ledger.Name]...)
+}
+
+// DefaultLedgerSort returns default sort.
+func (cr CommonRepo) DefaultLedgerSort() OpFunc {
+	return WithSort(cr.sort[Tables.Ledger.Name]...)
+}
+
+// LedgerByID is a function that returns Ledger by ID(s) or nil.
+func (cr CommonRepo) LedgerByID(ctx context.Context, id int, ops ...OpFunc) (*Ledger, error) {
+	return cr.OneLedger(ctx, &LedgerSearch{ID: &id}, ops...)
+}
+
+// OneLedger is a function that returns one Ledger by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneLedger(ctx context.Context, search *LedgerSearch, ops ...OpFunc) (*Ledger, error) {
+	obj := &Ledger{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.Ledger.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// LedgersByFilters returns Ledger list.
+func (cr CommonRepo) LedgersByFilters(ctx context.Context, search *LedgerSearch, pager Pager, ops ...OpFunc) (ledgers []Ledger, err error) {
+	err = buildQuery(ctx, cr.db, &ledgers, search, cr.filters[Tables.Ledger.Name], pager, ops...).Select()
+	return
+}
+
+// CountLedgers returns count
+func (cr CommonRepo) CountLedgers(ctx context.Context, search *LedgerSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &Ledger{}, search, cr.filters[Tables.Ledger.Name], PagerOne, ops...).Count()
+}
+
+// AddLedger adds Ledger to DB.
+func (cr CommonRepo) AddLedger(ctx context.Context, ledger *Ledger, ops ...OpFunc) (*Ledger, error) {
+	q := cr.db.ModelContext(ctx, ledger)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Ledger.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return ledger, err
+}
+
+// UpdateLedger updates Ledger in DB.
+func (cr CommonRepo) UpdateLedger(ctx context.Context, ledger *Ledger, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, ledger).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Ledger.ID, Columns.Ledger.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteLedger set statusId to deleted in DB.
+func (cr CommonRepo) DeleteLedger(ctx context.Context, id int) (deleted bool, err error) {
+	ledger := &Ledger{ID: id, StatusID: StatusDeleted}
+
+	return cr.UpdateLedger(ctx, ledger, WithColumns(Columns.Ledger.StatusID))
+}
+
+/*** LedgerMember ***/
+
+// FullLedgerMember returns full joins with all columns
+func (cr CommonRepo) FullLedgerMember() OpFunc {
+	return WithColumns(cr.join[Tables.LedgerMember.Name]...)
+}
+
+// DefaultLedgerMemberSort returns default sort.
+func (cr CommonRepo) DefaultLedgerMemberSort() OpFunc {
+	return WithSort(cr.sort[Tables.LedgerMember.Name]...)
+}
+
+// LedgerMemberByID is a function that returns LedgerMember by ID(s) or nil.
+func (cr CommonRepo) LedgerMemberByID(ctx context.Context, id int, ops ...OpFunc) (*LedgerMember, error) {
+	return cr.OneLedgerMember(ctx, &LedgerMemberSearch{ID: &id}, ops...)
+}
+
+// OneLedgerMember is a function that returns one LedgerMember by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneLedgerMember(ctx context.Context, search *LedgerMemberSearch, ops ...OpFunc) (*LedgerMember, error) {
+	obj := &LedgerMember{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.LedgerMember.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// LedgerMembersByFilters returns LedgerMember list.
+func (cr CommonRepo) LedgerMembersByFilters(ctx context.Context, search *LedgerMemberSearch, pager Pager, ops ...OpFunc) (ledgerMembers []LedgerMember, err error) {
+	err = buildQuery(ctx, cr.db, &ledgerMembers, search, cr.filters[Tables.LedgerMember.Name], pager, ops...).Select()
+	return
+}
+
+// CountLedgerMembers returns count
+func (cr CommonRepo) CountLedgerMembers(ctx context.Context, search *LedgerMemberSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &LedgerMember{}, search, cr.filters[Tables.LedgerMember.Name], PagerOne, ops...).Count()
+}
+
+// AddLedgerMember adds LedgerMember to DB.
+func (cr CommonRepo) AddLedgerMember(ctx context.Context, ledgerMember *LedgerMember, ops ...OpFunc) (*LedgerMember, error) {
+	q := cr.db.ModelContext(ctx, ledgerMember)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.LedgerMember.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return ledgerMember, err
+}
+
+// UpdateLedgerMember updates LedgerMember in DB.
+func (cr CommonRepo) UpdateLedgerMember(ctx context.Context, ledgerMember *LedgerMember, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, ledgerMember).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.LedgerMember.ID, Columns.LedgerMember.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteLedgerMember deletes LedgerMember from DB.
+func (cr CommonRepo) DeleteLedgerMember(ctx context.Context, id int) (deleted bool, err error) {
+	ledgerMember := &LedgerMember{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, ledgerMember).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
@@ -3,8 +3,166 @@ package db
 import (
 	"context"
 	"time"
+
+	"github.com/go-pg/pg/v10"
 )
 
+var StatusDeletedFilter = Filter{Field: "statusId", Value: []int{StatusDeleted}, SearchType: SearchTypeArray}
+
+// WithDeletedOnly is a function that replaces base filters with "statusId"=3 filter.
+func (cr CommonRepo) WithDeletedOnly() CommonRepo {
+	f := make(map[string][]Filter, len(cr.filters))
+	for i := range cr.filters {
+		f[i] = []Filter{StatusDeletedFilter}
+	}
+	cr.filters = f
+
+	return cr
+}
+
+// SumExpenses returns total amount of expenses found by search.
+func (cr CommonRepo) SumExpenses(ctx context.Context, search *ExpenseSearch) (sum int64, err error) {
+	err = buildQuery(ctx, cr.db, &Expense{}, search, cr.filters[Tables.Expense.Name], PagerOne).
+		ColumnExpr("coalesce(sum(?), 0)", pg.Ident(TablePrefix+"."+Columns.Expense.Amount)).
+		Select(pg.Scan(&sum))
+	return
+}
+
+// ExpenseTotal is sum of expenses of one category in one currency spent in one day.
+type ExpenseTotal struct {
+	CategoryID *int      `pg:"categoryId"`
+	Currency   string    `pg:"currency"`
+	Day        time.Time `pg:"day"` // date in time zone of totals, at midnight UTC
+	Amount     int64     `pg:"amount"`
+	Count      int       `pg:"count"`
+}
+
+// SumExpensesByCategory returns totals of expenses found by search grouped by category, currency and day in timezone.
+func (cr CommonRepo) SumExpensesByCategory(ctx context.Context, search *ExpenseSearch, timezone string) ([]ExpenseTotal, error) {
+	var totals []ExpenseTotal
+
+	category := pg.Ident(TablePrefix + "." + Columns.Expense.CategoryID)
+	currency := pg.Ident(TablePrefix + "." + Columns.Expense.Currency)
+	day := pg.SafeQuery("(? AT TIME ZONE ?)::date", pg.Ident(TablePrefix+"."+Columns.Expense.SpentAt), timezone)
+	err := buildQuery(ctx, cr.db, &Expense{}, search, cr.filters[Tables.Expense.Name], PagerNoLimit).
+		ColumnExpr("? AS ?, ? AS ?, ? AS ?", category, pg.Ident(Columns.Expense.CategoryID), currency, pg.Ident(Columns.Expense.Currency), day, pg.Ident("day")).
+		ColumnExpr("sum(?) AS ?, count(*) AS ?", pg.Ident(TablePrefix+"."+Columns.Expense.Amount), pg.Ident(Columns.Expense.Amount), pg.Ident("count")).
+		GroupExpr("?, ?, ?", category, currency, day).
+		Select(&totals)
+
+	return totals, err
+}
+
+// ExpenseMedian is median amount of expenses of one category in one currency.
+type ExpenseMedian struct {
+	CategoryID int    `pg:"categoryId"`
+	Currency   string `pg:"currency"`
+	Median     int64  `pg:"median"`
+	Count      int    `pg:"count"`
+}
+
+// MedianExpensesByCategory returns median amounts of expenses found by search grouped by category and currency.
+// Expenses without category are skipped.
+func (cr CommonRepo) MedianExpensesByCategory(ctx context.Context, search *ExpenseSearch) ([]ExpenseMedian, error) {
+	var medians []ExpenseMedian
+
+	category := pg.Ident(TablePrefix + "." + Columns.Expense.CategoryID)
+	currency := pg.Ident(TablePrefix + "." + Columns.Expense.Currency)
+	err := buildQuery(ctx, cr.db, &Expense{}, search, cr.filters[Tables.Expense.Name], PagerNoLimit).
+		ColumnExpr("? AS ?, ? AS ?", category, pg.Ident(Columns.Expense.CategoryID), currency, pg.Ident(Columns.Expense.Currency)).
+		ColumnExpr("round(percentile_cont(0.5) WITHIN GROUP (ORDER BY ?))::int8 AS ?, count(*) AS ?",
+			pg.Ident(TablePrefix+"."+Columns.Expense.Amount), pg.Ident("median"), pg.Ident("count")).
+		Where("? IS NOT NULL", category).
+		GroupExpr("?, ?", category, currency).
+		Select(&medians)
+
+	return medians, err
+}
+
+// CountExpensesByCategory returns number of expenses found by search for each category.
+func (cr CommonRepo) CountExpensesByCategory(ctx context.Context, search *ExpenseSearch) (map[int]int, error) {
+	var rows []struct {
+		CategoryID int `pg:"categoryId"`
+		Count      int `pg:"count"`
+	}
+
+	column := pg.Ident(TablePrefix + "." + Columns.Expense.CategoryID)
+	err := buildQuery(ctx, cr.db, &Expense{}, search, cr.filters[Tables.Expense.Name], PagerNoLimit).
+		ColumnExpr("? AS ?, count(*) AS ?", column, pg.Ident(Columns.Expense.CategoryID), pg.Ident("count")).
+		Where("? IS NOT NULL", column).
+		GroupExpr("?", column).
+		Select(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+// CountExpensesByTag returns number of expenses found by search for each of their tags.
+func (cr CommonRepo) CountExpensesByTag(ctx context.Context, search *ExpenseSearch) (map[string]int, error) {
+	var rows []struct {
+		Tag   string `pg:"tag"`
+		Count int    `pg:"count"`
+	}
+
+	err := buildQuery(ctx, cr.db, &Expense{}, search, cr.filters[Tables.Expense.Name], PagerNoLimit).
+		ColumnExpr("unnest(?) AS ?, count(*) AS ?", pg.Ident(TablePrefix+"."+Columns.Expense.Tags), pg.Ident("tag"), pg.Ident("count")).
+		GroupExpr("?", pg.Ident("tag")).
+		Select(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Tag] = row.Count
+	}
+	return counts, nil
+}
+
+// ReassignCategory moves expenses, incomes and recurring expenses of any status from one category to another,
+// nil category leaves them without category. Returns number of moved expenses.
+func (cr CommonRepo) ReassignCategory(ctx context.Context, fromID int, toID *int) (int, error) {
+	res, err := cr.db.ModelContext(ctx, (*Expense)(nil)).
+		Set("? = ?", pg.Ident(Columns.Expense.CategoryID), toID).
+		Where("? = ?", pg.Ident(Columns.Expense.CategoryID), fromID).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := cr.db.ModelContext(ctx, (*Income)(nil)).
+		Set("? = ?", pg.Ident(Columns.Income.CategoryID), toID).
+		Where("? = ?", pg.Ident(Columns.Income.CategoryID), fromID).
+		Update(); err != nil {
+		return 0, err
+	}
+
+	if _, err := cr.db.ModelContext(ctx, (*RecurringExpense)(nil)).
+		Set("? = ?", pg.Ident(Columns.RecurringExpense.CategoryID), toID).
+		Where("? = ?", pg.Ident(Columns.RecurringExpense.CategoryID), fromID).
+		Update(); err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// ReparentCategories moves sub-categories of any status from one parent category to another,
+// nil parent makes them top level categories.
+func (cr CommonRepo) ReparentCategories(ctx context.Context, fromID int, toID *int) error {
+	_, err := cr.db.ModelContext(ctx, (*Category)(nil)).
+		Set("? = ?", pg.Ident(Columns.Category.ParentID), toID).
+		Where("? = ?", pg.Ident(Columns.Category.ParentID), fromID).
+		Update()
+	return err
+}
+
 // AuthenticateUser update authKey and last activity while user login/logout
 func (cr CommonRepo) AuthenticateUser(ctx context.Context, dbu *User, authKey string) (bool, error) {
 	dbu.AuthKey = authKey
@@ -32,3 +190,35 @@ func (cr CommonRepo) EnabledUserByLogin(ctx context.Context, login string) (*Use
 func (cr CommonRepo) UpdateUserPassword(ctx context.Context, dbu *User) (bool, error) {
 	return cr.UpdateUser(ctx, dbu, WithColumns(Columns.User.Password, Columns.User.AuthKey))
 }
+
+// DeleteUserStateByTelegramID deletes conversation state of telegram user in chat.
+func (cr CommonRepo) DeleteUserStateByTelegramID(ctx context.Context, telegramID, chatID int64) error {
+	_, err := cr.db.ModelContext(ctx, (*UserState)(nil)).
+		Where("? = ?", pg.Ident(Columns.UserState.TelegramID), telegramID).
+		Where("? = ?", pg.Ident(Columns.UserState.ChatID), chatID).
+		Delete()
+	return err
+}
+
+// DeleteExpiredUserStates deletes conversation states expired before given time and returns their count.
+func (cr CommonRepo) DeleteExpiredUserStates(ctx context.Context, before time.Time) (int, error) {
+	res, err := cr.db.ModelContext(ctx, (*UserState)(nil)).
+		Where("? < ?", pg.Ident(Columns.UserState.ExpiresAt), before).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+// AddExchangeRates inserts exchange rates, rates already stored for the same date and currency are kept.
+func (cr CommonRepo) AddExchangeRates(ctx context.Context, rates []ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	_, err := cr.db.ModelContext(ctx, &rates).
+		ExcludeColumn(Columns.ExchangeRate.CreatedAt).
+		OnConflict("DO NOTHING").
+		Insert()
+	return err
+}
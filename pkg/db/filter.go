@@ -23,8 +23,12 @@ const (
 	SearchTypeArrayContained
 	SearchTypeArrayIntersect
 	SearchTypeJsonbPath
+	SearchTypeFullText
 )
 
+// FullTextConfig is text search configuration of full-text search, it stems Russian words and English ones
+const FullTextConfig = "russian"
+
 var formatter = orm.Formatter{}
 
 var searchTypes = map[bool]map[int]string{
@@ -43,6 +47,7 @@ var searchTypes = map[bool]map[int]string{
 		SearchTypeArrayContained: "ARRAY[?] <@",
 		SearchTypeArrayIntersect: "ARRAY[?] &&",
 		SearchTypeJsonbPath:      "@> ?",
+		SearchTypeFullText:       "@@ websearch_to_tsquery(?, ?)",
 	},
 	// exclude
 	true: {
@@ -110,6 +115,8 @@ func (f Filter) prepare() (field, value types.ValueAppender) {
 	case SearchTypeArrayContained, SearchTypeArrayIntersect:
 		f.Value = pg.In(f.Value)
 		return pg.SafeQuery(st, f.Value), pg.SafeQuery("?", pg.Ident(f.Field))
+	case SearchTypeFullText:
+		return pg.SafeQuery("to_tsvector(?, ?)", FullTextConfig, pg.Ident(f.Field)), pg.SafeQuery(st, FullTextConfig, f.Value)
 	}
 
 	return pg.Ident(f.Field), pg.SafeQuery(st, f.Value)
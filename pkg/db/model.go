@@ -10,21 +10,50 @@ import (
 
 var Columns = struct {
 	User struct {
-		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID, TelegramID, TelegramUsername, TeleramFirstName, TelegramLastName string
+		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID, TelegramID, TelegramUsername, TeleramFirstName, TelegramLastName, BaseCurrency, Language, Timezone, DefaultCurrency, WeekStart, LedgerID, WeeklyDigestAt, MonthlyDigestAt string
 	}
 	Category struct {
-		ID, UserID, Title, Alias, CreatedAt, UpdatedAt, StatusID, Emoji string
+		ID, UserID, LedgerID, Title, Alias, CreatedAt, UpdatedAt, StatusID, Emoji, Kind, ParentID string
 
-		User string
+		User, Parent string
 	}
 	Expense struct {
-		ID, UserID, CategoryID, Amount, Description, CreatedAt, UpdatedAt, StatusID, Currency string
+		ID, UserID, LedgerID, CategoryID, Amount, Description, CreatedAt, UpdatedAt, StatusID, Currency, SpentAt, Tags string
 
 		User, Category string
 	}
+	Income struct {
+		ID, UserID, LedgerID, CategoryID, Amount, Description, CreatedAt, UpdatedAt, StatusID, Currency, ReceivedAt string
+
+		User, Category string
+	}
+	Budget struct {
+		ID, UserID, LedgerID, CategoryID, Amount, Currency, CreatedAt, UpdatedAt, StatusID string
+
+		User, Category string
+	}
+	RecurringExpense struct {
+		ID, UserID, LedgerID, CategoryID, Amount, Currency, Description, DayOfMonth, NextRunAt, CreatedAt, StatusID string
+
+		User, Category string
+	}
+	ExchangeRate struct {
+		ID, Date, Currency, Rate, CreatedAt string
+	}
+	UserState struct {
+		ID, TelegramID, ChatID, Data, ExpiresAt string
+	}
+	Ledger struct {
+		ID, Title, TelegramChatID, InviteCode, CreatedAt, StatusID string
+	}
+	LedgerMember struct {
+		ID, LedgerID, UserID, CreatedAt string
+
+		Ledger, User string
+	}
 }{
 	User: struct {
-		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID, TelegramID, TelegramUsername, TeleramFirstName, TelegramLastName string
+		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID, TelegramID, TelegramUsername, TeleramFirstName, TelegramLastName, BaseCurrency, Language, Timezone, DefaultCurrency, WeekStart, LedgerID, WeeklyDigestAt, MonthlyDigestAt string
 	}{
 		ID:               "userId",
 		CreatedAt:        "createdAt",
@@ -37,30 +66,43 @@ var Columns = struct {
 		TelegramUsername: "telegramUsername",
 		TeleramFirstName: "teleramFirstName",
 		TelegramLastName: "telegramLastName",
+		BaseCurrency:     "baseCurrency",
+		Language:         "language",
+		Timezone:         "timezone",
+		DefaultCurrency:  "defaultCurrency",
+		WeekStart:        "weekStart",
+		LedgerID:         "ledgerId",
+		WeeklyDigestAt:   "weeklyDigestAt",
+		MonthlyDigestAt:  "monthlyDigestAt",
 	},
 	Category: struct {
-		ID, UserID, Title, Alias, CreatedAt, UpdatedAt, StatusID, Emoji string
+		ID, UserID, LedgerID, Title, Alias, CreatedAt, UpdatedAt, StatusID, Emoji, Kind, ParentID string
 
-		User string
+		User, Parent string
 	}{
 		ID:        "categoryId",
 		UserID:    "userId",
+		LedgerID:  "ledgerId",
 		Title:     "title",
 		Alias:     "alias",
 		CreatedAt: "createdAt",
 		UpdatedAt: "updatedAt",
 		StatusID:  "statusId",
 		Emoji:     "emoji",
+		Kind:      "kind",
+		ParentID:  "parentId",
 
-		User: "User",
+		User:   "User",
+		Parent: "Parent",
 	},
 	Expense: struct {
-		ID, UserID, CategoryID, Amount, Description, CreatedAt, UpdatedAt, StatusID, Currency string
+		ID, UserID, LedgerID, CategoryID, Amount, Description, CreatedAt, UpdatedAt, StatusID, Currency, SpentAt, Tags string
 
 		User, Category string
 	}{
 		ID:          "expenseId",
 		UserID:      "userId",
+		LedgerID:    "ledgerId",
 		CategoryID:  "categoryId",
 		Amount:      "amount",
 		Description: "description",
@@ -68,10 +110,111 @@ var Columns = struct {
 		UpdatedAt:   "updatedAt",
 		StatusID:    "statusId",
 		Currency:    "currency",
+		SpentAt:     "spentAt",
+		Tags:        "tags",
 
 		User:     "User",
 		Category: "Category",
 	},
+	Income: struct {
+		ID, UserID, LedgerID, CategoryID, Amount, Description, CreatedAt, UpdatedAt, StatusID, Currency, ReceivedAt string
+
+		User, Category string
+	}{
+		ID:          "incomeId",
+		UserID:      "userId",
+		LedgerID:    "ledgerId",
+		CategoryID:  "categoryId",
+		Amount:      "amount",
+		Description: "description",
+		CreatedAt:   "createdAt",
+		UpdatedAt:   "updatedAt",
+		StatusID:    "statusId",
+		Currency:    "currency",
+		ReceivedAt:  "receivedAt",
+
+		User:     "User",
+		Category: "Category",
+	},
+	Budget: struct {
+		ID, UserID, LedgerID, CategoryID, Amount, Currency, CreatedAt, UpdatedAt, StatusID string
+
+		User, Category string
+	}{
+		ID:         "budgetId",
+		UserID:     "userId",
+		LedgerID:   "ledgerId",
+		CategoryID: "categoryId",
+		Amount:     "amount",
+		Currency:   "currency",
+		CreatedAt:  "createdAt",
+		UpdatedAt:  "updatedAt",
+		StatusID:   "statusId",
+
+		User:     "User",
+		Category: "Category",
+	},
+	RecurringExpense: struct {
+		ID, UserID, LedgerID, CategoryID, Amount, Currency, Description, DayOfMonth, NextRunAt, CreatedAt, StatusID string
+
+		User, Category string
+	}{
+		ID:          "recurringExpenseId",
+		UserID:      "userId",
+		LedgerID:    "ledgerId",
+		CategoryID:  "categoryId",
+		Amount:      "amount",
+		Currency:    "currency",
+		Description: "description",
+		DayOfMonth:  "dayOfMonth",
+		NextRunAt:   "nextRunAt",
+		CreatedAt:   "createdAt",
+		StatusID:    "statusId",
+
+		User:     "User",
+		Category: "Category",
+	},
+	ExchangeRate: struct {
+		ID, Date, Currency, Rate, CreatedAt string
+	}{
+		ID:        "exchangeRateId",
+		Date:      "date",
+		Currency:  "currency",
+		Rate:      "rate",
+		CreatedAt: "createdAt",
+	},
+	UserState: struct {
+		ID, TelegramID, ChatID, Data, ExpiresAt string
+	}{
+		ID:         "userStateId",
+		TelegramID: "telegramId",
+		ChatID:     "chatId",
+		Data:       "data",
+		ExpiresAt:  "expiresAt",
+	},
+	Ledger: struct {
+		ID, Title, TelegramChatID, InviteCode, CreatedAt, StatusID string
+	}{
+		ID:             "ledgerId",
+		Title:          "title",
+		TelegramChatID: "telegramChatId",
+		InviteCode:     "inviteCode",
+		CreatedAt:      "createdAt",
+		StatusID:       "statusId",
+	},
+	LedgerMember: struct {
+		ID, LedgerID, UserID, CreatedAt string
+
+		Ledger, User string
+	}{
+		ID:        "ledgerMemberId",
+		LedgerID:  "ledgerId",
+		UserID:    "userId",
+		CreatedAt: "createdAt",
+
+		Ledger: "Ledger",
+		User:   "User",
+	},
 }
 
 var Tables = struct {
@@ -84,6 +227,27 @@ var Tables = struct {
 	Expense struct {
 		Name, Alias string
 	}
+	Income struct {
+		Name, Alias string
+	}
+	Budget struct {
+		Name, Alias string
+	}
+	RecurringExpense struct {
+		Name, Alias string
+	}
+	ExchangeRate struct {
+		Name, Alias string
+	}
+	UserState struct {
+		Name, Alias string
+	}
+	Ledger struct {
+		Name, Alias string
+	}
+	LedgerMember struct {
+		Name, Alias string
+	}
 }{
 	User: struct {
 		Name, Alias string
@@ -103,6 +267,48 @@ var Tables = struct {
 		Name:  "expenses",
 		Alias: "t",
 	},
+	Income: struct {
+		Name, Alias string
+	}{
+		Name:  "incomes",
+		Alias: "t",
+	},
+	Budget: struct {
+		Name, Alias string
+	}{
+		Name:  "budgets",
+		Alias: "t",
+	},
+	RecurringExpense: struct {
+		Name, Alias string
+	}{
+		Name:  "recurringExpenses",
+		Alias: "t",
+	},
+	ExchangeRate: struct {
+		Name, Alias string
+	}{
+		Name:  "exchangeRates",
+		Alias: "t",
+	},
+	UserState: struct {
+		Name, Alias string
+	}{
+		Name:  "userStates",
+		Alias: "t",
+	},
+	Ledger: struct {
+		Name, Alias string
+	}{
+		Name:  "ledgers",
+		Alias: "t",
+	},
+	LedgerMember: struct {
+		Name, Alias string
+	}{
+		Name:  "ledgerMembers",
+		Alias: "t",
+	},
 }
 
 type User struct {
@@ -119,6 +325,14 @@ type User struct {
 	TelegramUsername string     `pg:"telegramUsername,use_zero"`
 	TeleramFirstName *string    `pg:"teleramFirstName"`
 	TelegramLastName *string    `pg:"telegramLastName"`
+	BaseCurrency     string     `pg:"baseCurrency,use_zero"`
+	Language         string     `pg:"language,use_zero"`
+	Timezone         string     `pg:"timezone,use_zero"`
+	DefaultCurrency  string     `pg:"defaultCurrency,use_zero"`
+	WeekStart        int        `pg:"weekStart,use_zero"`
+	LedgerID         *int       `pg:"ledgerId"`
+	WeeklyDigestAt   *time.Time `pg:"weeklyDigestAt"`
+	MonthlyDigestAt  *time.Time `pg:"monthlyDigestAt"`
 }
 
 type Category struct {
@@ -126,14 +340,18 @@ type Category struct {
 
 	ID        int       `pg:"categoryId,pk"`
 	UserID    int       `pg:"userId,use_zero"`
+	LedgerID  int       `pg:"ledgerId,use_zero"`
 	Title     string    `pg:"title,use_zero"`
 	Alias     *string   `pg:"alias"`
 	CreatedAt time.Time `pg:"createdAt,use_zero"`
 	UpdatedAt time.Time `pg:"updatedAt,use_zero"`
 	StatusID  int       `pg:"statusId,use_zero"`
 	Emoji     *string   `pg:"emoji"`
+	Kind      string    `pg:"kind,use_zero"`
+	ParentID  *int      `pg:"parentId"`
 
-	User *User `pg:"fk:userId,rel:has-one"`
+	User   *User     `pg:"fk:userId,rel:has-one"`
+	Parent *Category `pg:"fk:parentId,rel:has-one"`
 }
 
 type Expense struct {
@@ -141,6 +359,7 @@ type Expense struct {
 
 	ID          int       `pg:"expenseId,pk"`
 	UserID      int       `pg:"userId,use_zero"`
+	LedgerID    int       `pg:"ledgerId,use_zero"`
 	CategoryID  *int      `pg:"categoryId"`
 	Amount      int64     `pg:"amount,use_zero"`
 	Description string    `pg:"description,use_zero"`
@@ -148,7 +367,107 @@ type Expense struct {
 	UpdatedAt   time.Time `pg:"updatedAt,use_zero"`
 	StatusID    int       `pg:"statusId,use_zero"`
 	Currency    string    `pg:"currency,use_zero"`
+	SpentAt     time.Time `pg:"spentAt,use_zero"`
+	Tags        []string  `pg:"tags,array"`
 
 	User     *User     `pg:"fk:userId,rel:has-one"`
 	Category *Category `pg:"fk:categoryId,rel:has-one"`
 }
+
+type Income struct {
+	tableName struct{} `pg:"incomes,alias:t,discard_unknown_columns"`
+
+	ID          int       `pg:"incomeId,pk"`
+	UserID      int       `pg:"userId,use_zero"`
+	LedgerID    int       `pg:"ledgerId,use_zero"`
+	CategoryID  *int      `pg:"categoryId"`
+	Amount      int64     `pg:"amount,use_zero"`
+	Description string    `pg:"description,use_zero"`
+	CreatedAt   time.Time `pg:"createdAt,use_zero"`
+	UpdatedAt   time.Time `pg:"updatedAt,use_zero"`
+	StatusID    int       `pg:"statusId,use_zero"`
+	Currency    string    `pg:"currency,use_zero"`
+	ReceivedAt  time.Time `pg:"receivedAt,use_zero"`
+
+	User     *User     `pg:"fk:userId,rel:has-one"`
+	Category *Category `pg:"fk:categoryId,rel:has-one"`
+}
+
+type Budget struct {
+	tableName struct{} `pg:"budgets,alias:t,discard_unknown_columns"`
+
+	ID         int       `pg:"budgetId,pk"`
+	UserID     int       `pg:"userId,use_zero"`
+	LedgerID   int       `pg:"ledgerId,use_zero"`
+	CategoryID int       `pg:"categoryId,use_zero"`
+	Amount     int64     `pg:"amount,use_zero"`
+	Currency   string    `pg:"currency,use_zero"`
+	CreatedAt  time.Time `pg:"createdAt,use_zero"`
+	UpdatedAt  time.Time `pg:"updatedAt,use_zero"`
+	StatusID   int       `pg:"statusId,use_zero"`
+
+	User     *User     `pg:"fk:userId,rel:has-one"`
+	Category *Category `pg:"fk:categoryId,rel:has-one"`
+}
+
+type RecurringExpense struct {
+	tableName struct{} `pg:"recurringExpenses,alias:t,discard_unknown_columns"`
+
+	ID          int       `pg:"recurringExpenseId,pk"`
+	UserID      int       `pg:"userId,use_zero"`
+	LedgerID    int       `pg:"ledgerId,use_zero"`
+	CategoryID  *int      `pg:"categoryId"`
+	Amount      int64     `pg:"amount,use_zero"`
+	Currency    string    `pg:"currency,use_zero"`
+	Description string    `pg:"description,use_zero"`
+	DayOfMonth  int       `pg:"dayOfMonth,use_zero"`
+	NextRunAt   time.Time `pg:"nextRunAt,use_zero"`
+	CreatedAt   time.Time `pg:"createdAt,use_zero"`
+	StatusID    int       `pg:"statusId,use_zero"`
+
+	User     *User     `pg:"fk:userId,rel:has-one"`
+	Category *Category `pg:"fk:categoryId,rel:has-one"`
+}
+
+type ExchangeRate struct {
+	tableName struct{} `pg:"exchangeRates,alias:t,discard_unknown_columns"`
+
+	ID        int       `pg:"exchangeRateId,pk"`
+	Date      time.Time `pg:"date,use_zero"`
+	Currency  string    `pg:"currency,use_zero"`
+	Rate      float64   `pg:"rate,use_zero"`
+	CreatedAt time.Time `pg:"createdAt,use_zero"`
+}
+
+type UserState struct {
+	tableName struct{} `pg:"userStates,alias:t,discard_unknown_columns"`
+
+	ID         int       `pg:"userStateId,pk"`
+	TelegramID int64     `pg:"telegramId,use_zero"`
+	ChatID     int64     `pg:"chatId,use_zero"`
+	Data       string    `pg:"data,use_zero"`
+	ExpiresAt  time.Time `pg:"expiresAt,use_zero"`
+}
+
+type Ledger struct {
+	tableName struct{} `pg:"ledgers,alias:t,discard_unknown_columns"`
+
+	ID             int       `pg:"ledgerId,pk"`
+	Title          string    `pg:"title,use_zero"`
+	TelegramChatID int64     `pg:"telegramChatId,use_zero"`
+	InviteCode     string    `pg:"inviteCode,use_zero"`
+	CreatedAt      time.Time `pg:"createdAt,use_zero"`
+	StatusID       int       `pg:"statusId,use_zero"`
+}
+
+type LedgerMember struct {
+	tableName struct{} `pg:"ledgerMembers,alias:t,discard_unknown_columns"`
+
+	ID        int       `pg:"ledgerMemberId,pk"`
+	LedgerID  int       `pg:"ledgerId,use_zero"`
+	UserID    int       `pg:"userId,use_zero"`
+	CreatedAt time.Time `pg:"createdAt,use_zero"`
+
+	Ledger *Ledger `pg:"fk:ledgerId,rel:has-one"`
+	User   *User   `pg:"fk:userId,rel:has-one"`
+}
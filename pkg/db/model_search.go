@@ -66,6 +66,14 @@ type UserSearch struct {
 	TelegramUsername      *string
 	TeleramFirstName      *string
 	TelegramLastName      *string
+	BaseCurrency          *string
+	Language              *string
+	Timezone              *string
+	DefaultCurrency       *string
+	WeekStart             *int
+	LedgerID              *int
+	WeeklyDigestAt        *time.Time
+	MonthlyDigestAt       *time.Time
 	IDs                   []int
 	NotID                 *int
 	LoginILike            *string
@@ -76,6 +84,8 @@ type UserSearch struct {
 	TelegramUsernameILike *string
 	TeleramFirstNameILike *string
 	TelegramLastNameILike *string
+	WeeklyDigestAtTo      *time.Time
+	MonthlyDigestAtTo     *time.Time
 }
 
 func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
@@ -115,6 +125,30 @@ func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
 	if us.TelegramLastName != nil {
 		us.where(query, Tables.User.Alias, Columns.User.TelegramLastName, us.TelegramLastName)
 	}
+	if us.BaseCurrency != nil {
+		us.where(query, Tables.User.Alias, Columns.User.BaseCurrency, us.BaseCurrency)
+	}
+	if us.Language != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Language, us.Language)
+	}
+	if us.Timezone != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Timezone, us.Timezone)
+	}
+	if us.DefaultCurrency != nil {
+		us.where(query, Tables.User.Alias, Columns.User.DefaultCurrency, us.DefaultCurrency)
+	}
+	if us.WeekStart != nil {
+		us.where(query, Tables.User.Alias, Columns.User.WeekStart, us.WeekStart)
+	}
+	if us.LedgerID != nil {
+		us.where(query, Tables.User.Alias, Columns.User.LedgerID, us.LedgerID)
+	}
+	if us.WeeklyDigestAt != nil {
+		us.where(query, Tables.User.Alias, Columns.User.WeeklyDigestAt, us.WeeklyDigestAt)
+	}
+	if us.MonthlyDigestAt != nil {
+		us.where(query, Tables.User.Alias, Columns.User.MonthlyDigestAt, us.MonthlyDigestAt)
+	}
 	if len(us.IDs) > 0 {
 		Filter{Columns.User.ID, us.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -145,6 +179,12 @@ func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
 	if us.TelegramLastNameILike != nil {
 		Filter{Columns.User.TelegramLastName, *us.TelegramLastNameILike, SearchTypeILike, false}.Apply(query)
 	}
+	if us.WeeklyDigestAtTo != nil {
+		Filter{Columns.User.WeeklyDigestAt, *us.WeeklyDigestAtTo, SearchTypeLE, false}.Apply(query)
+	}
+	if us.MonthlyDigestAtTo != nil {
+		Filter{Columns.User.MonthlyDigestAt, *us.MonthlyDigestAtTo, SearchTypeLE, false}.Apply(query)
+	}
 
 	us.apply(query)
 
@@ -165,16 +205,20 @@ type CategorySearch struct {
 
 	ID         *int
 	UserID     *int
+	LedgerID   *int
 	Title      *string
 	Alias      *string
 	CreatedAt  *time.Time
 	UpdatedAt  *time.Time
 	StatusID   *int
 	Emoji      *string
+	Kind       *string
+	ParentID   *int
 	IDs        []int
 	NotID      *int
 	TitleILike *string
 	EmojiILike *string
+	KindILike  *string
 }
 
 func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
@@ -187,6 +231,9 @@ func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
 	if cs.UserID != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.UserID, cs.UserID)
 	}
+	if cs.LedgerID != nil {
+		cs.where(query, Tables.Category.Alias, Columns.Category.LedgerID, cs.LedgerID)
+	}
 	if cs.Title != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.Title, cs.Title)
 	}
@@ -205,6 +252,12 @@ func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
 	if cs.Emoji != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.Emoji, cs.Emoji)
 	}
+	if cs.Kind != nil {
+		cs.where(query, Tables.Category.Alias, Columns.Category.Kind, cs.Kind)
+	}
+	if cs.ParentID != nil {
+		cs.where(query, Tables.Category.Alias, Columns.Category.ParentID, cs.ParentID)
+	}
 	if len(cs.IDs) > 0 {
 		Filter{Columns.Category.ID, cs.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -217,6 +270,9 @@ func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
 	if cs.EmojiILike != nil {
 		Filter{Columns.Category.Emoji, *cs.EmojiILike, SearchTypeILike, false}.Apply(query)
 	}
+	if cs.KindILike != nil {
+		Filter{Columns.Category.Kind, *cs.KindILike, SearchTypeILike, false}.Apply(query)
+	}
 
 	cs.apply(query)
 
@@ -237,6 +293,7 @@ type ExpenseSearch struct {
 
 	ID               *int
 	UserID           *int
+	LedgerID         *int
 	CategoryID       *int
 	Amount           *int
 	Description      *string
@@ -244,9 +301,20 @@ type ExpenseSearch struct {
 	UpdatedAt        *time.Time
 	StatusID         *int
 	Currency         *string
+	SpentAt          *time.Time
 	IDs              []int
 	DescriptionILike *string
 	CurrencyILike    *string
+	CreatedAtFrom    *time.Time
+	CreatedAtTo      *time.Time
+	SpentAtFrom      *time.Time
+	SpentAtTo        *time.Time
+	Tag              *string
+	TagsIntersect    []string
+	DescriptionText  *string
+	AmountFrom       *int64
+	AmountTo         *int64
+	CategoryIDs      []int
 }
 
 func (es *ExpenseSearch) Apply(query *orm.Query) *orm.Query {
@@ -259,6 +327,9 @@ func (es *ExpenseSearch) Apply(query *orm.Query) *orm.Query {
 	if es.UserID != nil {
 		es.where(query, Tables.Expense.Alias, Columns.Expense.UserID, es.UserID)
 	}
+	if es.LedgerID != nil {
+		es.where(query, Tables.Expense.Alias, Columns.Expense.LedgerID, es.LedgerID)
+	}
 	if es.CategoryID != nil {
 		es.where(query, Tables.Expense.Alias, Columns.Expense.CategoryID, es.CategoryID)
 	}
@@ -280,6 +351,9 @@ func (es *ExpenseSearch) Apply(query *orm.Query) *orm.Query {
 	if es.Currency != nil {
 		es.where(query, Tables.Expense.Alias, Columns.Expense.Currency, es.Currency)
 	}
+	if es.SpentAt != nil {
+		es.where(query, Tables.Expense.Alias, Columns.Expense.SpentAt, es.SpentAt)
+	}
 	if len(es.IDs) > 0 {
 		Filter{Columns.Expense.ID, es.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -289,6 +363,36 @@ func (es *ExpenseSearch) Apply(query *orm.Query) *orm.Query {
 	if es.CurrencyILike != nil {
 		Filter{Columns.Expense.Currency, *es.CurrencyILike, SearchTypeILike, false}.Apply(query)
 	}
+	if es.CreatedAtFrom != nil {
+		Filter{Columns.Expense.CreatedAt, *es.CreatedAtFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if es.CreatedAtTo != nil {
+		Filter{Columns.Expense.CreatedAt, *es.CreatedAtTo, SearchTypeLE, false}.Apply(query)
+	}
+	if es.SpentAtFrom != nil {
+		Filter{Columns.Expense.SpentAt, *es.SpentAtFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if es.SpentAtTo != nil {
+		Filter{Columns.Expense.SpentAt, *es.SpentAtTo, SearchTypeLE, false}.Apply(query)
+	}
+	if es.Tag != nil {
+		Filter{Columns.Expense.Tags, *es.Tag, SearchTypeArrayContains, false}.Apply(query)
+	}
+	if len(es.TagsIntersect) > 0 {
+		Filter{Columns.Expense.Tags, es.TagsIntersect, SearchTypeArrayIntersect, false}.Apply(query)
+	}
+	if es.DescriptionText != nil {
+		Filter{Columns.Expense.Description, *es.DescriptionText, SearchTypeFullText, false}.Apply(query)
+	}
+	if es.AmountFrom != nil {
+		Filter{Columns.Expense.Amount, *es.AmountFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if es.AmountTo != nil {
+		Filter{Columns.Expense.Amount, *es.AmountTo, SearchTypeLE, false}.Apply(query)
+	}
+	if len(es.CategoryIDs) > 0 {
+		Filter{Columns.Expense.CategoryID, es.CategoryIDs, SearchTypeArray, false}.Apply(query)
+	}
 
 	es.apply(query)
 
@@ -303,3 +407,455 @@ func (es *ExpenseSearch) Q() applier {
 		return es.Apply(query), nil
 	}
 }
+
+type IncomeSearch struct {
+	search
+
+	ID               *int
+	UserID           *int
+	LedgerID         *int
+	CategoryID       *int
+	Amount           *int
+	Description      *string
+	CreatedAt        *time.Time
+	UpdatedAt        *time.Time
+	StatusID         *int
+	Currency         *string
+	ReceivedAt       *time.Time
+	IDs              []int
+	DescriptionILike *string
+	CurrencyILike    *string
+	CreatedAtFrom    *time.Time
+	CreatedAtTo      *time.Time
+	ReceivedAtFrom   *time.Time
+	ReceivedAtTo     *time.Time
+}
+
+func (is *IncomeSearch) Apply(query *orm.Query) *orm.Query {
+	if is == nil {
+		return query
+	}
+	if is.ID != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.ID, is.ID)
+	}
+	if is.UserID != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.UserID, is.UserID)
+	}
+	if is.LedgerID != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.LedgerID, is.LedgerID)
+	}
+	if is.CategoryID != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.CategoryID, is.CategoryID)
+	}
+	if is.Amount != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.Amount, is.Amount)
+	}
+	if is.Description != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.Description, is.Description)
+	}
+	if is.CreatedAt != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.CreatedAt, is.CreatedAt)
+	}
+	if is.UpdatedAt != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.UpdatedAt, is.UpdatedAt)
+	}
+	if is.StatusID != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.StatusID, is.StatusID)
+	}
+	if is.Currency != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.Currency, is.Currency)
+	}
+	if is.ReceivedAt != nil {
+		is.where(query, Tables.Income.Alias, Columns.Income.ReceivedAt, is.ReceivedAt)
+	}
+	if len(is.IDs) > 0 {
+		Filter{Columns.Income.ID, is.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if is.DescriptionILike != nil {
+		Filter{Columns.Income.Description, *is.DescriptionILike, SearchTypeILike, false}.Apply(query)
+	}
+	if is.CurrencyILike != nil {
+		Filter{Columns.Income.Currency, *is.CurrencyILike, SearchTypeILike, false}.Apply(query)
+	}
+	if is.CreatedAtFrom != nil {
+		Filter{Columns.Income.CreatedAt, *is.CreatedAtFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if is.CreatedAtTo != nil {
+		Filter{Columns.Income.CreatedAt, *is.CreatedAtTo, SearchTypeLE, false}.Apply(query)
+	}
+	if is.ReceivedAtFrom != nil {
+		Filter{Columns.Income.ReceivedAt, *is.ReceivedAtFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if is.ReceivedAtTo != nil {
+		Filter{Columns.Income.ReceivedAt, *is.ReceivedAtTo, SearchTypeLE, false}.Apply(query)
+	}
+
+	is.apply(query)
+
+	return query
+}
+
+func (is *IncomeSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if is == nil {
+			return query, nil
+		}
+		return is.Apply(query), nil
+	}
+}
+
+type BudgetSearch struct {
+	search
+
+	ID            *int
+	UserID        *int
+	LedgerID      *int
+	CategoryID    *int
+	Amount        *int64
+	Currency      *string
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+	StatusID      *int
+	IDs           []int
+	CurrencyILike *string
+}
+
+func (bs *BudgetSearch) Apply(query *orm.Query) *orm.Query {
+	if bs == nil {
+		return query
+	}
+	if bs.ID != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.ID, bs.ID)
+	}
+	if bs.UserID != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.UserID, bs.UserID)
+	}
+	if bs.LedgerID != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.LedgerID, bs.LedgerID)
+	}
+	if bs.CategoryID != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.CategoryID, bs.CategoryID)
+	}
+	if bs.Amount != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.Amount, bs.Amount)
+	}
+	if bs.Currency != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.Currency, bs.Currency)
+	}
+	if bs.CreatedAt != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.CreatedAt, bs.CreatedAt)
+	}
+	if bs.UpdatedAt != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.UpdatedAt, bs.UpdatedAt)
+	}
+	if bs.StatusID != nil {
+		bs.where(query, Tables.Budget.Alias, Columns.Budget.StatusID, bs.StatusID)
+	}
+	if len(bs.IDs) > 0 {
+		Filter{Columns.Budget.ID, bs.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if bs.CurrencyILike != nil {
+		Filter{Columns.Budget.Currency, *bs.CurrencyILike, SearchTypeILike, false}.Apply(query)
+	}
+
+	bs.apply(query)
+
+	return query
+}
+
+func (bs *BudgetSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if bs == nil {
+			return query, nil
+		}
+		return bs.Apply(query), nil
+	}
+}
+
+type RecurringExpenseSearch struct {
+	search
+
+	ID          *int
+	UserID      *int
+	LedgerID    *int
+	CategoryID  *int
+	Amount      *int64
+	Currency    *string
+	Description *string
+	DayOfMonth  *int
+	NextRunAt   *time.Time
+	CreatedAt   *time.Time
+	StatusID    *int
+	IDs         []int
+	NextRunAtTo *time.Time
+}
+
+func (res *RecurringExpenseSearch) Apply(query *orm.Query) *orm.Query {
+	if res == nil {
+		return query
+	}
+	if res.ID != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.ID, res.ID)
+	}
+	if res.UserID != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.UserID, res.UserID)
+	}
+	if res.LedgerID != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.LedgerID, res.LedgerID)
+	}
+	if res.CategoryID != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.CategoryID, res.CategoryID)
+	}
+	if res.Amount != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.Amount, res.Amount)
+	}
+	if res.Currency != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.Currency, res.Currency)
+	}
+	if res.Description != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.Description, res.Description)
+	}
+	if res.DayOfMonth != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.DayOfMonth, res.DayOfMonth)
+	}
+	if res.NextRunAt != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.NextRunAt, res.NextRunAt)
+	}
+	if res.CreatedAt != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.CreatedAt, res.CreatedAt)
+	}
+	if res.StatusID != nil {
+		res.where(query, Tables.RecurringExpense.Alias, Columns.RecurringExpense.StatusID, res.StatusID)
+	}
+	if len(res.IDs) > 0 {
+		Filter{Columns.RecurringExpense.ID, res.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if res.NextRunAtTo != nil {
+		Filter{Columns.RecurringExpense.NextRunAt, *res.NextRunAtTo, SearchTypeLE, false}.Apply(query)
+	}
+
+	res.apply(query)
+
+	return query
+}
+
+func (res *RecurringExpenseSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if res == nil {
+			return query, nil
+		}
+		return res.Apply(query), nil
+	}
+}
+
+type ExchangeRateSearch struct {
+	search
+
+	ID        *int
+	Date      *time.Time
+	Currency  *string
+	Rate      *float64
+	CreatedAt *time.Time
+	IDs       []int
+	DateFrom  *time.Time
+	DateTo    *time.Time
+}
+
+func (ers *ExchangeRateSearch) Apply(query *orm.Query) *orm.Query {
+	if ers == nil {
+		return query
+	}
+	if ers.ID != nil {
+		ers.where(query, Tables.ExchangeRate.Alias, Columns.ExchangeRate.ID, ers.ID)
+	}
+	if ers.Date != nil {
+		ers.where(query, Tables.ExchangeRate.Alias, Columns.ExchangeRate.Date, ers.Date)
+	}
+	if ers.Currency != nil {
+		ers.where(query, Tables.ExchangeRate.Alias, Columns.ExchangeRate.Currency, ers.Currency)
+	}
+	if ers.Rate != nil {
+		ers.where(query, Tables.ExchangeRate.Alias, Columns.ExchangeRate.Rate, ers.Rate)
+	}
+	if ers.CreatedAt != nil {
+		ers.where(query, Tables.ExchangeRate.Alias, Columns.ExchangeRate.CreatedAt, ers.CreatedAt)
+	}
+	if len(ers.IDs) > 0 {
+		Filter{Columns.ExchangeRate.ID, ers.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if ers.DateFrom != nil {
+		Filter{Columns.ExchangeRate.Date, *ers.DateFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if ers.DateTo != nil {
+		Filter{Columns.ExchangeRate.Date, *ers.DateTo, SearchTypeLE, false}.Apply(query)
+	}
+
+	ers.apply(query)
+
+	return query
+}
+
+func (ers *ExchangeRateSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ers == nil {
+			return query, nil
+		}
+		return ers.Apply(query), nil
+	}
+}
+
+type UserStateSearch struct {
+	search
+
+	ID          *int
+	TelegramID  *int64
+	ChatID      *int64
+	Data        *string
+	ExpiresAt   *time.Time
+	IDs         []int
+	ExpiresAtTo *time.Time
+}
+
+func (uss *UserStateSearch) Apply(query *orm.Query) *orm.Query {
+	if uss == nil {
+		return query
+	}
+	if uss.ID != nil {
+		uss.where(query, Tables.UserState.Alias, Columns.UserState.ID, uss.ID)
+	}
+	if uss.TelegramID != nil {
+		uss.where(query, Tables.UserState.Alias, Columns.UserState.TelegramID, uss.TelegramID)
+	}
+	if uss.ChatID != nil {
+		uss.where(query, Tables.UserState.Alias, Columns.UserState.ChatID, uss.ChatID)
+	}
+	if uss.Data != nil {
+		uss.where(query, Tables.UserState.Alias, Columns.UserState.Data, uss.Data)
+	}
+	if uss.ExpiresAt != nil {
+		uss.where(query, Tables.UserState.Alias, Columns.UserState.ExpiresAt, uss.ExpiresAt)
+	}
+	if len(uss.IDs) > 0 {
+		Filter{Columns.UserState.ID, uss.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if uss.ExpiresAtTo != nil {
+		Filter{Columns.UserState.ExpiresAt, *uss.ExpiresAtTo, SearchTypeLE, false}.Apply(query)
+	}
+
+	uss.apply(query)
+
+	return query
+}
+
+func (uss *UserStateSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if uss == nil {
+			return query, nil
+		}
+		return uss.Apply(query), nil
+	}
+}
+
+type LedgerSearch struct {
+	search
+
+	ID              *int
+	Title           *string
+	TelegramChatID  *int64
+	InviteCode      *string
+	CreatedAt       *time.Time
+	StatusID        *int
+	IDs             []int
+	TitleILike      *string
+	InviteCodeILike *string
+}
+
+func (ls *LedgerSearch) Apply(query *orm.Query) *orm.Query {
+	if ls == nil {
+		return query
+	}
+	if ls.ID != nil {
+		ls.where(query, Tables.Ledger.Alias, Columns.Ledger.ID, ls.ID)
+	}
+	if ls.Title != nil {
+		ls.where(query, Tables.Ledger.Alias, Columns.Ledger.Title, ls.Title)
+	}
+	if ls.TelegramChatID != nil {
+		ls.where(query, Tables.Ledger.Alias, Columns.Ledger.TelegramChatID, ls.TelegramChatID)
+	}
+	if ls.InviteCode != nil {
+		ls.where(query, Tables.Ledger.Alias, Columns.Ledger.InviteCode, ls.InviteCode)
+	}
+	if ls.CreatedAt != nil {
+		ls.where(query, Tables.Ledger.Alias, Columns.Ledger.CreatedAt, ls.CreatedAt)
+	}
+	if ls.StatusID != nil {
+		ls.where(query, Tables.Ledger.Alias, Columns.Ledger.StatusID, ls.StatusID)
+	}
+	if len(ls.IDs) > 0 {
+		Filter{Columns.Ledger.ID, ls.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if ls.TitleILike != nil {
+		Filter{Columns.Ledger.Title, *ls.TitleILike, SearchTypeILike, false}.Apply(query)
+	}
+	if ls.InviteCodeILike != nil {
+		Filter{Columns.Ledger.InviteCode, *ls.InviteCodeILike, SearchTypeILike, false}.Apply(query)
+	}
+
+	ls.apply(query)
+
+	return query
+}
+
+func (ls *LedgerSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ls == nil {
+			return query, nil
+		}
+		return ls.Apply(query), nil
+	}
+}
+
+type LedgerMemberSearch struct {
+	search
+
+	ID        *int
+	LedgerID  *int
+	UserID    *int
+	CreatedAt *time.Time
+	IDs       []int
+}
+
+func (lms *LedgerMemberSearch) Apply(query *orm.Query) *orm.Query {
+	if lms == nil {
+		return query
+	}
+	if lms.ID != nil {
+		lms.where(query, Tables.LedgerMember.Alias, Columns.LedgerMember.ID, lms.ID)
+	}
+	if lms.LedgerID != nil {
+		lms.where(query, Tables.LedgerMember.Alias, Columns.LedgerMember.LedgerID, lms.LedgerID)
+	}
+	if lms.UserID != nil {
+		lms.where(query, Tables.LedgerMember.Alias, Columns.LedgerMember.UserID, lms.UserID)
+	}
+	if lms.CreatedAt != nil {
+		lms.where(query, Tables.LedgerMember.Alias, Columns.LedgerMember.CreatedAt, lms.CreatedAt)
+	}
+	if len(lms.IDs) > 0 {
+		Filter{Columns.LedgerMember.ID, lms.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	lms.apply(query)
+
+	return query
+}
+
+func (lms *LedgerMemberSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if lms == nil {
+			return query, nil
+		}
+		return lms.Apply(query), nil
+	}
+}
@@ -41,6 +41,22 @@ func (u User) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.User.TelegramLastName] = ErrMaxLength
 	}
 
+	if utf8.RuneCountInString(u.BaseCurrency) > 12 {
+		errors[Columns.User.BaseCurrency] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(u.Language) > 8 {
+		errors[Columns.User.Language] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(u.Timezone) > 64 {
+		errors[Columns.User.Timezone] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(u.DefaultCurrency) > 12 {
+		errors[Columns.User.DefaultCurrency] = ErrMaxLength
+	}
+
 	return errors, len(errors) == 0
 }
 
@@ -51,6 +67,10 @@ func (c Category) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.Category.Emoji] = ErrMaxLength
 	}
 
+	if utf8.RuneCountInString(c.Kind) > 16 {
+		errors[Columns.Category.Kind] = ErrMaxLength
+	}
+
 	return errors, len(errors) == 0
 }
 
@@ -63,3 +83,69 @@ func (e Expense) Validate() (errors map[string]string, valid bool) {
 
 	return errors, len(errors) == 0
 }
+
+func (i Income) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(i.Currency) > 12 {
+		errors[Columns.Income.Currency] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (b Budget) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(b.Currency) > 12 {
+		errors[Columns.Budget.Currency] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (re RecurringExpense) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(re.Currency) > 12 {
+		errors[Columns.RecurringExpense.Currency] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (er ExchangeRate) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(er.Currency) > 12 {
+		errors[Columns.ExchangeRate.Currency] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (us UserState) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	return errors, len(errors) == 0
+}
+
+func (l Ledger) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(l.Title) > 255 {
+		errors[Columns.Ledger.Title] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(l.InviteCode) > 32 {
+		errors[Columns.Ledger.InviteCode] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (lm LedgerMember) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	return errors, len(errors) == 0
+}
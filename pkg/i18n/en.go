@@ -0,0 +1,422 @@
+package i18n
+
+// en is English catalog of messages
+var en = map[string]string{
+	// common
+	"start.error":   "Registration failed. Please try again later.",
+	"start.welcome": "👋 Hi, %s!\n\nI will help you keep track of your expenses.\n\nUse the buttons below:",
+	"help": `📚 <b>Commands help:</b>
+
+<b>➕ Add expense</b> - Add new expense or income
+Tap the button and send a voice message or text describing the expense.
+Incomes are added the same way, for example "got my salary 3k".
+You can mention the day: "had lunch yesterday for 12", "taxi 20 on Friday".
+If the message has several expenses, before confirmation you can remove any of them (🗑), change its category (📂) or fix its amount (💰).
+Add a hashtag to mark expenses of a trip or a project regardless of category: "#vacation taxi 30".
+
+<b>📊 Statistics</b> - Statistics
+Show expenses by category or by expense, incomes and balance for a period.
+Totals are converted to the base currency at the Bank of Russia rate on the date of operation.
+In "💼 Budgets" you can set a monthly limit for a category, the bot warns at 80% and 100% of spending.
+The bot also warns when an expense is several times larger than usual for its category or a day's spending is far above usual.
+"📤 Export" sends all expenses for a period as a CSV or XLSX file.
+"📈 Charts" draws a pie chart by category and bars of expenses by day.
+"🔀 Compare" shows how spending by category changed versus last month or the same week last month, with a six-month trend.
+
+<b>🧾 Receipts</b> - Expense from QR code
+Take a photo of the QR code of a fiscal receipt, the bot takes the amount and time of purchase. Write what was bought in the photo caption to choose the category.
+
+<b>📥 Statement import</b> - Expenses from your bank
+Send an OFX or CSV statement file (T-Bank, Alfa-Bank, Sberbank), the bot picks categories and shows a summary before saving.
+
+<b>📂 Categories</b> - Keep categories tidy
+Rename a category, set its emoji, merge two categories into one or delete an extra one, its expenses move to the category you choose.
+Categories can be grouped: for example "Taxi", "Metro" and "Fuel" inside "Transport". Statistics by category show the group total, and sub-categories expand on tap.
+
+<b>🗑 Trash</b> - Deleted expenses
+Restore an accidentally deleted expense.
+
+<b>🔁 Recurring payments</b> - Rent, phone, subscriptions
+The bot adds the expense on the right day of month and sends a notification with an undo button.
+
+<b>👥 Shared ledger</b> - Budget for two
+Add the bot to a group chat, expenses of all members go to the shared ledger with who spent the money. Statistics can be viewed as a whole or per member.
+In a group the bot reads only messages that mention it or reply to its messages, ordinary conversation is left alone.
+
+/undo - undo last added expense
+/trash - open trash
+/recurring - recurring payments
+/currency - base currency of statistics
+/language - interface language
+/settings - time zone, default currency, first day of week and weekly or monthly digests
+/ledger - shared ledger, members and invite link
+/categories - manage categories
+/tags - tag cloud and statistics by tag
+/find - search expenses by description, amount, category, currency and dates
+
+💡 If the bot guesses the category poorly, you can hint it yourself (for example in brackets)`,
+	"main_menu":                   "Main menu:",
+	"unknown_command":             "Unknown command. Use /help to see available commands.",
+	"unknown_action":              "Unknown action",
+	"use_start":                   "Please use /start to get started.",
+	"user_not_found":              "Error: user not found",
+	"cancelled":                   "Cancelled.",
+	"no_category":                 "Uncategorized",
+	"amount.invalid":              "invalid amount format",
+	"amount.not_positive":         "amount must be greater than zero",
+	"amount.retry":                "❌ %s. Enter the amount again, for example: 1500 or 99.90",
+	"format.invalid":              "invalid format",
+	"format.unsupported_currency": "currency %s is not supported",
+	"categories.load_error":       "Failed to load categories",
+	"categories.get_error":        "Failed to get categories.",
+	"categories.empty":            "You have no categories yet",
+	"expenses.load_error":         "Failed to get expenses.",
+	"expenses.save_error":         "Failed to save expenses",
+
+	// keyboards
+	"btn.add_expense":    "➕ Add expense",
+	"btn.stats":          "📊 Statistics",
+	"btn.week_expenses":  "💰 This week",
+	"btn.trash":          "🗑 Trash",
+	"btn.recurring":      "🔁 Recurring payments",
+	"btn.by_categories":  "📊 By category",
+	"btn.by_expenses":    "💸 By expense",
+	"btn.budgets":        "💼 Budgets",
+	"btn.export":         "📤 Export",
+	"btn.charts":         "📈 Charts",
+	"btn.compare":        "🔀 Compare",
+	"btn.today":          "📅 Today",
+	"btn.week":           "📅 Week",
+	"btn.month":          "📅 Month",
+	"btn.all_time":       "📅 All time",
+	"btn.custom_period":  "📅 Custom period",
+	"btn.back":           "🔙 Back",
+	"btn.cancel":         "❌ Cancel",
+	"kb.yes":             "✅ Yes",
+	"kb.no":              "❌ No",
+	"kb.confirm":         "✅ Confirm",
+	"kb.cancel":          "❌ Cancel",
+	"kb.back":            "🔙 Back",
+	"kb.edit_expense":    "✏️ Edit: %s",
+	"kb.delete_income":   "🗑 Delete income: %s",
+	"kb.restore":         "↩️ Restore",
+	"kb.restore_expense": "♻️ Restore: %s",
+	"kb.add_budget":      "➕ Set budget",
+	"kb.add_recurring":   "➕ Add payment",
+	"kb.undo":            "↩️ Undo",
+	"kb.amount":          "💰 Amount",
+	"kb.currency":        "💱 Currency",
+	"kb.category":        "📂 Category",
+	"kb.description":     "📝 Description",
+	"kb.delete":          "🗑 Delete",
+	"kb.done":            "✅ Done",
+	"kb.import":          "✅ Import",
+
+	// currency
+	"currency.base":        "💱 <b>Base currency:</b> %s",
+	"currency.base_hint":   "Statistics are converted to it at the Bank of Russia rate on the date of each operation.",
+	"currency.unsupported": "Currency is not supported",
+	"currency.save_error":  "Failed to save currency",
+	"currency.saved":       "Currency saved",
+	"stats.total":          "💰 <b>Total:</b> %s\n",
+	"stats.income_balance": "📈 <b>Income:</b> %s\n⚖️ <b>Balance:</b> %s\n",
+
+	// language
+	"language.current":     "🌐 <b>Interface language:</b> %s",
+	"language.unsupported": "Language is not supported",
+	"language.save_error":  "Failed to save language",
+	"language.saved":       "✅ Language changed",
+
+	// dates
+	"date.invalid_format":  "invalid date format",
+	"date.start_error":     "error in start date: %s",
+	"date.end_error":       "error in end date: %s",
+	"date.start_after_end": "start date cannot be after end date",
+	"date.invalid_date":    "invalid date format (use DD.MM.YY or DD.MM)",
+	"date.invalid_month":   "month must be from 1 to 12",
+	"date.invalid_day":     "day must be from 1 to 31",
+	"date.nonexistent":     "date does not exist",
+
+	// edit
+	"edit.not_found":         "Error: expense not found",
+	"edit.enter_amount":      "💰 Enter new amount, for example: <code>1500</code> or <code>99.90</code>",
+	"edit.enter_description": "📝 Enter new description or <code>-</code> to clear it",
+	"edit.choose_category":   "📂 Choose a category or type the name of a new one.",
+	"edit.saved":             "✅ <b>Expense saved:</b>",
+	"edit.category_error":    "Failed to save category.",
+	"edit.save_error":        "Failed to save expense.",
+	"edit.title":             "✏️ <b>Editing expense</b>",
+	"edit.what":              "What to change?",
+	"edit.details":           "💰 Amount: %s %s\n📂 Category: %s\n📝 Description: %s\n📅 Date: %s",
+
+	// confirmation
+	"confirm.item_not_found":     "Error: item not found",
+	"confirm.item_dropped":       "Item removed",
+	"confirm.category_not_found": "Error: category not found",
+	"confirm.enter_amount":       "💰 Enter amount for \"%s\", for example: <code>1500</code> or <code>99.90</code>",
+	"confirm.expired":            "Confirmation expired",
+	"confirm.expired_message":    "⌛ Confirmation expired, expense was not saved. Please send it again.",
+	"confirm.expenses":           "Confirm expenses:",
+	"confirm.operations":         "Confirm operations:",
+
+	// trash
+	"trash.delete_error":     "Failed to delete expense.",
+	"trash.nothing_to_undo":  "No expenses to undo.",
+	"trash.undone":           "↩️ <b>Last expense undone:</b>",
+	"trash.title":            "🗑 <b>Trash</b>",
+	"trash.empty":            "<i>Trash is empty.</i>",
+	"trash.hint":             "Tap an expense to restore it.",
+	"trash.already_deleted":  "Expense is already deleted",
+	"trash.deleted":          "Expense deleted",
+	"trash.moved":            "🗑 <b>Expense moved to trash:</b>",
+	"trash.already_restored": "Expense is already restored",
+	"trash.restored":         "Expense restored",
+	"trash.restored_details": "♻️ <b>Expense restored:</b>",
+
+	// income
+	"income.already_deleted":  "Income is already deleted",
+	"income.deleted":          "Income deleted",
+	"income.deleted_details":  "🗑 <b>Income deleted:</b>",
+	"income.already_restored": "Income is already restored",
+	"income.restored":         "Income restored",
+	"income.restored_details": "♻️ <b>Income restored:</b>",
+
+	// budgets
+	"budget.load_error":      "Failed to get budgets.",
+	"budget.title":           "💼 <b>Monthly budgets</b>",
+	"budget.empty":           "<i>No budgets set.</i>\n\nTap \"Set budget\" to limit spending in a category.",
+	"budget.enter":           "💼 Enter category and monthly limit.\nFor example: <code>Food 500 USD</code> or <code>Taxi 100</code>",
+	"budget.already_deleted": "Budget is already deleted",
+	"budget.deleted":         "Budget deleted",
+	"budget.retry":           "❌ %s. Enter the budget again, for example: <code>Food 500 USD</code>",
+	"budget.save_error":      "Failed to save budget.",
+	"budget.saved":           "✅ <b>Budget saved</b>",
+	"budget.exceeded":        "🚨 Budget \"%s\" exceeded by %s %s!",
+	"budget.warning":         "⚠️ Spent more than %d%% of budget \"%s\"",
+	"budget.remaining":       ", %s %s left",
+	"budget.overspent":       ", overspent by %s %s",
+
+	// recurring
+	"recurring.charged":         "🔁 <b>Recurring payment added:</b>",
+	"recurring.next_charge":     "Next charge: %s",
+	"recurring.load_error":      "Failed to get recurring payments.",
+	"recurring.title":           "🔁 <b>Recurring payments</b>",
+	"recurring.empty":           "<i>No recurring payments.</i>\n\nAdd rent, phone or subscriptions and the bot will record them itself.",
+	"recurring.upcoming":        "<b>Upcoming charges:</b>",
+	"recurring.enter":           "🔁 Enter category, amount and day of month separated by comma.\nFor example: <code>Rent 1000 USD, 5</code> or <code>Phone 20, 15</code>",
+	"recurring.already_deleted": "Payment is already deleted",
+	"recurring.deleted":         "Payment deleted",
+	"recurring.retry":           "❌ %s. Enter the payment again, for example: <code>Rent 1000 USD, 5</code>",
+	"recurring.save_error":      "Failed to save recurring payment.",
+	"recurring.saved":           "✅ <b>Recurring payment saved</b>",
+	"recurring.no_day":          "specify day of month after comma",
+	"recurring.invalid_day":     "invalid day of month format",
+	"recurring.day_range":       "day of month must be from 1 to 31",
+	"recurring.item":            "📅 %s — %s, every month on day %d",
+
+	// import
+	"import.too_large":      "❌ File is too large. Export a statement for a shorter period.",
+	"import.download_error": "Failed to download file.",
+	"import.parse_error":    "❌ Failed to read statement: %v",
+	"import.unknown_format": "❌ Statement format is not recognized. OFX and CSV exports of T-Bank, Alfa-Bank and Sberbank are supported.",
+	"import.check_error":    "Failed to check expenses.",
+	"import.no_expenses":    "There are no expenses in the statement.",
+	"import.all_duplicates": "All expenses of the statement are already added (%d).",
+	"import.too_many":       "❌ The statement has %d expenses, no more than %d can be imported at once. Export a statement for a shorter period.",
+	"import.categorizing":   "⏳ %s statement: found %d expenses, picking categories…",
+	"import.progress":       "⏳ %s statement: picking categories… %d of %d",
+	"import.in_progress":    "⏳ Previous statement is still being processed, wait for its summary.",
+	"import.cancelled":      "Import cancelled.",
+	"import.expired":        "⌛ Confirmation expired, statement was not imported. Please send the file again.",
+	"import.done":           "Statement imported!",
+	"import.saved":          "💾 <b>Expenses imported: %d</b>\n\n💰 <b>Total:</b> %s",
+	"import.confirm":        "📥 <b>Confirm statement import</b>",
+	"import.count":          "🧾 Expenses: %d",
+	"import.skipped":        "♻️ Already added and skipped: %d",
+	"import.by_category":    "<b>By category:</b>",
+	"import.category_line":  "• %s — %s (%d)",
+
+	// export
+	"export.choose_format":  "📤 <b>Export of expenses for %s</b>\n\nChoose file format:",
+	"export.invalid_format": "Invalid export format",
+	"export.load_error":     "Failed to get expenses",
+	"export.empty":          "No expenses for this period",
+	"export.file_error":     "Failed to build file",
+	"export.caption":        "📤 Expenses for %s: %d",
+
+	// receipts
+	"receipt.too_large":      "❌ Photo is too large.",
+	"receipt.download_error": "Failed to download photo.",
+	"receipt.read_error":     "❌ Failed to read receipt: %v",
+	"receipt.qr_not_found":   "❌ QR code not found. Take a closer, straight photo of the receipt QR code without glare.",
+	"receipt.not_receipt":    "❌ This is not a QR code of a fiscal receipt.",
+	"receipt.not_purchase":   "❌ This is not a purchase receipt (refund or payout), it is not added to expenses.",
+	"receipt.description":    "Receipt of %s",
+
+	// expenses and statistics
+	"expense.add":                "💰 <b>Adding expense</b>\n\nSend a voice message or write a text.\nFor example: <code>10 dollars for food at McDonald's</code>",
+	"expense.parse_error":        "Failed to process text.",
+	"expense.not_found":          "Could not find expenses.",
+	"expense.added":              "✅ Expenses added!\n\n💰",
+	"expense.operations_added":   "✅ Operations added!\n\n💰",
+	"expense.saved":              "Expense saved!",
+	"expense.saved_list":         "💾 <b>Saved:</b>",
+	"income.save_error":          "Failed to save income.",
+	"income.load_error":          "Failed to get incomes.",
+	"voice.download_error":       "Failed to get voice message.",
+	"voice.transcribe_error":     "Failed to recognize voice.",
+	"stats.choose_type":          "📊 <b>Choose statistics type:</b>",
+	"stats.categories_period":    "📊 <b>Statistics by category</b>\n\nChoose period:",
+	"stats.export_period":        "📤 <b>Export of expenses</b>\n\nChoose period:",
+	"stats.charts_period":        "📈 <b>Charts of expenses</b>\n\nChoose period:",
+	"stats.expenses_period":      "💸 <b>Statistics by expense</b>\n\nChoose period:",
+	"stats.empty":                "📊 <b>Statistics</b>\n\n<i>No expenses yet.</i>",
+	"stats.by_categories":        "📊 <b>Statistics by category:</b>",
+	"stats.income_by_categories": "📈 <b>Income by category:</b>",
+	"stats.by_expenses":          "📊 <b>Statistics by expense:</b>",
+	"stats.no_expenses":          "<i>No expenses for this period.</i>",
+	"stats.incomes":              "📈 <b>Income:</b>",
+	"stats.choose_to_edit":       "✏️ Choose an expense to edit it:",
+	"period.voice_rejected":      "Please enter the period as text in format: DD.MM.YY DD.MM.YY",
+	"period.enter":               "📅 <b>Enter custom period</b>\n\nFormats:\n• <code>03.04.25 07.04.25</code>\n• <code>03.04.25 - 07.04.25</code>\n• <code>03.04 07.04</code> (current year)\n• <code>03.04 - 07.04</code> (current year)",
+	"period.retry":               "❌ Error: %s\n\nPlease enter the period in format:\n• DD.MM.YY DD.MM.YY\n• DD.MM - DD.MM (current year)",
+	"period.too_long":            "❌ Period of statistics by expense cannot be longer than a month (31 days).",
+
+	// settings
+	"settings.title":            "⚙️ <b>Settings</b>",
+	"settings.timezone":         "🕐 Time zone: <b>%s</b> (now %s)",
+	"settings.currency":         "💱 Default currency: <b>%s</b>",
+	"settings.week_start":       "📅 First day of week: <b>%s</b>",
+	"settings.digests":          "📬 Digests: <b>%s</b>",
+	"settings.digests_weekly":   "weekly",
+	"settings.digests_monthly":  "monthly",
+	"settings.digests_off":      "off",
+	"settings.digests_prompt":   "📬 <b>Expense digests</b>\n\nThe bot sends totals, top categories and comparison with the previous period at %d:00 in your time zone: for the week on Mondays, for the month on the 1st.",
+	"settings.saved":            "Setting saved",
+	"settings.save_error":       "❌ Failed to save setting",
+	"settings.timezone_prompt":  "Type time zone in IANA format, for example <code>America/Chicago</code> or <code>Europe/Berlin</code>",
+	"settings.timezone_unknown": "❌ Unknown time zone: %s",
+	"kb.timezone":               "🕐 Time zone",
+	"kb.default_currency":       "💱 Default currency",
+	"kb.week_start":             "📅 Week start",
+	"kb.digests":                "📬 Digests",
+	"kb.digest_weekly":          "Every Monday",
+	"kb.digest_monthly":         "On the 1st of each month",
+	"kb.other_timezone":         "✏️ Other",
+	"weekday.0":                 "Sunday",
+	"weekday.1":                 "Monday",
+	"weekday.2":                 "Tuesday",
+	"weekday.3":                 "Wednesday",
+	"weekday.4":                 "Thursday",
+	"weekday.5":                 "Friday",
+	"weekday.6":                 "Saturday",
+
+	// ledgers
+	"ledger.personal": `📒 <b>Personal ledger</b>
+
+Only you see these expenses.
+To keep a shared household budget, add me to a group chat: expenses of all group members go to the shared ledger, and statistics can be viewed as a whole or for each member.
+
+⚠️ In a group the bot sees plain messages only if it is an admin or its privacy mode is disabled in @BotFather.`,
+	"ledger.shared":            "👥 <b>Shared ledger \"%s\"</b>",
+	"ledger.members":           "Members:",
+	"ledger.invite":            "Invite link to add expenses to this ledger from a private chat:\n%s",
+	"ledger.joined":            "✅ You joined ledger \"%s\".\n\nExpenses from this chat go to it now. Use /ledger to switch back to your personal ledger.",
+	"ledger.invite_invalid":    "❌ Invite link is invalid.",
+	"ledger.switched_personal": "📒 Expenses from this chat go to your personal ledger again.",
+	"ledger.load_error":        "❌ Failed to load ledger.",
+	"ledger.save_error":        "❌ Failed to switch ledger. Please try again later.",
+	"kb.personal_ledger":       "📒 Switch to personal ledger",
+	"kb.all_members":           "👥 All members",
+	"stats.member_choose":      "👥 Whose statistics to show?",
+	"stats.member":             "👤 %s",
+
+	// categories menu
+	"btn.categories":           "📂 Categories",
+	"kb.category_rename":       "✏️ Rename",
+	"kb.category_emoji":        "😀 Emoji",
+	"kb.category_merge":        "🔀 Merge",
+	"kb.category_delete":       "🗑 Delete",
+	"kb.category_none":         "🚫 Leave without category",
+	"categories.title":         "📂 <b>Categories</b>\nThe number next to a category is the count of its expenses, ↳ marks sub-categories. Choose a category to rename it, set its emoji, move it to a group, merge it with another one or delete it.",
+	"categories.item":          "%s — %d",
+	"categories.detail":        "<b>%s</b>\nExpenses: %d",
+	"categories.not_found":     "Category not found",
+	"categories.enter_name":    "✏️ Enter new title of category <b>%s</b>",
+	"categories.name_invalid":  "Title must be at most %d characters long. Try again",
+	"categories.exists":        "Category \"%s\" already exists. Merge the categories to move expenses into it",
+	"categories.renamed":       "✅ Category renamed",
+	"categories.enter_emoji":   "Send an emoji for category <b>%s</b> or \"-\" to remove it",
+	"categories.emoji_invalid": "This does not look like an emoji. Send an emoji or \"-\"",
+	"categories.emoji_set":     "✅ Category emoji updated",
+	"categories.merge_choose":  "🔀 Choose the category that all expenses of <b>%s</b> move to. The category itself will be deleted.",
+	"categories.delete_choose": "🗑 Category <b>%s</b> will be deleted. Where should its expenses (%d) move?",
+	"categories.merged":        "✅ Categories merged, expenses moved: %d",
+	"categories.deleted":       "✅ Category deleted, expenses moved: %d",
+	"categories.save_error":    "Failed to save category",
+	"categories.no_targets":    "There are no other categories to merge with",
+
+	// sub-categories
+	"kb.category_parent":       "📁 Move to group",
+	"kb.category_top":          "⬆️ Make top level",
+	"categories.parent":        "Group: %s",
+	"categories.parent_choose": "📁 Choose the category that <b>%s</b> joins as a sub-category.",
+	"categories.parent_set":    "✅ Category group changed",
+	"categories.has_children":  "The category has sub-categories and only two levels are allowed. Move its sub-categories first",
+	"stats.category_other":     "Other",
+
+	// tags
+	"tags.title":       "🏷 <b>Tags</b>\nThe number next to a tag is the count of its expenses. Choose a tag to see its statistics.",
+	"tags.empty":       "No tags yet. Add a hashtag to an expense, for example: <code>#vacation taxi 30</code>",
+	"tags.load_error":  "Failed to load tags",
+	"tags.stats_title": "🏷 <b>Expenses tagged #%s</b>",
+	"tags.stats_count": "Expenses: %d",
+	"tags.stats_empty": "There are no expenses with this tag anymore",
+
+	// search
+	"find.usage": `🔎 <b>Expense search</b>
+Write words of the description and filters after /find, for example:
+<code>/find taxi</code>
+<code>/find amount>1000 category:Food currency:USD after:01.09</code>
+
+Filters:
+• <code>amount>1000</code>, <code>amount<500</code>, <code>amount=300</code> — amount from, up to or exactly
+• <code>category:Food</code> — category with its sub-categories, put a title with spaces in quotes
+• <code>currency:USD</code> — currency
+• <code>after:01.09</code>, <code>before:30.09.25</code> — dates, inclusive
+• <code>#vacation</code> — tag`,
+	"find.results": "🔎 <b>%s</b>\nExpenses found: %d",
+	"find.empty":   "🔎 Nothing found for <b>%s</b>",
+	"find.page":    "Page %d of %d",
+	"find.expired": "Search has expired, run /find again",
+	"find.error":   "Failed to search expenses",
+
+	// charts
+	"charts.by_categories": "🍩 <b>Expenses by category</b>",
+	"charts.by_days":       "📊 <b>Expenses by day</b>",
+	"charts.by_months":     "📊 <b>Expenses by month</b>",
+	"charts.average":       "📐 <b>Average:</b> %s\n",
+	"charts.error":         "❌ Failed to draw the chart. Please try again later.",
+
+	// digests
+	"digest.weekly_title":      "📬 <b>Weekly digest</b>",
+	"digest.monthly_title":     "📬 <b>Monthly digest</b>",
+	"digest.no_expenses":       "<i>No expenses for this period.</i>",
+	"digest.change_week":       "%s <b>%s</b> vs previous week (%s)",
+	"digest.change_month":      "%s <b>%s</b> vs previous month (%s)",
+	"digest.no_previous_week":  "<i>No expenses in the previous week</i>",
+	"digest.no_previous_month": "<i>No expenses in the previous month</i>",
+	"digest.top_categories":    "🏆 <b>Top categories:</b>",
+
+	// compare
+	"compare.month_title": "🔀 <b>This month vs last month</b>",
+	"compare.week_title":  "🔀 <b>This week vs the same week last month</b>",
+	"compare.new":         "(🆕 new)",
+	"compare.trend_hint":  "<i>The line under a category shows its expenses by month over the last %d months.</i>",
+	"kb.compare_month":    "📅 Month",
+	"kb.compare_week":     "📅 Week",
+
+	// anomaly
+	"anomaly.expense": "⚠️ Unusually large expense: %s in \"%s\", usually about %s (×%d)",
+	"anomaly.daily":   "📈 Spent <b>%s</b> today, ×%d of a usual day (median %s over the last %d days)",
+}
@@ -0,0 +1,159 @@
+// Package i18n translates bot interface to language of user.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Lang is a language of bot interface
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+)
+
+// Default is language of users whose language is unknown
+const Default = RU
+
+// Langs lists supported languages
+var Langs = []Lang{RU, EN}
+
+// names are names of languages in themselves
+var names = map[Lang]string{
+	RU: "🇷🇺 Русский",
+	EN: "🇬🇧 English",
+}
+
+// catalogs are messages of each language by key
+var catalogs = map[Lang]map[string]string{
+	RU: ru,
+	EN: en,
+}
+
+// buttons maps texts of reply keyboard buttons in all languages to their keys
+var buttons = make(map[string]string)
+
+func init() {
+	for _, catalog := range catalogs {
+		for key, text := range catalog {
+			if strings.HasPrefix(key, "btn.") {
+				buttons[text] = key
+			}
+		}
+	}
+}
+
+// Parse returns supported language of code or default language
+func Parse(code string) Lang {
+	lang := Lang(code)
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+	return Default
+}
+
+// Detect returns language for Telegram language_code of user like "ru" or "en-US".
+// Russian is used for empty code, English for all other languages.
+func Detect(code string) Lang {
+	code = strings.ToLower(code)
+	switch {
+	case code == "":
+		return Default
+	case code == "ru" || strings.HasPrefix(code, "ru-"):
+		return RU
+	default:
+		return EN
+	}
+}
+
+// Name returns name of language in itself
+func Name(lang Lang) string {
+	return names[lang]
+}
+
+// T returns message of key in language, args are formatted like fmt.Sprintf.
+// Message of default language is used if language has no such message.
+func T(lang Lang, key string, args ...any) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		if msg, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Button returns key of reply keyboard button by its text in any language
+func Button(text string) (string, bool) {
+	key, ok := buttons[text]
+	return key, ok
+}
+
+type langKey struct{}
+
+// WithLang returns context with language of user
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// FromContext returns language of user from context or default language
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langKey{}).(Lang); ok {
+		return lang
+	}
+	return Default
+}
+
+// Error is an error for user, its message is translated to language of user
+type Error struct {
+	Key  string
+	Args []any
+}
+
+// Errorf returns error with message of key, args can be errors which are translated too
+func Errorf(key string, args ...any) error {
+	return &Error{Key: key, Args: args}
+}
+
+// Error returns message in default language
+func (e *Error) Error() string {
+	return Message(Default, e)
+}
+
+// Unwrap returns wrapped errors of args
+func (e *Error) Unwrap() []error {
+	var errs []error
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Message returns error message in language, errors without translation keep their message
+func Message(lang Lang, err error) string {
+	var e *Error
+	if !errors.As(err, &e) {
+		return err.Error()
+	}
+
+	args := make([]any, len(e.Args))
+	for i, arg := range e.Args {
+		if argErr, ok := arg.(error); ok {
+			args[i] = Message(lang, argErr)
+		} else {
+			args[i] = arg
+		}
+	}
+
+	return T(lang, e.Key, args...)
+}
@@ -0,0 +1,422 @@
+package i18n
+
+// ru is Russian catalog of messages
+var ru = map[string]string{
+	// common
+	"start.error":   "Произошла ошибка при регистрации. Попробуйте позже.",
+	"start.welcome": "👋 Привет, %s!\n\nЯ помогу вам вести учет расходов.\n\nИспользуйте кнопки ниже для управления:",
+	"help": `📚 <b>Справка по командам:</b>
+
+<b>➕ Добавить расход</b> - Добавить новый расход или доход
+Нажмите кнопку и отправьте голосовое сообщение или текст с описанием расхода.
+Доходы добавляются так же: например, «пришла зарплата 150к».
+Можно указать день: «вчера обедал за 700», «в пятницу такси 400».
+Если в сообщении несколько расходов, перед подтверждением любой можно убрать (🗑), сменить категорию (📂) или исправить сумму (💰).
+Добавьте хештег, чтобы отметить расходы поездки или проекта независимо от категории: «#отпуск такси 3000».
+
+<b>📊 Статистика</b> - Статистика
+Показать распределение расходов по категориям или тратам, доходы и баланс за период.
+Итоги пересчитываются в основную валюту по курсу ЦБ РФ на дату операции.
+В разделе «💼 Бюджеты» можно задать месячный лимит по категории — бот предупредит при 80% и 100% трат.
+Если трата в несколько раз больше обычной для категории или за день потрачено намного больше обычного, бот тоже предупредит.
+«📤 Экспорт» пришлет все расходы за период файлом CSV или XLSX.
+«📈 Графики» нарисуют круговую диаграмму по категориям и столбцы расходов по дням.
+«🔀 Сравнение» покажет, как изменились расходы категорий к прошлому месяцу или к той же неделе прошлого месяца, и тренд за полгода.
+
+<b>🧾 Чеки</b> - Расход по QR-коду
+Сфотографируйте QR-код кассового чека — бот возьмет сумму и время покупки. В подписи к фото можно написать, что куплено, чтобы выбрать категорию.
+
+<b>📥 Импорт выписки</b> - Загрузка расходов из банка
+Отправьте файл выписки OFX или CSV (Т-Банк, Альфа-Банк, Сбербанк) — бот подберет категории и покажет сводку перед сохранением.
+
+<b>📂 Категории</b> - Порядок в категориях
+Переименуйте категорию, задайте ей эмодзи, объедините две категории в одну или удалите лишнюю — расходы перейдут в выбранную категорию.
+Категории можно группировать: например, «Такси», «Метро» и «Топливо» внутри «Транспорта». В статистике по категориям группа показывает общую сумму, а подкатегории раскрываются по нажатию.
+
+<b>🗑 Корзина</b> - Удаленные расходы
+Восстановите случайно удаленный расход.
+
+<b>🔁 Регулярные платежи</b> - Аренда, связь, подписки
+Бот сам добавит расход в нужное число месяца и пришлет уведомление с кнопкой отмены.
+
+<b>👥 Общий учет</b> - Бюджет на двоих
+Добавьте бота в групповой чат — расходы всех участников попадут в общий учет с указанием, кто потратил. Статистику можно смотреть целиком или по участнику.
+В группе бот читает только сообщения с упоминанием бота или ответы на его сообщения, обычную переписку он не трогает.
+
+/undo - отменить последний добавленный расход
+/trash - открыть корзину
+/recurring - регулярные платежи
+/currency - основная валюта статистики
+/language - язык интерфейса
+/settings - часовой пояс, валюта по умолчанию, начало недели и еженедельные или ежемесячные сводки
+/ledger - общий учет, участники и ссылка-приглашение
+/categories - управление категориями
+/tags - облако тегов и статистика по тегу
+/find - поиск расходов по описанию, сумме, категории, валюте и датам
+
+💡 Если бот плохо угадывает категорию, вы можете сами подсказать её (например в скобках)`,
+	"main_menu":                   "Главное меню:",
+	"unknown_command":             "Неизвестная команда. Используйте /help для списка доступных команд.",
+	"unknown_action":              "Неизвестное действие",
+	"use_start":                   "Пожалуйста, используйте /start для начала работы.",
+	"user_not_found":              "Ошибка: пользователь не найден",
+	"cancelled":                   "Отменено.",
+	"no_category":                 "Без категории",
+	"amount.invalid":              "неверный формат суммы",
+	"amount.not_positive":         "сумма должна быть больше нуля",
+	"amount.retry":                "❌ %s. Введите сумму ещё раз, например: 1500 или 99.90",
+	"format.invalid":              "неверный формат",
+	"format.unsupported_currency": "валюта %s не поддерживается",
+	"categories.load_error":       "Ошибка загрузки категорий",
+	"categories.get_error":        "Ошибка получения категорий.",
+	"categories.empty":            "У вас пока нет категорий",
+	"expenses.load_error":         "Ошибка получения расходов.",
+	"expenses.save_error":         "Ошибка сохранения расходов",
+
+	// keyboards
+	"btn.add_expense":    "➕ Добавить расход",
+	"btn.stats":          "📊 Статистика",
+	"btn.week_expenses":  "💰 Траты за неделю",
+	"btn.trash":          "🗑 Корзина",
+	"btn.recurring":      "🔁 Регулярные платежи",
+	"btn.by_categories":  "📊 По категориям",
+	"btn.by_expenses":    "💸 По тратам",
+	"btn.budgets":        "💼 Бюджеты",
+	"btn.export":         "📤 Экспорт",
+	"btn.charts":         "📈 Графики",
+	"btn.compare":        "🔀 Сравнение",
+	"btn.today":          "📅 За сегодня",
+	"btn.week":           "📅 За неделю",
+	"btn.month":          "📅 За месяц",
+	"btn.all_time":       "📅 За всё время",
+	"btn.custom_period":  "📅 Кастомный период",
+	"btn.back":           "🔙 Назад",
+	"btn.cancel":         "❌ Отменить",
+	"kb.yes":             "✅ Да",
+	"kb.no":              "❌ Нет",
+	"kb.confirm":         "✅ Подтвердить",
+	"kb.cancel":          "❌ Отменить",
+	"kb.back":            "🔙 Назад",
+	"kb.edit_expense":    "✏️ Изменить: %s",
+	"kb.delete_income":   "🗑 Удалить доход: %s",
+	"kb.restore":         "↩️ Вернуть",
+	"kb.restore_expense": "♻️ Восстановить: %s",
+	"kb.add_budget":      "➕ Задать бюджет",
+	"kb.add_recurring":   "➕ Добавить платеж",
+	"kb.undo":            "↩️ Отменить",
+	"kb.amount":          "💰 Сумма",
+	"kb.currency":        "💱 Валюта",
+	"kb.category":        "📂 Категория",
+	"kb.description":     "📝 Описание",
+	"kb.delete":          "🗑 Удалить",
+	"kb.done":            "✅ Готово",
+	"kb.import":          "✅ Импортировать",
+
+	// currency
+	"currency.base":        "💱 <b>Основная валюта:</b> %s",
+	"currency.base_hint":   "Статистика пересчитывается в неё по курсу ЦБ РФ на дату каждой операции.",
+	"currency.unsupported": "Валюта не поддерживается",
+	"currency.save_error":  "Ошибка сохранения валюты",
+	"currency.saved":       "Валюта сохранена",
+	"stats.total":          "💰 <b>Всего:</b> %s\n",
+	"stats.income_balance": "📈 <b>Доходы:</b> %s\n⚖️ <b>Баланс:</b> %s\n",
+
+	// language
+	"language.current":     "🌐 <b>Язык интерфейса:</b> %s",
+	"language.unsupported": "Язык не поддерживается",
+	"language.save_error":  "Ошибка сохранения языка",
+	"language.saved":       "✅ Язык изменён",
+
+	// dates
+	"date.invalid_format":  "неверный формат даты",
+	"date.start_error":     "ошибка в начальной дате: %s",
+	"date.end_error":       "ошибка в конечной дате: %s",
+	"date.start_after_end": "начальная дата не может быть позже конечной",
+	"date.invalid_date":    "неверный формат даты (используйте ДД.ММ.ГГ или ДД.ММ)",
+	"date.invalid_month":   "месяц должен быть от 1 до 12",
+	"date.invalid_day":     "день должен быть от 1 до 31",
+	"date.nonexistent":     "несуществующая дата",
+
+	// edit
+	"edit.not_found":         "Ошибка: расход не найден",
+	"edit.enter_amount":      "💰 Введите новую сумму, например: <code>1500</code> или <code>99.90</code>",
+	"edit.enter_description": "📝 Введите новое описание или <code>-</code>, чтобы очистить его",
+	"edit.choose_category":   "📂 Выберите категорию или напишите название новой.",
+	"edit.saved":             "✅ <b>Расход сохранен:</b>",
+	"edit.category_error":    "Ошибка сохранения категории.",
+	"edit.save_error":        "Ошибка сохранения расхода.",
+	"edit.title":             "✏️ <b>Изменение расхода</b>",
+	"edit.what":              "Что изменить?",
+	"edit.details":           "💰 Сумма: %s %s\n📂 Категория: %s\n📝 Описание: %s\n📅 Дата: %s",
+
+	// confirmation
+	"confirm.item_not_found":     "Ошибка: позиция не найдена",
+	"confirm.item_dropped":       "Позиция удалена",
+	"confirm.category_not_found": "Ошибка: категория не найдена",
+	"confirm.enter_amount":       "💰 Введите сумму для «%s», например: <code>1500</code> или <code>99.90</code>",
+	"confirm.expired":            "Подтверждение устарело",
+	"confirm.expired_message":    "⌛ Подтверждение устарело, расход не сохранен. Отправьте его заново.",
+	"confirm.expenses":           "Подтвердите расходы:",
+	"confirm.operations":         "Подтвердите операции:",
+
+	// trash
+	"trash.delete_error":     "Ошибка удаления расхода.",
+	"trash.nothing_to_undo":  "Нет расходов для отмены.",
+	"trash.undone":           "↩️ <b>Последний расход отменен:</b>",
+	"trash.title":            "🗑 <b>Корзина</b>",
+	"trash.empty":            "<i>Корзина пуста.</i>",
+	"trash.hint":             "Нажмите на расход, чтобы восстановить его.",
+	"trash.already_deleted":  "Расход уже удален",
+	"trash.deleted":          "Расход удален",
+	"trash.moved":            "🗑 <b>Расход перемещен в корзину:</b>",
+	"trash.already_restored": "Расход уже восстановлен",
+	"trash.restored":         "Расход восстановлен",
+	"trash.restored_details": "♻️ <b>Расход восстановлен:</b>",
+
+	// income
+	"income.already_deleted":  "Доход уже удален",
+	"income.deleted":          "Доход удален",
+	"income.deleted_details":  "🗑 <b>Доход удален:</b>",
+	"income.already_restored": "Доход уже восстановлен",
+	"income.restored":         "Доход восстановлен",
+	"income.restored_details": "♻️ <b>Доход восстановлен:</b>",
+
+	// budgets
+	"budget.load_error":      "Ошибка получения бюджетов.",
+	"budget.title":           "💼 <b>Бюджеты на месяц</b>",
+	"budget.empty":           "<i>Бюджеты не заданы.</i>\n\nНажмите «Задать бюджет», чтобы ограничить траты по категории.",
+	"budget.enter":           "💼 Введите категорию и лимит на месяц.\nНапример: <code>Еда 30000 RUB</code> или <code>Такси 5000</code>",
+	"budget.already_deleted": "Бюджет уже удален",
+	"budget.deleted":         "Бюджет удален",
+	"budget.retry":           "❌ %s. Введите бюджет ещё раз, например: <code>Еда 30000 RUB</code>",
+	"budget.save_error":      "Ошибка сохранения бюджета.",
+	"budget.saved":           "✅ <b>Бюджет сохранен</b>",
+	"budget.exceeded":        "🚨 Бюджет «%s» превышен на %s %s!",
+	"budget.warning":         "⚠️ Потрачено больше %d%% бюджета «%s»",
+	"budget.remaining":       ", осталось %s %s",
+	"budget.overspent":       ", перерасход %s %s",
+
+	// recurring
+	"recurring.charged":         "🔁 <b>Регулярный платеж добавлен:</b>",
+	"recurring.next_charge":     "Следующее списание: %s",
+	"recurring.load_error":      "Ошибка получения регулярных платежей.",
+	"recurring.title":           "🔁 <b>Регулярные платежи</b>",
+	"recurring.empty":           "<i>Регулярных платежей нет.</i>\n\nДобавьте аренду, связь или подписки — бот будет записывать их сам.",
+	"recurring.upcoming":        "<b>Ближайшие списания:</b>",
+	"recurring.enter":           "🔁 Введите категорию, сумму и число месяца через запятую.\nНапример: <code>Аренда 30000 RUB, 5</code> или <code>Связь 600, 15 числа</code>",
+	"recurring.already_deleted": "Платеж уже удален",
+	"recurring.deleted":         "Платеж удален",
+	"recurring.retry":           "❌ %s. Введите платеж ещё раз, например: <code>Аренда 30000 RUB, 5</code>",
+	"recurring.save_error":      "Ошибка сохранения регулярного платежа.",
+	"recurring.saved":           "✅ <b>Регулярный платеж сохранен</b>",
+	"recurring.no_day":          "укажите число месяца через запятую",
+	"recurring.invalid_day":     "неверный формат числа месяца",
+	"recurring.day_range":       "число месяца должно быть от 1 до 31",
+	"recurring.item":            "📅 %s — %s, каждое %d-е число",
+
+	// import
+	"import.too_large":      "❌ Файл слишком большой. Выгрузите выписку за период покороче.",
+	"import.download_error": "Ошибка загрузки файла.",
+	"import.parse_error":    "❌ Не удалось прочитать выписку: %v",
+	"import.unknown_format": "❌ Формат выписки не распознан. Поддерживаются OFX и CSV выгрузки Т-Банка, Альфа-Банка и Сбербанка.",
+	"import.check_error":    "Ошибка проверки расходов.",
+	"import.no_expenses":    "В выписке нет расходов.",
+	"import.all_duplicates": "Все расходы из выписки уже добавлены (%d шт.).",
+	"import.too_many":       "❌ В выписке %d расходов, за раз можно импортировать не больше %d. Выгрузите выписку за период покороче.",
+	"import.categorizing":   "⏳ Выписка %s: найдено %d расходов, подбираю категории…",
+	"import.progress":       "⏳ Выписка %s: подбираю категории… %d из %d",
+	"import.in_progress":    "⏳ Предыдущая выписка еще обрабатывается, дождитесь ее итогов.",
+	"import.cancelled":      "Импорт отменен.",
+	"import.expired":        "⌛ Подтверждение устарело, выписка не импортирована. Отправьте файл заново.",
+	"import.done":           "Выписка импортирована!",
+	"import.saved":          "💾 <b>Импортировано расходов: %d</b>\n\n💰 <b>Всего:</b> %s",
+	"import.confirm":        "📥 <b>Подтвердите импорт выписки</b>",
+	"import.count":          "🧾 Расходов: %d",
+	"import.skipped":        "♻️ Уже добавлены и пропущены: %d",
+	"import.by_category":    "<b>По категориям:</b>",
+	"import.category_line":  "• %s — %s (%d шт.)",
+
+	// export
+	"export.choose_format":  "📤 <b>Экспорт расходов за %s</b>\n\nВыберите формат файла:",
+	"export.invalid_format": "Неверный формат экспорта",
+	"export.load_error":     "Ошибка получения расходов",
+	"export.empty":          "За этот период расходов нет",
+	"export.file_error":     "Ошибка формирования файла",
+	"export.caption":        "📤 Расходы за %s: %d шт.",
+
+	// receipts
+	"receipt.too_large":      "❌ Фото слишком большое.",
+	"receipt.download_error": "Ошибка загрузки фото.",
+	"receipt.read_error":     "❌ Не удалось прочитать чек: %v",
+	"receipt.qr_not_found":   "❌ QR-код не найден. Сфотографируйте QR-код чека крупнее, ровно и без бликов.",
+	"receipt.not_receipt":    "❌ Это не QR-код кассового чека.",
+	"receipt.not_purchase":   "❌ Это не чек покупки (возврат или выплата), он не добавляется в расходы.",
+	"receipt.description":    "Чек от %s",
+
+	// expenses and statistics
+	"expense.add":                "💰 <b>Добавление расхода</b>\n\nОтправьте голосовое сообщение или напишите текстом.\nНапример: <code>500 рублей на еду в Макдональдс</code>",
+	"expense.parse_error":        "Ошибка обработки текста.",
+	"expense.not_found":          "Не получилось получить расходы.",
+	"expense.added":              "✅ Расходы добавлены!\n\n💰",
+	"expense.operations_added":   "✅ Операции добавлены!\n\n💰",
+	"expense.saved":              "Расход сохранен!",
+	"expense.saved_list":         "💾 <b>Сохранено:</b>",
+	"income.save_error":          "Ошибка сохранения дохода.",
+	"income.load_error":          "Ошибка получения доходов.",
+	"voice.download_error":       "Ошибка получения голосового сообщения.",
+	"voice.transcribe_error":     "Ошибка распознавания голоса.",
+	"stats.choose_type":          "📊 <b>Выберите тип статистики:</b>",
+	"stats.categories_period":    "📊 <b>Статистика по категориям</b>\n\nВыберите период:",
+	"stats.export_period":        "📤 <b>Экспорт расходов</b>\n\nВыберите период:",
+	"stats.charts_period":        "📈 <b>Графики расходов</b>\n\nВыберите период:",
+	"stats.expenses_period":      "💸 <b>Статистика по тратам</b>\n\nВыберите период:",
+	"stats.empty":                "📊 <b>Статистика</b>\n\n<i>Пока нет расходов.</i>",
+	"stats.by_categories":        "📊 <b>Статистика по категориям:</b>",
+	"stats.income_by_categories": "📈 <b>Доходы по категориям:</b>",
+	"stats.by_expenses":          "📊 <b>Статистика по тратам:</b>",
+	"stats.no_expenses":          "<i>Нет расходов за этот период.</i>",
+	"stats.incomes":              "📈 <b>Доходы:</b>",
+	"stats.choose_to_edit":       "✏️ Выберите расход, чтобы изменить его:",
+	"period.voice_rejected":      "Пожалуйста, введите период текстом в формате: ДД.ММ.ГГ ДД.ММ.ГГ",
+	"period.enter":               "📅 <b>Введите кастомный период</b>\n\nФорматы:\n• <code>03.04.25 07.04.25</code>\n• <code>03.04.25 - 07.04.25</code>\n• <code>03.04 07.04</code> (текущий год)\n• <code>03.04 - 07.04</code> (текущий год)",
+	"period.retry":               "❌ Ошибка: %s\n\nПожалуйста, введите период в формате:\n• ДД.ММ.ГГ ДД.ММ.ГГ\n• ДД.ММ - ДД.ММ (текущий год)",
+	"period.too_long":            "❌ Для статистики по тратам период не может быть больше месяца (31 день).",
+
+	// settings
+	"settings.title":            "⚙️ <b>Настройки</b>",
+	"settings.timezone":         "🕐 Часовой пояс: <b>%s</b> (сейчас %s)",
+	"settings.currency":         "💱 Валюта по умолчанию: <b>%s</b>",
+	"settings.week_start":       "📅 Первый день недели: <b>%s</b>",
+	"settings.digests":          "📬 Сводки: <b>%s</b>",
+	"settings.digests_weekly":   "еженедельная",
+	"settings.digests_monthly":  "ежемесячная",
+	"settings.digests_off":      "выключены",
+	"settings.digests_prompt":   "📬 <b>Сводки расходов</b>\n\nБот пришлет итоги, крупнейшие категории и сравнение с прошлым периодом в %d:00 по вашему часовому поясу: за неделю — по понедельникам, за месяц — 1-го числа.",
+	"settings.saved":            "Настройка сохранена",
+	"settings.save_error":       "❌ Не удалось сохранить настройку",
+	"settings.timezone_prompt":  "Напишите часовой пояс в формате IANA, например <code>Asia/Yekaterinburg</code> или <code>Europe/Berlin</code>",
+	"settings.timezone_unknown": "❌ Неизвестный часовой пояс: %s",
+	"kb.timezone":               "🕐 Часовой пояс",
+	"kb.default_currency":       "💱 Валюта по умолчанию",
+	"kb.week_start":             "📅 Начало недели",
+	"kb.digests":                "📬 Сводки",
+	"kb.digest_weekly":          "Каждый понедельник",
+	"kb.digest_monthly":         "1-го числа каждого месяца",
+	"kb.other_timezone":         "✏️ Другой",
+	"weekday.0":                 "воскресенье",
+	"weekday.1":                 "понедельник",
+	"weekday.2":                 "вторник",
+	"weekday.3":                 "среда",
+	"weekday.4":                 "четверг",
+	"weekday.5":                 "пятница",
+	"weekday.6":                 "суббота",
+
+	// ledgers
+	"ledger.personal": `📒 <b>Личный учет</b>
+
+Эти расходы видите только вы.
+Чтобы вести общий бюджет семьи, добавьте меня в групповой чат: расходы всех участников группы попадут в общий учет, а статистику можно смотреть целиком или по каждому участнику.
+
+⚠️ В группе бот видит обычные сообщения, только если он администратор или в @BotFather для него отключен режим приватности.`,
+	"ledger.shared":            "👥 <b>Общий учет «%s»</b>",
+	"ledger.members":           "Участники:",
+	"ledger.invite":            "Ссылка-приглашение, чтобы добавлять расходы в этот учет из личного чата:\n%s",
+	"ledger.joined":            "✅ Вы присоединились к учету «%s».\n\nРасходы из этого чата теперь попадают в него. Вернуться к личному учету можно командой /ledger.",
+	"ledger.invite_invalid":    "❌ Ссылка-приглашение недействительна.",
+	"ledger.switched_personal": "📒 Расходы из этого чата снова попадают в ваш личный учет.",
+	"ledger.load_error":        "❌ Не удалось загрузить учет.",
+	"ledger.save_error":        "❌ Не удалось сменить учет. Попробуйте позже.",
+	"kb.personal_ledger":       "📒 Перейти к личному учету",
+	"kb.all_members":           "👥 Все участники",
+	"stats.member_choose":      "👥 Чью статистику показать?",
+	"stats.member":             "👤 %s",
+
+	// categories menu
+	"btn.categories":           "📂 Категории",
+	"kb.category_rename":       "✏️ Переименовать",
+	"kb.category_emoji":        "😀 Эмодзи",
+	"kb.category_merge":        "🔀 Объединить",
+	"kb.category_delete":       "🗑 Удалить",
+	"kb.category_none":         "🚫 Оставить без категории",
+	"categories.title":         "📂 <b>Категории</b>\nЧисло рядом с категорией — количество расходов, ↳ отмечает подкатегории. Выберите категорию, чтобы переименовать её, задать эмодзи, переместить в группу, объединить с другой или удалить.",
+	"categories.item":          "%s — %d",
+	"categories.detail":        "<b>%s</b>\nРасходов: %d",
+	"categories.not_found":     "Категория не найдена",
+	"categories.enter_name":    "✏️ Введите новое название категории <b>%s</b>",
+	"categories.name_invalid":  "Название должно быть не длиннее %d символов. Попробуйте еще раз",
+	"categories.exists":        "Категория «%s» уже есть. Чтобы перенести в нее расходы, объедините категории",
+	"categories.renamed":       "✅ Категория переименована",
+	"categories.enter_emoji":   "Отправьте эмодзи для категории <b>%s</b> или «-», чтобы убрать его",
+	"categories.emoji_invalid": "Это не похоже на эмодзи. Отправьте эмодзи или «-»",
+	"categories.emoji_set":     "✅ Эмодзи категории обновлено",
+	"categories.merge_choose":  "🔀 Выберите категорию, в которую перейдут все расходы <b>%s</b>. Сама категория будет удалена.",
+	"categories.delete_choose": "🗑 Категория <b>%s</b> будет удалена. Куда перенести её расходы (%d)?",
+	"categories.merged":        "✅ Категории объединены, перенесено расходов: %d",
+	"categories.deleted":       "✅ Категория удалена, перенесено расходов: %d",
+	"categories.save_error":    "Не удалось сохранить категорию",
+	"categories.no_targets":    "Нет других категорий для объединения",
+
+	// sub-categories
+	"kb.category_parent":       "📁 Переместить в группу",
+	"kb.category_top":          "⬆️ Сделать основной",
+	"categories.parent":        "Группа: %s",
+	"categories.parent_choose": "📁 Выберите категорию, в которую войдет <b>%s</b> как подкатегория.",
+	"categories.parent_set":    "✅ Группа категории изменена",
+	"categories.has_children":  "В категории есть подкатегории, а вложенность только двухуровневая. Сначала перенесите подкатегории",
+	"stats.category_other":     "Прочее",
+
+	// tags
+	"tags.title":       "🏷 <b>Теги</b>\nЧисло рядом с тегом — количество расходов. Выберите тег, чтобы увидеть статистику по нему.",
+	"tags.empty":       "Тегов пока нет. Добавьте хештег к расходу, например: <code>#отпуск такси 3000</code>",
+	"tags.load_error":  "Ошибка загрузки тегов",
+	"tags.stats_title": "🏷 <b>Расходы с тегом #%s</b>",
+	"tags.stats_count": "Расходов: %d",
+	"tags.stats_empty": "Расходов с этим тегом больше нет",
+
+	// search
+	"find.usage": `🔎 <b>Поиск расходов</b>
+Напишите после /find слова из описания и фильтры, например:
+<code>/find такси</code>
+<code>/find amount>1000 category:Еда currency:USD after:01.09</code>
+
+Фильтры:
+• <code>amount>1000</code>, <code>amount<500</code>, <code>amount=300</code> — сумма от, до или ровно
+• <code>category:Еда</code> — категория вместе с подкатегориями, название с пробелами берите в кавычки
+• <code>currency:USD</code> — валюта
+• <code>after:01.09</code>, <code>before:30.09.25</code> — даты включительно
+• <code>#отпуск</code> — тег`,
+	"find.results": "🔎 <b>%s</b>\nНайдено расходов: %d",
+	"find.empty":   "🔎 По запросу <b>%s</b> ничего не найдено",
+	"find.page":    "Страница %d из %d",
+	"find.expired": "Поиск устарел, повторите /find",
+	"find.error":   "Ошибка поиска расходов",
+
+	// charts
+	"charts.by_categories": "🍩 <b>Расходы по категориям</b>",
+	"charts.by_days":       "📊 <b>Расходы по дням</b>",
+	"charts.by_months":     "📊 <b>Расходы по месяцам</b>",
+	"charts.average":       "📐 <b>В среднем:</b> %s\n",
+	"charts.error":         "❌ Не удалось построить график. Попробуйте позже.",
+
+	// digests
+	"digest.weekly_title":      "📬 <b>Сводка за неделю</b>",
+	"digest.monthly_title":     "📬 <b>Сводка за месяц</b>",
+	"digest.no_expenses":       "<i>Расходов за этот период не было.</i>",
+	"digest.change_week":       "%s <b>%s</b> к прошлой неделе (%s)",
+	"digest.change_month":      "%s <b>%s</b> к прошлому месяцу (%s)",
+	"digest.no_previous_week":  "<i>На прошлой неделе расходов не было</i>",
+	"digest.no_previous_month": "<i>В прошлом месяце расходов не было</i>",
+	"digest.top_categories":    "🏆 <b>Крупнейшие категории:</b>",
+
+	// compare
+	"compare.month_title": "🔀 <b>Этот месяц и прошлый</b>",
+	"compare.week_title":  "🔀 <b>Эта неделя и та же неделя прошлого месяца</b>",
+	"compare.new":         "(🆕 новая)",
+	"compare.trend_hint":  "<i>Линия под категорией — ее расходы по месяцам за последние %d месяцев.</i>",
+	"kb.compare_month":    "📅 Месяц",
+	"kb.compare_week":     "📅 Неделя",
+
+	// anomaly
+	"anomaly.expense": "⚠️ Необычно крупная трата: %s в «%s» — обычно около %s (×%d)",
+	"anomaly.daily":   "📈 Сегодня потрачено <b>%s</b> — ×%d к обычному дню (медиана %s за последние %d дней)",
+}
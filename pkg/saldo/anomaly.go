@@ -0,0 +1,52 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"saldo/pkg/db"
+)
+
+const (
+	// AnomalyHistoryDays is number of days before expense that typical spending is computed from
+	AnomalyHistoryDays = 90
+
+	// AnomalyMinExpenses is min number of expenses of category in history to tell its typical amount
+	AnomalyMinExpenses = 5
+)
+
+// ExpenseMedian is median amount of category's expenses in one currency
+type ExpenseMedian struct {
+	db.ExpenseMedian
+}
+
+// GetExpenseMedians returns median amounts of ledger's expenses of categories spent within AnomalyHistoryDays before now.
+// Only expenses created before createdBefore are taken, so just saved ones do not affect their own median.
+// Categories with fewer than AnomalyMinExpenses expenses in currency are skipped.
+func (s *Manager) GetExpenseMedians(ctx context.Context, ledgerID int, categoryIDs []int, now, createdBefore time.Time) ([]ExpenseMedian, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	from := now.AddDate(0, 0, -AnomalyHistoryDays)
+	to := createdBefore.Add(-time.Microsecond)
+	medians, err := s.ecr.MedianExpensesByCategory(ctx, &db.ExpenseSearch{
+		LedgerID:    &ledgerID,
+		CategoryIDs: categoryIDs,
+		SpentAtFrom: &from,
+		CreatedAtTo: &to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense medians: %w", err)
+	}
+
+	var result []ExpenseMedian
+	for _, median := range medians {
+		if median.Count >= AnomalyMinExpenses && median.Median > 0 {
+			result = append(result, ExpenseMedian{ExpenseMedian: median})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,217 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"saldo/pkg/db"
+)
+
+// BudgetStatus is a monthly budget with amount spent in current month
+type BudgetStatus struct {
+	Budget
+	Spent       int64 // in cents, expenses in other currencies are converted by CBR rates of their days
+	CategoryIDs []int // budget category and its sub-categories whose expenses are counted
+}
+
+// Percent returns spent part of the budget in percents
+func (bs BudgetStatus) Percent() int64 {
+	if bs.Amount <= 0 {
+		return 0
+	}
+
+	return bs.Spent * 100 / bs.Amount
+}
+
+// Remaining returns amount left until the limit, negative if budget is overspent
+func (bs BudgetStatus) Remaining() int64 {
+	return bs.Amount - bs.Spent
+}
+
+// monthStart returns beginning of the month of t
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// SetBudget sets monthly limit for ledger's expense category, creating category if needed
+func (s *Manager) SetBudget(ctx context.Context, ledgerID, userID int, categoryTitle string, amount int64, currency string) (*Budget, error) {
+	category, err := s.FindOrCreateCategoryByTitle(ctx, ledgerID, userID, categoryTitle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find or create category: %w", err)
+	}
+
+	budget, err := s.ecr.OneBudget(ctx, &db.BudgetSearch{
+		LedgerID:   &ledgerID,
+		CategoryID: &category.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	if budget != nil {
+		budget.Amount = amount
+		budget.Currency = currency
+		budget.UpdatedAt = time.Now()
+
+		_, err = s.cr.UpdateBudget(ctx, budget, db.WithColumns(
+			db.Columns.Budget.Amount,
+			db.Columns.Budget.Currency,
+			db.Columns.Budget.UpdatedAt,
+		))
+	} else {
+		budget, err = s.cr.AddBudget(ctx, &db.Budget{
+			UserID:     userID,
+			LedgerID:   ledgerID,
+			CategoryID: category.ID,
+			Amount:     amount,
+			Currency:   currency,
+			StatusID:   db.StatusEnabled,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	budget.Category = &category.Category
+
+	s.log.Print(ctx, "budget set",
+		"budget_id", budget.ID,
+		"ledger_id", ledgerID,
+		"user_id", userID,
+		"category_id", category.ID,
+		"amount", amount,
+		"currency", currency,
+	)
+
+	return NewBudget(budget), nil
+}
+
+// GetBudgets returns ledger's budgets with categories
+func (s *Manager) GetBudgets(ctx context.Context, ledgerID int) ([]Budget, error) {
+	budgets, err := s.ecr.BudgetsByFilters(ctx, &db.BudgetSearch{
+		LedgerID: &ledgerID,
+	}, db.PagerNoLimit, s.ecr.FullBudget())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	return NewBudgets(budgets), nil
+}
+
+// GetBudgetStatuses returns ledger's budgets with amounts spent in current month, now is in user's time zone
+func (s *Manager) GetBudgetStatuses(ctx context.Context, ledgerID int, now time.Time) ([]BudgetStatus, error) {
+	budgets, err := s.GetBudgets(ctx, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.GetCategories(ctx, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := s.budgetStatus(ctx, budget, categories, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+
+	return statuses, nil
+}
+
+// GetCategoryBudgetStatus returns budget status of ledger's category or nil if category has no budget
+func (s *Manager) GetCategoryBudgetStatus(ctx context.Context, ledgerID, categoryID int, now time.Time) (*BudgetStatus, error) {
+	budget, err := s.ecr.OneBudget(ctx, &db.BudgetSearch{
+		LedgerID:   &ledgerID,
+		CategoryID: &categoryID,
+	}, s.ecr.FullBudget())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	} else if budget == nil {
+		return nil, nil
+	}
+
+	categories, err := s.GetCategories(ctx, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.budgetStatus(ctx, *NewBudget(budget), categories, now)
+}
+
+// DeleteBudget removes ledger's budget
+func (s *Manager) DeleteBudget(ctx context.Context, ledgerID, budgetID int) (*Budget, error) {
+	budget, err := s.ecr.OneBudget(ctx, &db.BudgetSearch{
+		ID:       &budgetID,
+		LedgerID: &ledgerID,
+	}, s.ecr.FullBudget())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	} else if budget == nil {
+		return nil, nil
+	}
+
+	if _, err := s.cr.DeleteBudget(ctx, budget.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	s.log.Print(ctx, "budget deleted", "budget_id", budget.ID, "ledger_id", ledgerID)
+
+	return NewBudget(budget), nil
+}
+
+// budgetStatus calculates amount spent for budget by all ledger members since beginning of month of now.
+// Expenses of sub-categories are included, expenses in other currencies are converted to budget currency.
+func (s *Manager) budgetStatus(ctx context.Context, budget Budget, categories []Category, now time.Time) (*BudgetStatus, error) {
+	categoryIDs := []int{budget.CategoryID}
+	for _, category := range categories {
+		if category.ParentID != nil && *category.ParentID == budget.CategoryID {
+			categoryIDs = append(categoryIDs, category.ID)
+		}
+	}
+
+	from := monthStart(now)
+	totals, err := s.ecr.SumExpensesByCategory(ctx, &db.ExpenseSearch{
+		LedgerID:    &budget.LedgerID,
+		CategoryIDs: categoryIDs,
+		SpentAtFrom: &from,
+	}, now.Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget spent amount: %w", err)
+	}
+
+	status := &BudgetStatus{Budget: budget, CategoryIDs: categoryIDs}
+	var days []time.Time
+	for _, total := range totals {
+		if total.Currency == budget.Currency {
+			status.Spent += total.Amount
+		} else {
+			days = append(days, total.Day)
+		}
+	}
+	if len(days) == 0 {
+		return status, nil
+	}
+
+	// Without rates budget shows expenses in its currency only rather than failing
+	rates, err := s.GetDailyRates(ctx, days)
+	if err != nil {
+		s.log.Error(ctx, "failed to get exchange rates of budget", "err", err, "budget_id", budget.ID)
+		return status, nil
+	}
+
+	for _, total := range totals {
+		if total.Currency == budget.Currency {
+			continue
+		}
+		if amount, ok := rates.Convert(total.Amount, total.Currency, budget.Currency, total.Day); ok {
+			status.Spent += amount
+		}
+	}
+
+	return status, nil
+}
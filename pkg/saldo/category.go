@@ -0,0 +1,263 @@
+package saldo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"saldo/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+)
+
+var (
+	// ErrCategoryExists is returned when category is renamed to title of another category of ledger
+	ErrCategoryExists = errors.New("category with this title already exists")
+
+	// ErrSameCategory is returned when category is merged into itself or becomes its own parent
+	ErrSameCategory = errors.New("category can not be merged into itself")
+
+	// ErrCategoryDepth is returned when category would be nested deeper than two levels
+	ErrCategoryDepth = errors.New("sub-category can not have sub-categories")
+)
+
+// CategoryUsage is a category with number of its expenses
+type CategoryUsage struct {
+	Category
+	ExpensesCount int
+}
+
+// GetCategoriesUsage returns ledger's expense categories with numbers of their expenses, most used first
+func (s *Manager) GetCategoriesUsage(ctx context.Context, ledgerID int) ([]CategoryUsage, error) {
+	categories, err := s.GetCategories(ctx, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.ecr.CountExpensesByCategory(ctx, &db.ExpenseSearch{LedgerID: &ledgerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expenses: %w", err)
+	}
+
+	usage := make([]CategoryUsage, len(categories))
+	for i, category := range categories {
+		usage[i] = CategoryUsage{Category: category, ExpensesCount: counts[category.ID]}
+	}
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].ExpensesCount != usage[j].ExpensesCount {
+			return usage[i].ExpensesCount > usage[j].ExpensesCount
+		}
+		return usage[i].Title < usage[j].Title
+	})
+
+	return usage, nil
+}
+
+// GetLedgerCategory returns ledger's category by ID or nil if it does not exist or belongs to another ledger
+func (s *Manager) GetLedgerCategory(ctx context.Context, ledgerID, categoryID int) (*Category, error) {
+	category, err := s.ecr.OneCategory(ctx, &db.CategorySearch{
+		ID:       &categoryID,
+		LedgerID: &ledgerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return NewCategory(category), nil
+}
+
+// RenameCategory changes title of ledger's category, title of another category of the same kind is not allowed
+func (s *Manager) RenameCategory(ctx context.Context, ledgerID, categoryID int, title string) (*Category, error) {
+	category, err := s.GetLedgerCategory(ctx, ledgerID, categoryID)
+	if err != nil || category == nil {
+		return nil, err
+	}
+
+	existing, err := s.ecr.OneCategory(ctx, &db.CategorySearch{
+		LedgerID: &ledgerID,
+		Title:    &title,
+		Kind:     &category.Kind,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search category: %w", err)
+	} else if existing != nil && existing.ID != category.ID {
+		return nil, ErrCategoryExists
+	}
+
+	category.Title = title
+	category.UpdatedAt = time.Now()
+	if _, err := s.cr.UpdateCategory(ctx, &category.Category, db.WithColumns(db.Columns.Category.Title, db.Columns.Category.UpdatedAt)); err != nil {
+		return nil, fmt.Errorf("failed to rename category: %w", err)
+	}
+
+	s.log.Print(ctx, "category renamed", "category_id", category.ID, "ledger_id", ledgerID, "title", title)
+
+	return category, nil
+}
+
+// SetCategoryEmoji sets emoji shown before title of ledger's category, empty emoji removes it
+func (s *Manager) SetCategoryEmoji(ctx context.Context, ledgerID, categoryID int, emoji string) (*Category, error) {
+	category, err := s.GetLedgerCategory(ctx, ledgerID, categoryID)
+	if err != nil || category == nil {
+		return nil, err
+	}
+
+	category.Emoji = nil
+	if emoji != "" {
+		category.Emoji = &emoji
+	}
+	category.UpdatedAt = time.Now()
+	if _, err := s.cr.UpdateCategory(ctx, &category.Category, db.WithColumns(db.Columns.Category.Emoji, db.Columns.Category.UpdatedAt)); err != nil {
+		return nil, fmt.Errorf("failed to set category emoji: %w", err)
+	}
+
+	s.log.Print(ctx, "category emoji set", "category_id", category.ID, "ledger_id", ledgerID, "emoji", emoji)
+
+	return category, nil
+}
+
+// SetCategoryParent moves ledger's category into parent category of the same kind, nil parent makes it top level.
+// Only two levels are allowed: parent can not be a sub-category and category with sub-categories can not be moved.
+func (s *Manager) SetCategoryParent(ctx context.Context, ledgerID, categoryID int, parentID *int) (*Category, error) {
+	category, err := s.GetLedgerCategory(ctx, ledgerID, categoryID)
+	if err != nil || category == nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		if *parentID == categoryID {
+			return nil, ErrSameCategory
+		}
+
+		parent, err := s.GetLedgerCategory(ctx, ledgerID, *parentID)
+		if err != nil {
+			return nil, err
+		} else if parent == nil || parent.Kind != category.Kind {
+			return nil, fmt.Errorf("parent category %d not found", *parentID)
+		} else if parent.ParentID != nil {
+			return nil, ErrCategoryDepth
+		}
+
+		children, err := s.ecr.CountCategories(ctx, &db.CategorySearch{ParentID: &categoryID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count sub-categories: %w", err)
+		} else if children > 0 {
+			return nil, ErrCategoryDepth
+		}
+	}
+
+	category.ParentID = parentID
+	category.UpdatedAt = time.Now()
+	if _, err := s.cr.UpdateCategory(ctx, &category.Category, db.WithColumns(db.Columns.Category.ParentID, db.Columns.Category.UpdatedAt)); err != nil {
+		return nil, fmt.Errorf("failed to set parent category: %w", err)
+	}
+
+	s.log.Print(ctx, "category parent set", "category_id", category.ID, "ledger_id", ledgerID, "parent_id", parentID)
+
+	return category, nil
+}
+
+// DeleteCategory deletes ledger's category and moves its expenses to target category, nil target leaves them without category.
+// Budget of category moves to target unless target has its own one. Sub-categories move to target if it is a top level
+// category, otherwise they become top level. Returns number of moved expenses.
+func (s *Manager) DeleteCategory(ctx context.Context, ledgerID, categoryID int, targetID *int) (int, error) {
+	var moved int
+
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		tm := s.withTransaction(tx)
+
+		category, err := tm.GetLedgerCategory(ctx, ledgerID, categoryID)
+		if err != nil {
+			return err
+		} else if category == nil {
+			return nil
+		}
+
+		var parentID *int
+		if targetID != nil {
+			if *targetID == categoryID {
+				return ErrSameCategory
+			}
+			target, err := tm.GetLedgerCategory(ctx, ledgerID, *targetID)
+			if err != nil {
+				return err
+			} else if target == nil || target.Kind != category.Kind {
+				return fmt.Errorf("target category %d not found", *targetID)
+			}
+			if target.ParentID == nil {
+				parentID = targetID
+			}
+		}
+
+		if moved, err = tm.cr.ReassignCategory(ctx, categoryID, targetID); err != nil {
+			return fmt.Errorf("failed to move expenses: %w", err)
+		}
+
+		if err := tm.cr.ReparentCategories(ctx, categoryID, parentID); err != nil {
+			return fmt.Errorf("failed to move sub-categories: %w", err)
+		}
+
+		if err := tm.moveCategoryBudget(ctx, ledgerID, categoryID, targetID); err != nil {
+			return err
+		}
+
+		if _, err := tm.cr.DeleteCategory(ctx, categoryID); err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.log.Print(ctx, "category deleted", "category_id", categoryID, "ledger_id", ledgerID, "target_id", targetID, "moved", moved)
+
+	return moved, nil
+}
+
+// MergeCategories moves all expenses of source category to target one and deletes source category
+func (s *Manager) MergeCategories(ctx context.Context, ledgerID, sourceID, targetID int) (int, error) {
+	return s.DeleteCategory(ctx, ledgerID, sourceID, &targetID)
+}
+
+// moveCategoryBudget moves budget of deleted category to target category or deletes it
+func (s *Manager) moveCategoryBudget(ctx context.Context, ledgerID, categoryID int, targetID *int) error {
+	budget, err := s.ecr.OneBudget(ctx, &db.BudgetSearch{
+		LedgerID:   &ledgerID,
+		CategoryID: &categoryID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get budget: %w", err)
+	} else if budget == nil {
+		return nil
+	}
+
+	if targetID != nil {
+		targetBudget, err := s.ecr.OneBudget(ctx, &db.BudgetSearch{
+			LedgerID:   &ledgerID,
+			CategoryID: targetID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get budget: %w", err)
+		}
+
+		if targetBudget == nil {
+			budget.CategoryID = *targetID
+			budget.UpdatedAt = time.Now()
+			_, err = s.cr.UpdateBudget(ctx, budget, db.WithColumns(db.Columns.Budget.CategoryID, db.Columns.Budget.UpdatedAt))
+			if err != nil {
+				return fmt.Errorf("failed to move budget: %w", err)
+			}
+			return nil
+		}
+	}
+
+	if _, err := s.cr.DeleteBudget(ctx, budget.ID); err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package saldo
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"saldo/pkg/services"
+)
+
+// DefaultRatesURL is daily exchange rates feed of the Central Bank of Russia
+const DefaultRatesURL = "https://www.cbr.ru/scripts/XML_daily.asp"
+
+// cbrTimezone is time zone of CBR calendar, rates are set for days in Moscow
+const cbrTimezone = "Europe/Moscow"
+
+// CBR loads daily exchange rates in the Central Bank of Russia XML format
+type CBR struct {
+	url    string
+	client *http.Client
+}
+
+// NewCBR creates rates feed client. Besides http(s) urls, file:// urls are supported
+// so a local XML file can stand in for the real feed.
+func NewCBR(feedURL string) *CBR {
+	if feedURL == "" {
+		feedURL = DefaultRatesURL
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+
+	return &CBR{
+		url:    feedURL,
+		client: &http.Client{Transport: transport, Timeout: 10 * time.Second},
+	}
+}
+
+type cbrValCurs struct {
+	Date    string `xml:"Date,attr"`
+	Valutes []struct {
+		CharCode string `xml:"CharCode"`
+		Nominal  string `xml:"Nominal"`
+		Value    string `xml:"Value"`
+	} `xml:"Valute"`
+}
+
+// Rates returns rates of the date in rubles for one unit of currency
+func (c *CBR) Rates(ctx context.Context, date time.Time) (Rates, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rates url: %w", err)
+	}
+	u.RawQuery = strings.TrimPrefix(u.RawQuery+"&date_req="+date.Format("02/01/2006"), "&")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rates feed error: %s", resp.Status)
+	}
+
+	return parseCBRRates(resp.Body)
+}
+
+// parseCBRRates parses ValCurs document of CBR daily feed
+func parseCBRRates(r io.Reader) (Rates, error) {
+	var valCurs cbrValCurs
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = cbrCharsetReader
+	if err := dec.Decode(&valCurs); err != nil {
+		return nil, fmt.Errorf("failed to parse rates: %w", err)
+	}
+
+	rates := Rates{CurrencyRUB: 1}
+	for _, v := range valCurs.Valutes {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v.Value), ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate of %s: %w", v.CharCode, err)
+		}
+		nominal, err := strconv.Atoi(strings.TrimSpace(v.Nominal))
+		if err != nil || nominal <= 0 {
+			return nil, fmt.Errorf("invalid nominal of %s: %q", v.CharCode, v.Nominal)
+		}
+
+		rates[strings.ToUpper(strings.TrimSpace(v.CharCode))] = value / float64(nominal)
+	}
+
+	if len(rates) == 1 {
+		return nil, fmt.Errorf("no rates in feed for %s", valCurs.Date)
+	}
+
+	return rates, nil
+}
+
+// cbrCharsetReader decodes windows-1251 feed
+func cbrCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	if !strings.EqualFold(charset, "windows-1251") {
+		return nil, fmt.Errorf("unsupported charset %s", charset)
+	}
+
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.NewReader(services.DecodeWindows1251(data)), nil
+}
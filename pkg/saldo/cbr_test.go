@@ -0,0 +1,158 @@
+package saldo
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCBRRates(t *testing.T) {
+	feed, err := os.ReadFile(filepath.Join("testdata", "cbr_daily.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    Rates
+		wantErr bool
+	}{
+		{
+			name: "daily feed in windows-1251",
+			data: string(feed),
+			want: Rates{"RUB": 1, "USD": 91.6359, "EUR": 100.3155, "CNY": 12.6812, "KZT": 0.20313},
+		},
+		{
+			name: "utf-8 feed",
+			data: `<?xml version="1.0" encoding="UTF-8"?><ValCurs Date="01.02.2024"><Valute><CharCode> usd </CharCode><Nominal>1</Nominal><Value>89.6883</Value></Valute></ValCurs>`,
+			want: Rates{"RUB": 1, "USD": 89.6883},
+		},
+		{
+			name:    "no rates",
+			data:    `<ValCurs Date="01.01.2024"></ValCurs>`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			data:    `<ValCurs><Valute><CharCode>USD</CharCode><Nominal>1</Nominal><Value>n/a</Value></Valute></ValCurs>`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid nominal",
+			data:    `<ValCurs><Valute><CharCode>USD</CharCode><Nominal>0</Nominal><Value>90,1</Value></Valute></ValCurs>`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported charset",
+			data:    `<?xml version="1.0" encoding="koi8-r"?><ValCurs></ValCurs>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCBRRates(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCBRRates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseCBRRates() = %v, want %v", got, tt.want)
+			}
+			for currency, rate := range tt.want {
+				if math.Abs(got[currency]-rate) > 1e-9 {
+					t.Errorf("rate of %s = %v, want %v", currency, got[currency], rate)
+				}
+			}
+		})
+	}
+}
+
+func TestCBRRates(t *testing.T) {
+	feed, err := os.ReadFile(filepath.Join("testdata", "cbr_daily.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dateReq string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dateReq = r.URL.Query().Get("date_req")
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	rates, err := NewCBR(server.URL).Rates(context.Background(), time.Date(2024, 3, 15, 23, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dateReq != "15/03/2024" {
+		t.Errorf("date_req = %q, want 15/03/2024", dateReq)
+	}
+	if rates["USD"] != 91.6359 {
+		t.Errorf("rate of USD = %v, want 91.6359", rates["USD"])
+	}
+}
+
+func TestRatesConvert(t *testing.T) {
+	rates := Rates{"RUB": 1, "USD": 90, "EUR": 99, "KZT": 0.2}
+
+	tests := []struct {
+		amount   int64
+		from, to string
+		want     int64
+		ok       bool
+	}{
+		{amount: 1000, from: "USD", to: "RUB", want: 90000, ok: true},
+		{amount: 9900, from: "RUB", to: "EUR", want: 100, ok: true},
+		{amount: 1000, from: "EUR", to: "USD", want: 1100, ok: true},
+		{amount: 100000, from: "KZT", to: "RUB", want: 20000, ok: true},
+		{amount: 500, from: "GEL", to: "GEL", want: 500, ok: true},
+		{amount: 500, from: "GEL", to: "RUB", ok: false},
+		{amount: 500, from: "RUB", to: "GEL", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := rates.Convert(tt.amount, tt.from, tt.to)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Convert(%d, %s, %s) = %d, %v, want %d, %v", tt.amount, tt.from, tt.to, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDailyRates(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily := DailyRates{
+		time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC): {"RUB": 1, "USD": 90},
+		time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC): {"RUB": 1, "USD": 92},
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want int64
+		ok   bool
+	}{
+		{name: "day in moscow", date: time.Date(2024, 3, 15, 23, 30, 0, 0, moscow), want: 9000, ok: true},
+		{name: "next moscow day of utc evening", date: time.Date(2024, 3, 15, 22, 0, 0, 0, time.UTC), want: 9200, ok: true},
+		{name: "day of totals at midnight utc", date: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC), want: 9200, ok: true},
+		{name: "unknown day", date: time.Date(2024, 3, 17, 12, 0, 0, 0, moscow), ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := daily.Convert(100, "USD", "RUB", tt.date)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Convert() = %d, %v, want %d, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -9,6 +9,14 @@ import "saldo/pkg/db"
 //colgen:Category:MapP(db.Category)
 //colgen:Expense
 //colgen:Expense:MapP(db.Expense)
+//colgen:Income
+//colgen:Income:MapP(db.Income)
+//colgen:Budget
+//colgen:Budget:MapP(db.Budget)
+//colgen:RecurringExpense
+//colgen:RecurringExpense:MapP(db.RecurringExpense)
+//colgen:Ledger
+//colgen:Ledger:MapP(db.Ledger)
 
 type User struct {
 	db.User
@@ -52,6 +60,62 @@ func NewExpense(in *db.Expense) *Expense {
 	}
 }
 
+type Income struct {
+	db.Income
+}
+
+func NewIncome(in *db.Income) *Income {
+	if in == nil {
+		return nil
+	}
+
+	return &Income{
+		Income: *in,
+	}
+}
+
+type Budget struct {
+	db.Budget
+}
+
+func NewBudget(in *db.Budget) *Budget {
+	if in == nil {
+		return nil
+	}
+
+	return &Budget{
+		Budget: *in,
+	}
+}
+
+type RecurringExpense struct {
+	db.RecurringExpense
+}
+
+func NewRecurringExpense(in *db.RecurringExpense) *RecurringExpense {
+	if in == nil {
+		return nil
+	}
+
+	return &RecurringExpense{
+		RecurringExpense: *in,
+	}
+}
+
+type Ledger struct {
+	db.Ledger
+}
+
+func NewLedger(in *db.Ledger) *Ledger {
+	if in == nil {
+		return nil
+	}
+
+	return &Ledger{
+		Ledger: *in,
+	}
+}
+
 // MapP converts slice of type T to slice of type M with given converter with pointers.
 func MapP[T, M any](a []T, f func(*T) *M) []M {
 	n := make([]M, len(a))
@@ -5,6 +5,26 @@ import (
 	"saldo/pkg/db"
 )
 
+type Budgets []Budget
+
+func (ll Budgets) IDs() []int {
+	r := make([]int, len(ll))
+	for i := range ll {
+		r[i] = ll[i].ID
+	}
+	return r
+}
+
+func (ll Budgets) Index() map[int]Budget {
+	r := make(map[int]Budget, len(ll))
+	for i := range ll {
+		r[ll[i].ID] = ll[i]
+	}
+	return r
+}
+
+func NewBudgets(in []db.Budget) Budgets { return MapP(in, NewBudget) }
+
 type Categories []Category
 
 func (ll Categories) IDs() []int {
@@ -45,6 +65,68 @@ func (ll Expenses) Index() map[int]Expense {
 
 func NewExpenses(in []db.Expense) Expenses { return MapP(in, NewExpense) }
 
+type Incomes []Income
+
+func (ll Incomes) IDs() []int {
+	r := make([]int, len(ll))
+	for i := range ll {
+		r[i] = ll[i].ID
+	}
+	return r
+}
+
+func (ll Incomes) Index() map[int]Income {
+	r := make(map[int]Income, len(ll))
+	for i := range ll {
+		r[ll[i].ID] = ll[i]
+	}
+	return r
+}
+
+func NewIncomes(in []db.Income) Incomes { return MapP(in, NewIncome) }
+
+type Ledgers []Ledger
+
+func (ll Ledgers) IDs() []int {
+	r := make([]int, len(ll))
+	for i := range ll {
+		r[i] = ll[i].ID
+	}
+	return r
+}
+
+func (ll Ledgers) Index() map[int]Ledger {
+	r := make(map[int]Ledger, len(ll))
+	for i := range ll {
+		r[ll[i].ID] = ll[i]
+	}
+	return r
+}
+
+func NewLedgers(in []db.Ledger) Ledgers { return MapP(in, NewLedger) }
+
+type RecurringExpenses []RecurringExpense
+
+func (ll RecurringExpenses) IDs() []int {
+	r := make([]int, len(ll))
+	for i := range ll {
+		r[i] = ll[i].ID
+	}
+	return r
+}
+
+func (ll RecurringExpenses) Index() map[int]RecurringExpense {
+	r := make(map[int]RecurringExpense, len(ll))
+	for i := range ll {
+		r[ll[i].ID] = ll[i]
+	}
+	return r
+}
+
+func NewRecurringExpenses(in []db.RecurringExpense) RecurringExpenses {
+	return MapP(in, NewRecurringExpense)
+}
+
 type Users []User
 
 func (ll Users) IDs() []int {
@@ -0,0 +1,196 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"saldo/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// digestLockName is advisory lock name that guards sending of digests
+const digestLockName = "digests"
+
+// DigestHour is hour of day in user's time zone when digests are delivered
+const DigestHour = 9
+
+// DigestKind is a period that digest sums up
+type DigestKind string
+
+const (
+	DigestWeekly  DigestKind = "weekly"
+	DigestMonthly DigestKind = "monthly"
+)
+
+// Digest is a summary of user's expenses for the past week or month that is due to be sent
+type Digest struct {
+	Kind     DigestKind
+	User     User
+	LedgerID int       // ledger of user's private chat
+	ChatID   int64     // private chat of user
+	Start    time.Time // start of period in user's time zone
+	End      time.Time // end of period in user's time zone
+}
+
+// PreviousPeriod returns period of the same kind just before digest period, expenses are compared with it
+func (d Digest) PreviousPeriod() (time.Time, time.Time) {
+	if d.Kind == DigestMonthly {
+		return d.Start.AddDate(0, -1, 0), d.Start.Add(-time.Nanosecond)
+	}
+	return d.Start.AddDate(0, 0, -7), d.Start.Add(-time.Nanosecond)
+}
+
+// NextDigestAt returns first delivery time of digest after t: Monday or the 1st day of month at DigestHour in location of t
+func NextDigestAt(kind DigestKind, t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), DigestHour, 0, 0, 0, t.Location())
+	for {
+		if day.After(t) && ((kind == DigestWeekly && day.Weekday() == time.Monday) || (kind == DigestMonthly && day.Day() == 1)) {
+			return day
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, DigestHour, 0, 0, 0, t.Location())
+	}
+}
+
+// digestPeriod returns period summed up by digest delivered at t: week or calendar month before day of t
+func digestPeriod(kind DigestKind, t time.Time) (time.Time, time.Time) {
+	end := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if kind == DigestMonthly {
+		return end.AddDate(0, -1, 0), end.Add(-time.Nanosecond)
+	}
+	return end.AddDate(0, 0, -7), end.Add(-time.Nanosecond)
+}
+
+// SetUserDigest subscribes user to digest or unsubscribes from it, first digest is delivered after now
+func (s *Manager) SetUserDigest(ctx context.Context, userID int, kind DigestKind, enabled bool, now time.Time) error {
+	user, err := s.cr.UserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	} else if user == nil {
+		return fmt.Errorf("user %d not found", userID)
+	}
+
+	var next *time.Time
+	if enabled {
+		at := NextDigestAt(kind, now.In(LoadLocation(user.Timezone)))
+		next = &at
+	}
+
+	if err := s.updateDigestAt(ctx, user, kind, next); err != nil {
+		return err
+	}
+
+	s.log.Print(ctx, "digest subscription changed", "user_id", userID, "kind", kind, "enabled", enabled)
+
+	return nil
+}
+
+// rescheduleDigests moves user's digests to morning in new time zone of user
+func (s *Manager) rescheduleDigests(ctx context.Context, userID int, timezone string, now time.Time) error {
+	user, err := s.cr.UserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	} else if user == nil {
+		return nil
+	}
+
+	for kind, at := range map[DigestKind]*time.Time{DigestWeekly: user.WeeklyDigestAt, DigestMonthly: user.MonthlyDigestAt} {
+		if at == nil {
+			continue
+		}
+		next := NextDigestAt(kind, now.In(LoadLocation(timezone)))
+		if err := s.updateDigestAt(ctx, user, kind, &next); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateDigestAt saves time of next digest of kind, nil unsubscribes user
+func (s *Manager) updateDigestAt(ctx context.Context, user *db.User, kind DigestKind, at *time.Time) error {
+	column := db.Columns.User.WeeklyDigestAt
+	if kind == DigestMonthly {
+		column = db.Columns.User.MonthlyDigestAt
+		user.MonthlyDigestAt = at
+	} else {
+		user.WeeklyDigestAt = at
+	}
+
+	if _, err := s.cr.UpdateUser(ctx, user, db.WithColumns(column)); err != nil {
+		return fmt.Errorf("failed to update %s digest: %w", kind, err)
+	}
+
+	return nil
+}
+
+// TakeDueDigests returns digests due by now and schedules next ones.
+// Digests missed while bot was stopped are sent once for their own period.
+// It runs under advisory lock, so several bot instances never send the same digest twice.
+func (s *Manager) TakeDueDigests(ctx context.Context, now time.Time) ([]Digest, error) {
+	var digests []Digest
+
+	err := s.db.RunInLock(ctx, digestLockName, func(tx *pg.Tx) error {
+		tm := s.withTransaction(tx)
+
+		for _, kind := range []DigestKind{DigestWeekly, DigestMonthly} {
+			search := &db.UserSearch{WeeklyDigestAtTo: &now}
+			if kind == DigestMonthly {
+				search = &db.UserSearch{MonthlyDigestAtTo: &now}
+			}
+
+			users, err := tm.ecr.UsersByFilters(ctx, search, db.PagerNoLimit)
+			if err != nil {
+				return fmt.Errorf("failed to get users with due %s digest: %w", kind, err)
+			}
+
+			for _, user := range users {
+				loc := LoadLocation(user.Timezone)
+				dueAt := user.WeeklyDigestAt
+				if kind == DigestMonthly {
+					dueAt = user.MonthlyDigestAt
+				}
+				start, end := digestPeriod(kind, dueAt.In(loc))
+
+				next := NextDigestAt(kind, now.In(loc))
+				if err := tm.updateDigestAt(ctx, &user, kind, &next); err != nil {
+					return err
+				}
+
+				// digest sums up ledger of user's private chat, it is shared ledger if user has chosen one
+				var ledger *db.Ledger
+				if user.LedgerID != nil {
+					ledger, err = tm.ecr.LedgerByID(ctx, *user.LedgerID)
+				} else {
+					ledger, err = tm.ecr.OneLedger(ctx, &db.LedgerSearch{TelegramChatID: &user.TelegramID})
+				}
+				if err != nil {
+					return fmt.Errorf("failed to get ledger: %w", err)
+				} else if ledger == nil {
+					continue
+				}
+
+				digests = append(digests, Digest{
+					Kind:     kind,
+					User:     *NewUser(&user),
+					LedgerID: ledger.ID,
+					ChatID:   user.TelegramID,
+					Start:    start,
+					End:      end,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, digest := range digests {
+		s.log.Print(ctx, "digest is due", "user_id", digest.User.ID, "kind", digest.Kind, "ledger_id", digest.LedgerID)
+	}
+
+	return digests, nil
+}
@@ -0,0 +1,136 @@
+package saldo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// emojiMaxLength is max length of category emoji in runes, it matches validation of categories.emoji
+	emojiMaxLength = 10
+
+	// emojiTimeout limits LLM request, category is created without waiting longer
+	emojiTimeout = 5 * time.Second
+
+	defaultExpenseEmoji = "💸"
+	defaultIncomeEmoji  = "💰"
+)
+
+// categoryEmojiKeywords maps lowercase stems of words of category titles to emoji.
+// The longest matching stem wins, rows are ordered from the most specific categories to the most general ones and
+// the first row wins stems of the same length, e.g. "Домашние животные" is 🐾, not 🛋.
+var categoryEmojiKeywords = []struct {
+	stems []string
+	emoji string
+}{
+	{[]string{"животн", "питом", "кот", "кошк", "собак", "ветеринар", "pet", "dog", "cat", "vet"}, "🐾"},
+	{[]string{"дети", "детск", "ребен", "ребён", "игрушк", "child", "kid", "baby", "toy"}, "🧸"},
+	{[]string{"такси", "taxi"}, "🚕"},
+	{[]string{"транспорт", "проезд", "метро", "автобус", "трамва", "электричк", "transport", "transit", "metro", "bus"}, "🚌"},
+	{[]string{"бензин", "топлив", "авто", "машин", "парков", "fuel", "gas", "gasoline", "petrol", "car", "parking"}, "🚗"},
+	{[]string{"путешеств", "отпуск", "отел", "гостиниц", "билет", "авиа", "travel", "vacation", "hotel", "flight", "ticket"}, "✈️"},
+	{[]string{"продукт", "супермаркет", "grocer", "supermarket"}, "🛒"},
+	{[]string{"кафе", "ресторан", "банкет", "обед", "кофе", "доставк", "cafe", "restaurant", "lunch", "coffee", "dining"}, "🍽"},
+	{[]string{"еда", "питан", "food", "meal"}, "🍔"},
+	{[]string{"аренд", "ипотек", "квартир", "rent", "mortgage"}, "🏠"},
+	{[]string{"коммунал", "жкх", "электроэнерг", "электричеств", "utilit", "electric"}, "💡"},
+	{[]string{"связь", "телефон", "мобил", "phone", "mobile"}, "📱"},
+	{[]string{"интернет", "подписк", "internet", "subscription", "streaming"}, "🌐"},
+	{[]string{"здоров", "аптек", "лекарств", "врач", "медиц", "стоматолог", "health", "pharmacy", "medic", "doctor", "dentist"}, "💊"},
+	{[]string{"спорт", "фитнес", "зал", "sport", "fitness", "gym"}, "🏋️"},
+	{[]string{"красот", "парикмах", "косметик", "beauty", "haircut", "cosmetic"}, "💅"},
+	{[]string{"одежд", "обув", "clothes", "clothing", "shoes", "apparel"}, "👕"},
+	{[]string{"развлеч", "кино", "театр", "концерт", "игр", "entertainment", "cinema", "movie", "concert", "game"}, "🎬"},
+	{[]string{"образован", "учеб", "курс", "книг", "education", "study", "course", "book"}, "📚"},
+	{[]string{"подар", "gift", "present"}, "🎁"},
+	{[]string{"благотвор", "charity", "donation"}, "❤️"},
+	{[]string{"страхов", "insurance"}, "🛡"},
+	{[]string{"налог", "штраф", "tax", "fine"}, "🧾"},
+	{[]string{"кредит", "долг", "банк", "комисс", "loan", "debt", "bank", "fee"}, "🏦"},
+	{[]string{"зарплат", "аванс", "оклад", "salary", "wage", "payroll"}, "💼"},
+	{[]string{"преми", "бонус", "bonus"}, "🏆"},
+	{[]string{"фриланс", "подработ", "freelance"}, "💻"},
+	{[]string{"кэшбэк", "кешбэк", "cashback"}, "🔙"},
+	{[]string{"процент", "вклад", "дивиденд", "инвест", "interest", "deposit", "dividend", "invest"}, "📈"},
+	{[]string{"дом", "домашн", "ремонт", "мебел", "хозяйств", "home", "house", "household", "repair", "furniture"}, "🛋"},
+	{[]string{"покупк", "шопинг", "маркетплейс", "электроник", "техник", "shopping", "purchase", "electronics"}, "🛍"},
+}
+
+// shortStemLength is max length in runes of stems that must be whole words, "кот" must not match "который"
+const shortStemLength = 3
+
+// shortStemEndings are endings that words matched by short stems may have, e.g. "коты" or "cars"
+var shortStemEndings = []string{"", "s", "es", "а", "я", "ы", "и", "у", "ю", "е", "ом", "ем", "ов", "ой", "ей", "ам", "ах", "ик", "ики"}
+
+// pickCategoryEmoji returns emoji for new category title, LLM is asked first and keyword table is used when it fails
+func (s *Manager) pickCategoryEmoji(ctx context.Context, title, kind string) string {
+	if s.emoji != nil {
+		ctx, cancel := context.WithTimeout(ctx, emojiTimeout)
+		defer cancel()
+
+		emoji, err := s.emoji.CategoryEmoji(ctx, title)
+		if err != nil {
+			s.log.Error(ctx, "failed to pick category emoji", "err", err, "title", title)
+		} else if IsEmoji(emoji) {
+			return emoji
+		}
+	}
+
+	return keywordEmoji(title, kind)
+}
+
+// keywordEmoji returns emoji of the longest stem matching a word of category title or default emoji of category kind
+func keywordEmoji(title, kind string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	emoji, matched := "", 0
+	for _, k := range categoryEmojiKeywords {
+		for _, stem := range k.stems {
+			for _, word := range words {
+				if length := utf8.RuneCountInString(stem); length > matched && matchesStem(word, stem) {
+					emoji, matched = k.emoji, length
+				}
+			}
+		}
+	}
+	if emoji != "" {
+		return emoji
+	}
+
+	if kind == CategoryKindIncome {
+		return defaultIncomeEmoji
+	}
+	return defaultExpenseEmoji
+}
+
+// matchesStem reports whether word is stem with some ending, short stems match only whole words with short endings
+func matchesStem(word, stem string) bool {
+	if !strings.HasPrefix(word, stem) {
+		return false
+	}
+	if utf8.RuneCountInString(stem) > shortStemLength {
+		return true
+	}
+	return slices.Contains(shortStemEndings, word[len(stem):])
+}
+
+// IsEmoji reports whether text may be used as category emoji: it is short and has no letters, digits or spaces
+func IsEmoji(text string) bool {
+	if text == "" || utf8.RuneCountInString(text) > emojiMaxLength {
+		return false
+	}
+
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || unicode.IsPunct(r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -12,77 +12,274 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"saldo/pkg/i18n"
 	"saldo/pkg/services"
 )
 
-const systemPrompt = `Ты — парсер денежных расходов. Извлеки информацию о расходах из текста и верни ТОЛЬКО валидный JSON массив.
-В каждом запросе пользователь будет присылать список уже существующих категорий и текст расхода.
-Если в тексте нет расходов, или они все с нулевой суммой, верни пустой JSON массив [].
+// systemPromptRU is instruction of LLM for users with Russian interface
+const systemPromptRU = `Ты — парсер денежных операций: расходов и доходов. Извлеки информацию об операциях из текста и верни ТОЛЬКО валидный JSON массив.
+В каждом запросе пользователь будет присылать сегодняшнюю дату, валюту по умолчанию, списки уже существующих категорий расходов и доходов и текст.
+Если в тексте нет операций, или они все с нулевой суммой, верни пустой JSON массив [].
 
 Формат ответа (МАССИВ):
 [
   {
+    "type": "expense|income",
     "amount": <целое число или число с плавающей точкой>,
     "currency": "RUB|USD|EUR|GBP|GEL|JPY|CNY|CHF|KZT",
     "category": "<непустая строка>",
-    "description": "<строка или пусто>"
+    "description": "<строка или пусто>",
+    "date": "<дата операции YYYY-MM-DD>"
   }
 ]
 
 Правила:
+- type = "income", если деньги пришли пользователю (зарплата, аванс, премия, кэшбэк, проценты, возврат долга, продажа вещей, подарок деньгами)
+- type = "expense" для всех трат и оплат
 - amount всегда должен быть в формате с плавающей точкой (например: 500.0, 20.50)
 - Если сумма целая — всё равно указывай десятичную часть .0 (например: 1200.0)
 - Если сумма содержит копейки/центы — сохраняй точное значение
-- Валюта по умолчанию RUB, если не указана
+- Если валюта не указана, используй валюту по умолчанию из запроса
 - Если описание неясно или повторяет сумму/категорию — оставь пустую строку "" в description
-- Если текст не содержит информации о расходе, не пытайся придумать её сам
-- Сумма всегда должна быть положительным числом
+- Если текст не содержит информации об операции, не пытайся придумать её сам
+- Сумма всегда должна быть положительным числом, даже для доходов
 - Категория не должна быть пустой
-- Сумма расхода не должна быть нулевой -- в таком случае игнорируй такой расход
+- Сумма операции не должна быть нулевой -- в таком случае игнорируй такую операцию
+- date — дата операции в формате YYYY-MM-DD. Относительные даты ("вчера", "позавчера", "в пятницу", "3 дня назад") вычисляй от сегодняшней даты из запроса, они всегда в прошлом или сегодня
+- Если дата в тексте не указана, date = сегодняшняя дата
 - Возвращай ТОЛЬКО JSON массив, без пояснений, текста или markdown
 
 Правила сопоставления категорий:
-- сопоставь расход с одной из существующих категорий, если она хорошо подходит по смыслу
+- Для расходов выбирай только из категорий расходов, для доходов — только из категорий доходов
+- сопоставь операцию с одной из существующих категорий, если она хорошо подходит по смыслу
 - Если подходящей категории нет, создай новую, даже если есть частично подходящая, но не точная.
-Не используй категории, которые не отражают смысл расхода.
+Не используй категории, которые не отражают смысл операции.
 - Если пользователь сам подсказывает что за категория, то если она подходит по смыслу, используй её.
+- Категории могут быть сгруппированы: запись "Транспорт (Такси, Метро)" означает категорию "Транспорт" с подкатегориями "Такси" и "Метро". Выбирай самую точную подходящую подкатегорию, а родительскую категорию — только если ни одна подкатегория не подходит. В поле category пиши название одной категории, без скобок и родителя.
 - Категория должна быть существительным в именительном падеже (например: "Еда", "Транспорт", "Развлечения", "Интернет подписки")
 - Будь точным: "Еда" для продуктов/ресторанов, "Транспорт" для такси/топлива, "Здоровье" для лекарств/врачей
+- Для доходов: "Зарплата" для зарплаты/аванса, "Кэшбэк", "Подработка", "Подарки", "Продажи"
 
 Примеры:
 
-Существующие категории: Еда, Транспорт, Дом
-Текст пользователя: "купил хлеба на 500 рублей"
-Вывод: [{"amount": 500.0, "currency": "RUB", "category": "Еда", "description": "хлеб"}]
-
-Существующие категории: Интернет сервисы, Авиабилеты, Развлечения, Еда
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Транспорт, Дом
+Категории доходов: Зарплата
+Ввод: "купил хлеба на 500 рублей"
+Вывод: [{"type": "expense", "amount": 500.0, "currency": "RUB", "category": "Еда", "description": "хлеб", "date": "2025-03-14"}]
+
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Интернет сервисы, Авиабилеты, Развлечения, Еда
+Категории доходов: Зарплата
 Ввод: "потратил 50 долларов на такси и 20 на кофе"
-Вывод: [{"amount": 50.0, "currency": "USD", "category": "Транспорт", "description": "такси"}, {"amount": 20.0, "currency": "USD", "category": "Еда", "description": "кофе"}]
+Вывод: [{"type": "expense", "amount": 50.0, "currency": "USD", "category": "Транспорт", "description": "такси", "date": "2025-03-14"}, {"type": "expense", "amount": 20.0, "currency": "USD", "category": "Еда", "description": "кофе", "date": "2025-03-14"}]
 
-Существующие категории: Еда, Общественный транспорт, Такси
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Общественный транспорт, Такси
+Категории доходов: Зарплата
 Ввод: "купил новый ноутбук за 50000"
-Вывод: [{"amount": 50000.0, "currency": "RUB", "category": "Электроника", "description": "ноутбук"}]
+Вывод: [{"type": "expense", "amount": 50000.0, "currency": "RUB", "category": "Электроника", "description": "ноутбук", "date": "2025-03-14"}]
 
-Существующие категории: Общественный транспорт, Дом
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Общественный транспорт, Дом
+Категории доходов: Зарплата
 Ввод: "купил новую лодку папе за 500к рублей и 3 куба досок за 30 тысяч"
-Вывод: [{"amount": 500000.0, "currency": "RUB", "category": "Водный транспорт", "description": "лодка папе"}, {"amount": 30000.0, "currency": "RUB", "category": "Стройматериалы", "description": "3 куба досок"}]
+Вывод: [{"type": "expense", "amount": 500000.0, "currency": "RUB", "category": "Водный транспорт", "description": "лодка папе", "date": "2025-03-14"}, {"type": "expense", "amount": 30000.0, "currency": "RUB", "category": "Стройматериалы", "description": "3 куба досок", "date": "2025-03-14"}]
 
-Существующие категории: Донаты стримерам
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Донаты стримерам
+Категории доходов: Зарплата
 Ввод: "Обед 60 лари, таблетки от гастрита 30 лари"
-Вывод: [{"amount": 60.0, "currency": "GEL", "category": "Еда", "description": "обед"}, {"amount": 30.0, "currency": "GEL", "category": "Медикаменты", "description": "таблетки от гастрита"}]
+Вывод: [{"type": "expense", "amount": 60.0, "currency": "GEL", "category": "Еда", "description": "обед", "date": "2025-03-14"}, {"type": "expense", "amount": 30.0, "currency": "GEL", "category": "Медикаменты", "description": "таблетки от гастрита", "date": "2025-03-14"}]
 
-Существующие категории: Еда, Бытовая техника
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Бытовая техника
+Категории доходов: Зарплата
 Ввод: "1200 на коммуналку"
-Вывод: [{"amount": 1200.0, "currency": "RUB", "category": "Дом", "description": "коммуналка"}]
+Вывод: [{"type": "expense", "amount": 1200.0, "currency": "RUB", "category": "Дом", "description": "коммуналка", "date": "2025-03-14"}]
 
-Существующие категории: Еда, Связь
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Связь
+Категории доходов: Зарплата
 Ввод: "Сегодня купил колбасу, сыр и оплатил такси"
 Вывод: []
 
-Существующие категории: Еда, Бары, Обувь
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Бары, Обувь
+Категории доходов: Зарплата
 Ввод: "Сегодня гулял в парке"
-Вывод: []`
+Вывод: []
+
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Транспорт
+Категории доходов: Зарплата
+Ввод: "пришла зарплата 150к"
+Вывод: [{"type": "income", "amount": 150000.0, "currency": "RUB", "category": "Зарплата", "description": "", "date": "2025-03-14"}]
+
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Кафе
+Категории доходов: Зарплата, Подработка
+Ввод: "вернули кэшбэк 350 рублей, а на обед ушло 900"
+Вывод: [{"type": "income", "amount": 350.0, "currency": "RUB", "category": "Кэшбэк", "description": "", "date": "2025-03-14"}, {"type": "expense", "amount": 900.0, "currency": "RUB", "category": "Кафе", "description": "обед", "date": "2025-03-14"}]
+
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Кафе
+Категории доходов: Зарплата
+Ввод: "вчера обедал за 700"
+Вывод: [{"type": "expense", "amount": 700.0, "currency": "RUB", "category": "Кафе", "description": "обед", "date": "2025-03-13"}]
+
+Сегодня: 2025-03-17, понедельник
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Транспорт
+Категории доходов: Зарплата
+Ввод: "в пятницу такси 400"
+Вывод: [{"type": "expense", "amount": 400.0, "currency": "RUB", "category": "Транспорт", "description": "такси", "date": "2025-03-14"}]
+
+Сегодня: 2025-03-14, пятница
+Валюта по умолчанию: RUB
+Категории расходов: Еда, Транспорт (Такси, Метро, Топливо)
+Категории доходов: Зарплата
+Ввод: "заправился на 3000 и проезд в метро 60"
+Вывод: [{"type": "expense", "amount": 3000.0, "currency": "RUB", "category": "Топливо", "description": "заправка", "date": "2025-03-14"}, {"type": "expense", "amount": 60.0, "currency": "RUB", "category": "Метро", "description": "", "date": "2025-03-14"}]`
+
+// systemPromptEN is instruction of LLM for users with English interface
+const systemPromptEN = `You are a parser of money operations: expenses and incomes. Extract operations from the text and return ONLY a valid JSON array.
+In every request the user sends today's date, default currency, lists of existing expense and income categories and the text.
+If the text has no operations, or all of them have zero amount, return an empty JSON array [].
+
+Response format (ARRAY):
+[
+  {
+    "type": "expense|income",
+    "amount": <integer or floating point number>,
+    "currency": "RUB|USD|EUR|GBP|GEL|JPY|CNY|CHF|KZT",
+    "category": "<non-empty string>",
+    "description": "<string or empty>",
+    "date": "<operation date YYYY-MM-DD>"
+  }
+]
+
+Rules:
+- type = "income" if money came to the user (salary, advance, bonus, cashback, interest, debt repayment, selling things, money gift)
+- type = "expense" for all spendings and payments
+- amount must always be a floating point number (for example: 500.0, 20.50)
+- If amount is whole, still add the .0 decimal part (for example: 1200.0)
+- If amount has cents, keep the exact value
+- If currency is not mentioned, use default currency of the request
+- If description is unclear or repeats amount/category, leave empty string "" in description
+- If the text has no information about an operation, do not make it up
+- Amount must always be positive, even for incomes
+- Category must not be empty
+- Amount of operation must not be zero, ignore such operations
+- date is operation date in YYYY-MM-DD format. Compute relative dates ("yesterday", "the day before yesterday", "on Friday", "3 days ago") from today's date of the request, they are always in the past or today
+- If the text has no date, date = today's date
+- Return ONLY the JSON array, without explanations, text or markdown
+
+Category matching rules:
+- Use only expense categories for expenses and only income categories for incomes
+- match the operation with one of existing categories if it fits well by meaning
+- If there is no suitable category, create a new one, even if there is a partially suitable but not exact one.
+Do not use categories that do not reflect the meaning of the operation.
+- If the user hints the category and it fits by meaning, use it.
+- Categories may be grouped: "Transport (Taxi, Metro)" means category "Transport" with sub-categories "Taxi" and "Metro". Choose the most precise suitable sub-category and use the parent category only if no sub-category fits. Write the title of one category in the category field, without brackets or parent.
+- Category must be a noun in English with capital first letter (for example: "Food", "Transport", "Entertainment", "Online subscriptions")
+- Be precise: "Food" for groceries/restaurants, "Transport" for taxi/fuel, "Health" for medicines/doctors
+- For incomes: "Salary" for salary/advance, "Cashback", "Side job", "Gifts", "Sales"
+
+Examples:
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Transport, Home
+Income categories: Salary
+Input: "bought bread for 5 dollars"
+Output: [{"type": "expense", "amount": 5.0, "currency": "USD", "category": "Food", "description": "bread", "date": "2025-03-14"}]
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Online services, Flights, Entertainment, Food
+Income categories: Salary
+Input: "spent 50 euros on taxi and 20 on coffee"
+Output: [{"type": "expense", "amount": 50.0, "currency": "EUR", "category": "Transport", "description": "taxi", "date": "2025-03-14"}, {"type": "expense", "amount": 20.0, "currency": "EUR", "category": "Food", "description": "coffee", "date": "2025-03-14"}]
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Public transport, Taxi
+Income categories: Salary
+Input: "bought a new laptop for 1200"
+Output: [{"type": "expense", "amount": 1200.0, "currency": "USD", "category": "Electronics", "description": "laptop", "date": "2025-03-14"}]
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Appliances
+Income categories: Salary
+Input: "150 for utilities"
+Output: [{"type": "expense", "amount": 150.0, "currency": "USD", "category": "Home", "description": "utilities", "date": "2025-03-14"}]
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Bars, Shoes
+Income categories: Salary
+Input: "walked in the park today"
+Output: []
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Transport
+Income categories: Salary
+Input: "got my salary 3k"
+Output: [{"type": "income", "amount": 3000.0, "currency": "USD", "category": "Salary", "description": "", "date": "2025-03-14"}]
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Cafe
+Income categories: Salary, Side job
+Input: "got 15 dollars cashback and lunch cost 25 lari"
+Output: [{"type": "income", "amount": 15.0, "currency": "USD", "category": "Cashback", "description": "", "date": "2025-03-14"}, {"type": "expense", "amount": 25.0, "currency": "GEL", "category": "Cafe", "description": "lunch", "date": "2025-03-14"}]
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Cafe
+Income categories: Salary
+Input: "had lunch yesterday for 12"
+Output: [{"type": "expense", "amount": 12.0, "currency": "USD", "category": "Cafe", "description": "lunch", "date": "2025-03-13"}]
+
+Today: 2025-03-17, Monday
+Default currency: USD
+Expense categories: Food, Transport
+Income categories: Salary
+Input: "taxi 20 on Friday"
+Output: [{"type": "expense", "amount": 20.0, "currency": "USD", "category": "Transport", "description": "taxi", "date": "2025-03-14"}]
+
+Today: 2025-03-14, Friday
+Default currency: USD
+Expense categories: Food, Transport (Taxi, Metro, Fuel)
+Income categories: Salary
+Input: "filled up the car for 50 and metro ride 3"
+Output: [{"type": "expense", "amount": 50.0, "currency": "USD", "category": "Fuel", "description": "", "date": "2025-03-14"}, {"type": "expense", "amount": 3.0, "currency": "USD", "category": "Metro", "description": "", "date": "2025-03-14"}]`
+
+// emojiPrompt is instruction of LLM for choosing emoji of category
+const emojiPrompt = `You choose an icon for a category of personal expenses or incomes.
+The user sends a category title in any language. Reply with exactly ONE emoji that best represents it, without any text, quotes or explanation.`
+
+// systemPrompts are LLM instructions by language of user
+var systemPrompts = map[i18n.Lang]string{
+	i18n.RU: systemPromptRU,
+	i18n.EN: systemPromptEN,
+}
 
 const generalModel = "meta-llama/llama-4-scout-17b-16e-instruct"
 const sttModel = "whisper-large-v3-turbo"
@@ -122,7 +319,7 @@ const (
 	AssistantRole GroqRole = "assistant"
 )
 
-func (g *Groq) callChat(ctx context.Context, userPrompt string) (string, error) {
+func (g *Groq) callChat(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	const endpoint = "https://api.groq.com/openai/v1/chat/completions"
 	reqBody := groqChatRequest{
 		Model: generalModel,
@@ -169,27 +366,79 @@ func (g *Groq) callChat(ctx context.Context, userPrompt string) (string, error)
 	return result.Choices[0].Message.Content, nil
 }
 
-func buildExpensePrompt(text string, userCategories []string) string {
-	categories := strings.Join(userCategories, ", ")
-	return fmt.Sprintf("Существующие категории: %s\n\nТекст пользователя с расходами: %s\n", categories, text)
+// weekdays are Russian names of week days for LLM prompt
+var weekdays = [...]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"}
+
+func buildExpensePrompt(text string, lang i18n.Lang, now time.Time, currency string, expenseCategories, incomeCategories []string) string {
+	if lang == i18n.EN {
+		return fmt.Sprintf("Today: %s, %s\nDefault currency: %s\nExpense categories: %s\nIncome categories: %s\n\nUser text: %s\n",
+			now.Format(time.DateOnly), now.Weekday(), currency,
+			strings.Join(expenseCategories, ", "), strings.Join(incomeCategories, ", "), text)
+	}
+
+	return fmt.Sprintf("Сегодня: %s, %s\nВалюта по умолчанию: %s\nКатегории расходов: %s\nКатегории доходов: %s\n\nТекст пользователя: %s\n",
+		now.Format(time.DateOnly), weekdays[now.Weekday()], currency,
+		strings.Join(expenseCategories, ", "), strings.Join(incomeCategories, ", "), text)
+}
+
+// groqExpense is operation in LLM response, date is a string in YYYY-MM-DD format
+type groqExpense struct {
+	services.ParsedExpense
+	Date string `json:"date"`
 }
 
-func (g *Groq) ParseExpenses(ctx context.Context, text string, userCategories []string) ([]services.ParsedExpense, error) {
-	userPrompt := buildExpensePrompt(text, userCategories)
+func (g *Groq) ParseExpenses(ctx context.Context, text string, lang i18n.Lang, now time.Time, currency string, expenseCategories, incomeCategories []string) ([]services.ParsedExpense, error) {
+	systemPrompt, ok := systemPrompts[lang]
+	if !ok {
+		systemPrompt = systemPrompts[i18n.Default]
+	}
+	userPrompt := buildExpensePrompt(text, lang, now, currency, expenseCategories, incomeCategories)
 
-	response, err := g.callChat(ctx, userPrompt)
+	response, err := g.callChat(ctx, systemPrompt, userPrompt)
 	if err != nil {
 		return nil, fmt.Errorf("groq api call failed: %w", err)
 	}
 
-	var expenses []services.ParsedExpense
-	if err := json.Unmarshal([]byte(response), &expenses); err != nil {
+	var list []groqExpense
+	if err := json.Unmarshal([]byte(response), &list); err != nil {
 		return nil, fmt.Errorf("failed to parse groq response: %w, response: %s", err, response)
 	}
 
+	expenses := make([]services.ParsedExpense, len(list))
+	for i, e := range list {
+		expenses[i] = e.ParsedExpense
+		expenses[i].Date = expenseDate(e.Date, now)
+	}
+
 	return expenses, nil
 }
 
+// expenseDate returns operation date from LLM response with time of day of now.
+// Zero time is returned for today, invalid or future dates, such operations happened now.
+func expenseDate(date string, now time.Time) time.Time {
+	day, err := time.ParseInLocation(time.DateOnly, date, now.Location())
+	if err != nil {
+		return time.Time{}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !day.Before(today) {
+		return time.Time{}
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), now.Hour(), now.Minute(), now.Second(), 0, now.Location())
+}
+
+// CategoryEmoji asks LLM for emoji that fits category title
+func (g *Groq) CategoryEmoji(ctx context.Context, title string) (string, error) {
+	response, err := g.callChat(ctx, emojiPrompt, title)
+	if err != nil {
+		return "", fmt.Errorf("groq api call failed: %w", err)
+	}
+
+	return strings.TrimSpace(response), nil
+}
+
 func NewAudioRequest(filePath string, fields map[string]string) (*bytes.Buffer, string, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -218,12 +467,12 @@ func NewAudioRequest(filePath string, fields map[string]string) (*bytes.Buffer,
 	return body, writer.FormDataContentType(), nil
 }
 
-func (g *Groq) callTranscription(ctx context.Context, audioFilePath string) (string, error) {
+func (g *Groq) callTranscription(ctx context.Context, audioFilePath string, lang i18n.Lang) (string, error) {
 	const endpoint = "https://api.groq.com/openai/v1/audio/transcriptions"
 
 	fields := map[string]string{
 		"model":       sttModel,
-		"language":    "ru",
+		"language":    string(lang),
 		"temperature": "0",
 	}
 	body, contentType, err := NewAudioRequest(audioFilePath, fields)
@@ -258,14 +507,14 @@ func (g *Groq) callTranscription(ctx context.Context, audioFilePath string) (str
 	return text.Text, nil
 }
 
-func (g *Groq) Transcribe(ctx context.Context, oggFilePath string) (string, error) {
+func (g *Groq) Transcribe(ctx context.Context, oggFilePath string, lang i18n.Lang) (string, error) {
 	tmpWav, err := ConvertOggToWav(ctx, oggFilePath)
 	if err != nil {
 		return "", fmt.Errorf("convert ogg to wav: %w", err)
 	}
 	defer os.Remove(tmpWav)
 
-	text, err := g.callTranscription(ctx, tmpWav)
+	text, err := g.callTranscription(ctx, tmpWav, lang)
 	if err != nil {
 		return "", fmt.Errorf("transcription failed: %w", err)
 	}
@@ -0,0 +1,129 @@
+package saldo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"saldo/pkg/db"
+)
+
+// inviteCodeBytes is length of random part of invite code, code is twice as long in hex
+const inviteCodeBytes = 8
+
+// newInviteCode returns random code for /start deep link that adds user to ledger
+func newInviteCode() (string, error) {
+	b := make([]byte, inviteCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// GetOrCreateChatLedger returns ledger of telegram chat or creates a new one with given title.
+// Private chat has personal ledger of user, group chat has ledger shared by its members.
+func (s *Manager) GetOrCreateChatLedger(ctx context.Context, chatID int64, title string) (*Ledger, error) {
+	ledger, err := s.ecr.OneLedger(ctx, &db.LedgerSearch{TelegramChatID: &chatID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger: %w", err)
+	} else if ledger != nil {
+		return NewLedger(ledger), nil
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invite code: %w", err)
+	}
+
+	ledger, err = s.cr.AddLedger(ctx, &db.Ledger{
+		Title:          title,
+		TelegramChatID: chatID,
+		InviteCode:     code,
+		StatusID:       db.StatusEnabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ledger: %w", err)
+	}
+
+	s.log.Print(ctx, "ledger created", "ledger_id", ledger.ID, "chat_id", chatID, "title", title)
+
+	return NewLedger(ledger), nil
+}
+
+// GetLedgerByID returns ledger by ID or nil if it does not exist
+func (s *Manager) GetLedgerByID(ctx context.Context, ledgerID int) (*Ledger, error) {
+	ledger, err := s.ecr.LedgerByID(ctx, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger: %w", err)
+	}
+
+	return NewLedger(ledger), nil
+}
+
+// GetLedgerByInviteCode returns ledger that invite code belongs to or nil if code is unknown
+func (s *Manager) GetLedgerByInviteCode(ctx context.Context, code string) (*Ledger, error) {
+	ledger, err := s.ecr.OneLedger(ctx, &db.LedgerSearch{InviteCode: &code})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger: %w", err)
+	}
+
+	return NewLedger(ledger), nil
+}
+
+// AddLedgerMember adds user to ledger members, returns false if user is already a member
+func (s *Manager) AddLedgerMember(ctx context.Context, ledgerID, userID int) (bool, error) {
+	member, err := s.cr.OneLedgerMember(ctx, &db.LedgerMemberSearch{
+		LedgerID: &ledgerID,
+		UserID:   &userID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get ledger member: %w", err)
+	} else if member != nil {
+		return false, nil
+	}
+
+	_, err = s.cr.AddLedgerMember(ctx, &db.LedgerMember{
+		LedgerID: ledgerID,
+		UserID:   userID,
+	}, db.OnConflict(`("ledgerId", "userId") DO NOTHING`))
+	if err != nil {
+		return false, fmt.Errorf("failed to add ledger member: %w", err)
+	}
+
+	s.log.Print(ctx, "ledger member added", "ledger_id", ledgerID, "user_id", userID)
+
+	return true, nil
+}
+
+// GetLedgerMembers returns users of ledger in order they joined it
+func (s *Manager) GetLedgerMembers(ctx context.Context, ledgerID int) ([]User, error) {
+	members, err := s.cr.LedgerMembersByFilters(ctx, &db.LedgerMemberSearch{
+		LedgerID: &ledgerID,
+	}, db.PagerNoLimit, s.cr.FullLedgerMember(), db.WithSort(db.NewSortField(db.Columns.LedgerMember.ID, false)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger members: %w", err)
+	}
+
+	users := make([]User, 0, len(members))
+	for _, member := range members {
+		if member.User != nil {
+			users = append(users, *NewUser(member.User))
+		}
+	}
+
+	return users, nil
+}
+
+// SetUserLedger sets ledger that user's private chat writes to, nil is personal ledger of user
+func (s *Manager) SetUserLedger(ctx context.Context, userID int, ledgerID *int) error {
+	user := &db.User{ID: userID, LedgerID: ledgerID}
+	if _, err := s.cr.UpdateUser(ctx, user, db.WithColumns(db.Columns.User.LedgerID)); err != nil {
+		return fmt.Errorf("failed to update ledger: %w", err)
+	}
+
+	s.log.Print(ctx, "active ledger changed", "user_id", userID, "ledger_id", ledgerID)
+
+	return nil
+}
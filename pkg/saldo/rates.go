@@ -0,0 +1,226 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"saldo/pkg/db"
+)
+
+// CurrencyRUB is currency all CBR rates are quoted against
+const CurrencyRUB = "RUB"
+
+// ratesRetryInterval is how long fallback rates are used before feed is requested again
+const ratesRetryInterval = time.Hour
+
+// Rates maps currency to its price in rubles for one unit
+type Rates map[string]float64
+
+// Convert converts amount in cents between currencies, false is returned if any rate is unknown
+func (r Rates) Convert(amount int64, from, to string) (int64, bool) {
+	if from == to {
+		return amount, true
+	}
+
+	fromRate, ok := r[from]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r[to]
+	if !ok || toRate == 0 {
+		return 0, false
+	}
+
+	return int64(math.Round(float64(amount) * fromRate / toRate)), true
+}
+
+// ratesCache keeps rates loaded by the process, fallback rates expire to retry the feed later
+type ratesCache struct {
+	mu   sync.Mutex
+	days map[string]ratesCacheEntry
+}
+
+type ratesCacheEntry struct {
+	rates     Rates
+	expiresAt time.Time // zero for rates of the day itself
+}
+
+func newRatesCache() *ratesCache {
+	return &ratesCache{days: make(map[string]ratesCacheEntry)}
+}
+
+func (c *ratesCache) get(day string) (Rates, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.days[day]
+	if !ok || (!entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt)) {
+		return nil, false
+	}
+
+	return entry.rates, true
+}
+
+func (c *ratesCache) set(day string, rates Rates, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := ratesCacheEntry{rates: rates}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.days[day] = entry
+}
+
+// DailyRates are exchange rates by CBR day at midnight UTC
+type DailyRates map[time.Time]Rates
+
+// On returns rates of the date or nil, day of date is taken in Moscow
+func (d DailyRates) On(date time.Time) Rates {
+	return d[ratesDay(date)]
+}
+
+// Convert converts amount in cents between currencies by rates of the date, false is returned if any rate is unknown
+func (d DailyRates) Convert(amount int64, from, to string, date time.Time) (int64, bool) {
+	if from == to {
+		return amount, true
+	}
+	return d.On(date).Convert(amount, from, to)
+}
+
+// ratesDay returns CBR day of date at midnight UTC, CBR sets rates by Moscow calendar
+func ratesDay(date time.Time) time.Time {
+	date = date.In(LoadLocation(cbrTimezone))
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// GetRates returns exchange rates of the date, day of date is taken in Moscow as CBR sets rates by its calendar.
+// Rates are loaded from CBR feed once per day and cached in database.
+// If feed is unavailable, the latest cached rates before the date are returned.
+func (s *Manager) GetRates(ctx context.Context, date time.Time) (Rates, error) {
+	daily, err := s.GetDailyRates(ctx, []time.Time{date})
+	if err != nil {
+		return nil, err
+	}
+
+	return daily.On(date), nil
+}
+
+// GetDailyRates returns exchange rates of all dates at once, see GetRates.
+// Rates missing in process cache are read from database by one query,
+// only days that are not in database yet are requested from CBR feed.
+func (s *Manager) GetDailyRates(ctx context.Context, dates []time.Time) (DailyRates, error) {
+	daily := make(DailyRates, len(dates))
+	var missing []time.Time
+	for _, date := range dates {
+		day := ratesDay(date)
+		if _, ok := daily[day]; ok || slices.ContainsFunc(missing, day.Equal) {
+			continue
+		}
+		if rates, ok := s.rates.get(day.Format(time.DateOnly)); ok {
+			daily[day] = rates
+		} else {
+			missing = append(missing, day)
+		}
+	}
+	if len(missing) == 0 {
+		return daily, nil
+	}
+
+	from := slices.MinFunc(missing, time.Time.Compare)
+	to := slices.MaxFunc(missing, time.Time.Compare)
+	list, err := s.cr.ExchangeRatesByFilters(ctx, &db.ExchangeRateSearch{DateFrom: &from, DateTo: &to}, db.PagerNoLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	stored := make(map[time.Time][]db.ExchangeRate)
+	for _, r := range list {
+		day := time.Date(r.Date.Year(), r.Date.Month(), r.Date.Day(), 0, 0, 0, 0, time.UTC)
+		stored[day] = append(stored[day], r)
+	}
+
+	feedFailed := false
+	for _, day := range missing {
+		key := day.Format(time.DateOnly)
+		if list, ok := stored[day]; ok {
+			daily[day] = newRates(list)
+			s.rates.set(key, daily[day], 0)
+			continue
+		}
+
+		// feed is not requested again for other days once it failed
+		var rates Rates
+		if !feedFailed {
+			if rates, err = s.loadRates(ctx, day); err != nil {
+				s.log.Error(ctx, "failed to load exchange rates", "err", err, "date", key)
+				feedFailed = true
+			}
+		}
+		ttl := time.Duration(0)
+		if rates == nil {
+			if rates, err = s.latestRates(ctx, day); err != nil {
+				return nil, err
+			} else if rates == nil {
+				return nil, fmt.Errorf("no exchange rates for %s", key)
+			}
+			ttl = ratesRetryInterval
+		}
+
+		daily[day] = rates
+		s.rates.set(key, rates, ttl)
+	}
+
+	return daily, nil
+}
+
+// loadRates requests rates of the day from CBR feed and stores them in database.
+// Feed is requested without lock, rates stored meanwhile by another instance are kept.
+func (s *Manager) loadRates(ctx context.Context, day time.Time) (Rates, error) {
+	rates, err := s.cbr.Rates(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]db.ExchangeRate, 0, len(rates))
+	for currency, rate := range rates {
+		list = append(list, db.ExchangeRate{Date: day, Currency: currency, Rate: rate})
+	}
+	if err := s.cr.AddExchangeRates(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+	s.log.Print(ctx, "exchange rates loaded", "date", day.Format(time.DateOnly), "currencies", len(rates))
+
+	return rates, nil
+}
+
+// latestRates returns the latest rates cached in database before the day or nil
+func (s *Manager) latestRates(ctx context.Context, day time.Time) (Rates, error) {
+	latest, err := s.cr.ExchangeRatesByFilters(ctx, &db.ExchangeRateSearch{
+		DateTo: &day,
+	}, db.PagerOne, db.WithSort(db.NewSortField(db.Columns.ExchangeRate.Date, true)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest exchange rates: %w", err)
+	} else if len(latest) == 0 {
+		return nil, nil
+	}
+
+	list, err := s.cr.ExchangeRatesByFilters(ctx, &db.ExchangeRateSearch{Date: &latest[0].Date}, db.PagerNoLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest exchange rates: %w", err)
+	}
+
+	return newRates(list), nil
+}
+
+// newRates builds rates from database rows
+func newRates(list []db.ExchangeRate) Rates {
+	rates := Rates{CurrencyRUB: 1}
+	for _, r := range list {
+		rates[r.Currency] = r.Rate
+	}
+	return rates
+}
@@ -0,0 +1,242 @@
+package saldo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"saldo/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// recurringLockName is advisory lock name that guards charging of recurring expenses
+const recurringLockName = "recurring-expenses"
+
+// ErrInvalidDayOfMonth is returned when recurring expense has day of month out of 1..31
+var ErrInvalidDayOfMonth = errors.New("day of month must be between 1 and 31")
+
+// RecurringCharge is an expense created automatically from recurring expense
+type RecurringCharge struct {
+	Recurring RecurringExpense
+	Expense   Expense
+	ChatID    int64  // telegram chat of ledger that is notified about charge
+	Language  string // interface language of user for notification
+	Timezone  string // time zone of user for dates in notification
+}
+
+// NextMonthlyRun returns first date after t that falls on day of month.
+// Day is clamped to the last day of short months, e.g. 31 becomes 28 in February.
+func NextMonthlyRun(t time.Time, day int) time.Time {
+	for i := 0; ; i++ {
+		first := time.Date(t.Year(), t.Month()+time.Month(i), 1, 0, 0, 0, 0, t.Location())
+		lastDay := first.AddDate(0, 1, -1).Day()
+		run := first.AddDate(0, 0, min(day, lastDay)-1)
+		if run.After(t) {
+			return run
+		}
+	}
+}
+
+// CreateRecurringExpense creates recurring expense charged monthly on dayOfMonth, finds/creates category if needed.
+// Now is current time in user's time zone, expenses are charged at midnight of it.
+func (s *Manager) CreateRecurringExpense(ctx context.Context, ledgerID, userID int, amount int64, currency, categoryTitle, description string, dayOfMonth int, now time.Time) (*RecurringExpense, error) {
+	if dayOfMonth < 1 || dayOfMonth > 31 {
+		return nil, ErrInvalidDayOfMonth
+	}
+
+	var category *Category
+	if categoryTitle != "" {
+		var err error
+		category, err = s.FindOrCreateCategoryByTitle(ctx, ledgerID, userID, categoryTitle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find or create category: %w", err)
+		}
+	}
+
+	recurring := &db.RecurringExpense{
+		UserID:      userID,
+		LedgerID:    ledgerID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: description,
+		DayOfMonth:  dayOfMonth,
+		NextRunAt:   NextMonthlyRun(now, dayOfMonth),
+		StatusID:    db.StatusEnabled,
+	}
+	if category != nil {
+		recurring.CategoryID = &category.ID
+	}
+
+	created, err := s.cr.AddRecurringExpense(ctx, recurring)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring expense: %w", err)
+	}
+	if category != nil {
+		created.Category = &category.Category
+	}
+
+	s.log.Print(ctx, "recurring expense created",
+		"recurring_expense_id", created.ID,
+		"ledger_id", ledgerID,
+		"user_id", userID,
+		"amount", amount,
+		"currency", currency,
+		"day_of_month", dayOfMonth,
+	)
+
+	return NewRecurringExpense(created), nil
+}
+
+// GetRecurringExpenses returns ledger's recurring expenses ordered by next charge date
+func (s *Manager) GetRecurringExpenses(ctx context.Context, ledgerID int) ([]RecurringExpense, error) {
+	list, err := s.ecr.RecurringExpensesByFilters(ctx, &db.RecurringExpenseSearch{
+		LedgerID: &ledgerID,
+	}, db.PagerNoLimit, s.ecr.FullRecurringExpense(), db.WithSort(
+		db.NewSortField(db.Columns.RecurringExpense.NextRunAt, false),
+		db.NewSortField(db.Columns.RecurringExpense.ID, false),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expenses: %w", err)
+	}
+
+	return NewRecurringExpenses(list), nil
+}
+
+// DeleteRecurringExpense stops ledger's recurring expense, already created expenses are kept
+func (s *Manager) DeleteRecurringExpense(ctx context.Context, ledgerID, recurringID int) (*RecurringExpense, error) {
+	recurring, err := s.ecr.OneRecurringExpense(ctx, &db.RecurringExpenseSearch{
+		ID:       &recurringID,
+		LedgerID: &ledgerID,
+	}, s.ecr.FullRecurringExpense())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expense: %w", err)
+	} else if recurring == nil {
+		return nil, nil
+	}
+
+	if _, err := s.cr.DeleteRecurringExpense(ctx, recurring.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete recurring expense: %w", err)
+	}
+
+	s.log.Print(ctx, "recurring expense deleted", "recurring_expense_id", recurring.ID, "ledger_id", ledgerID)
+
+	return NewRecurringExpense(recurring), nil
+}
+
+// ChargeDueRecurringExpenses creates expenses for all recurring expenses due by now and schedules next charges.
+// Every charge is committed separately, so one failed charge does not roll back others.
+// Charges made before an error are returned along with it.
+func (s *Manager) ChargeDueRecurringExpenses(ctx context.Context, now time.Time) ([]RecurringCharge, error) {
+	due, err := s.ecr.RecurringExpensesByFilters(ctx, &db.RecurringExpenseSearch{
+		NextRunAtTo: &now,
+	}, db.PagerNoLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due recurring expenses: %w", err)
+	}
+
+	var (
+		charges []RecurringCharge
+		errs    []error
+	)
+	for _, recurring := range due {
+		charge, err := s.chargeRecurringExpense(ctx, recurring.ID, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to charge recurring expense %d: %w", recurring.ID, err))
+			continue
+		} else if charge == nil {
+			continue
+		}
+
+		s.log.Print(ctx, "recurring expense charged",
+			"recurring_expense_id", charge.Recurring.ID,
+			"expense_id", charge.Expense.ID,
+			"next_run_at", charge.Recurring.NextRunAt,
+		)
+		charges = append(charges, *charge)
+	}
+
+	return charges, errors.Join(errs...)
+}
+
+// chargeRecurringExpense creates expense dated by scheduled run of recurring expense and schedules the next one.
+// Charge runs under advisory lock, so several bot instances never create the same expense twice.
+// Nil is returned if recurring expense is not due anymore, e.g. it was charged by another instance.
+func (s *Manager) chargeRecurringExpense(ctx context.Context, recurringID int, now time.Time) (*RecurringCharge, error) {
+	var charge *RecurringCharge
+
+	err := s.db.RunInLock(ctx, recurringLockName, func(tx *pg.Tx) error {
+		tm := s.withTransaction(tx)
+
+		recurring, err := tm.ecr.OneRecurringExpense(ctx, &db.RecurringExpenseSearch{
+			ID:          &recurringID,
+			NextRunAtTo: &now,
+		}, tm.ecr.FullRecurringExpense())
+		if err != nil {
+			return fmt.Errorf("failed to get recurring expense: %w", err)
+		} else if recurring == nil {
+			return nil
+		}
+
+		// expense is spent on the day it was scheduled for, even if bot charges it later
+		expense := &db.Expense{
+			UserID:      recurring.UserID,
+			LedgerID:    recurring.LedgerID,
+			CategoryID:  recurring.CategoryID,
+			Amount:      recurring.Amount,
+			Currency:    recurring.Currency,
+			Description: recurring.Description,
+			StatusID:    db.StatusEnabled,
+			SpentAt:     recurring.NextRunAt,
+			Tags:        []string{},
+		}
+		if _, err := tm.cr.AddExpense(ctx, expense, db.WithoutColumns(db.Columns.Expense.CreatedAt, db.Columns.Expense.UpdatedAt)); err != nil {
+			return fmt.Errorf("failed to create expense: %w", err)
+		}
+		expense.Category = recurring.Category
+
+		// next charge is at midnight in user's time zone, not in time zone of database
+		nextRunAt := recurring.NextRunAt
+		if recurring.User != nil {
+			nextRunAt = nextRunAt.In(LoadLocation(recurring.User.Timezone))
+		}
+		recurring.NextRunAt = NextMonthlyRun(nextRunAt, recurring.DayOfMonth)
+		if _, err := tm.cr.UpdateRecurringExpense(ctx, recurring, db.WithColumns(db.Columns.RecurringExpense.NextRunAt)); err != nil {
+			return fmt.Errorf("failed to schedule recurring expense: %w", err)
+		}
+
+		// notification goes to chat of ledger, it is private chat of author for personal ledger
+		ledger, err := tm.ecr.LedgerByID(ctx, recurring.LedgerID)
+		if err != nil {
+			return fmt.Errorf("failed to get ledger: %w", err)
+		}
+
+		charge = &RecurringCharge{Recurring: *NewRecurringExpense(recurring), Expense: *NewExpense(expense)}
+		if ledger != nil {
+			charge.ChatID = ledger.TelegramChatID
+		}
+		if recurring.User != nil {
+			if charge.ChatID == 0 {
+				charge.ChatID = recurring.User.TelegramID
+			}
+			charge.Language = recurring.User.Language
+			charge.Timezone = recurring.User.Timezone
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return charge, nil
+}
+
+// withTransaction returns manager copy that runs all queries in transaction
+func (s *Manager) withTransaction(tx *pg.Tx) *Manager {
+	tm := *s
+	tm.cr = s.cr.WithTransaction(tx)
+	tm.ecr = s.ecr.WithTransaction(tx)
+	return &tm
+}
@@ -0,0 +1,72 @@
+package saldo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextMonthlyRun(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		day  int
+		want time.Time
+	}{
+		{
+			name: "later this month",
+			t:    time.Date(2024, 3, 5, 10, 0, 0, 0, moscow),
+			day:  15,
+			want: time.Date(2024, 3, 15, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "today already started",
+			t:    time.Date(2024, 3, 15, 0, 0, 0, 0, moscow),
+			day:  15,
+			want: time.Date(2024, 4, 15, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "next month",
+			t:    time.Date(2024, 3, 20, 10, 0, 0, 0, moscow),
+			day:  1,
+			want: time.Date(2024, 4, 1, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "clamped to leap february",
+			t:    time.Date(2024, 1, 31, 12, 0, 0, 0, moscow),
+			day:  31,
+			want: time.Date(2024, 2, 29, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "clamped to february",
+			t:    time.Date(2023, 2, 1, 0, 0, 0, 0, moscow),
+			day:  30,
+			want: time.Date(2023, 2, 28, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "after clamped day of short month",
+			t:    time.Date(2024, 4, 30, 9, 0, 0, 0, moscow),
+			day:  31,
+			want: time.Date(2024, 5, 31, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "next year",
+			t:    time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+			day:  10,
+			want: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextMonthlyRun(tt.t, tt.day)
+			if !got.Equal(tt.want) || got.Location() != tt.want.Location() {
+				t.Errorf("NextMonthlyRun(%v, %d) = %v, want %v", tt.t, tt.day, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,30 +3,41 @@ package saldo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"saldo/pkg/db"
+	"saldo/pkg/services"
 
 	"github.com/vmkteam/embedlog"
 )
 
 type Manager struct {
-	cr  db.CommonRepo
-	db  db.DB
-	log embedlog.Logger
+	cr    db.CommonRepo
+	ecr   db.CommonRepo // enabled only, used for all user read paths
+	db    db.DB
+	log   embedlog.Logger
+	cbr   *CBR
+	rates *ratesCache
+	emoji services.EmojiPicker // picks emoji of new categories, keyword table is used without it
 }
 
-func NewManager(dbc db.DB, log embedlog.Logger) *Manager {
+func NewManager(dbc db.DB, log embedlog.Logger, cbr *CBR, emoji services.EmojiPicker) *Manager {
+	cr := db.NewCommonRepo(dbc)
 	return &Manager{
-		cr:  db.NewCommonRepo(dbc),
-		db:  dbc,
-		log: log,
+		cr:    cr,
+		ecr:   cr.WithEnabledOnly(),
+		db:    dbc,
+		log:   log,
+		cbr:   cbr,
+		rates: newRatesCache(),
+		emoji: emoji,
 	}
 }
 
 // User methods
 
-// GetOrCreateUserByTelegramID gets user by Telegram ID or creates a new one
-func (s *Manager) GetOrCreateUserByTelegramID(ctx context.Context, telegramID int64, username, firstName, lastName string) (*User, error) {
+// GetOrCreateUserByTelegramID gets user by Telegram ID or creates a new one with interface language
+func (s *Manager) GetOrCreateUserByTelegramID(ctx context.Context, telegramID int64, username, firstName, lastName, language string) (*User, error) {
 	// Try to find existing user
 	search := &db.UserSearch{
 		TelegramID: &telegramID,
@@ -49,6 +60,11 @@ func (s *Manager) GetOrCreateUserByTelegramID(ctx context.Context, telegramID in
 		TelegramUsername: username,
 		TeleramFirstName: &firstName,
 		TelegramLastName: &lastName,
+		BaseCurrency:     CurrencyRUB,
+		Language:         language,
+		Timezone:         DefaultTimezone,
+		DefaultCurrency:  CurrencyRUB,
+		WeekStart:        int(time.Monday),
 		StatusID:         db.StatusEnabled,
 	}
 
@@ -57,7 +73,7 @@ func (s *Manager) GetOrCreateUserByTelegramID(ctx context.Context, telegramID in
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	s.log.Print(ctx, "new user created", "user_id", user.ID, "telegram_user_id", telegramID, "username", username)
+	s.log.Print(ctx, "new user created", "user_id", user.ID, "telegram_user_id", telegramID, "username", username, "language", language)
 
 	return NewUser(user), nil
 }
@@ -76,12 +92,91 @@ func (s *Manager) GetUserByTelegramID(ctx context.Context, telegramUserID int64)
 	return NewUser(user), nil
 }
 
+// SetUserBaseCurrency sets currency that user's statistics are converted to
+func (s *Manager) SetUserBaseCurrency(ctx context.Context, userID int, currency string) error {
+	user := &db.User{ID: userID, BaseCurrency: currency}
+	if _, err := s.cr.UpdateUser(ctx, user, db.WithColumns(db.Columns.User.BaseCurrency)); err != nil {
+		return fmt.Errorf("failed to update base currency: %w", err)
+	}
+
+	s.log.Print(ctx, "base currency changed", "user_id", userID, "currency", currency)
+
+	return nil
+}
+
+// SetUserLanguage sets language of bot interface for user
+func (s *Manager) SetUserLanguage(ctx context.Context, userID int, language string) error {
+	user := &db.User{ID: userID, Language: language}
+	if _, err := s.cr.UpdateUser(ctx, user, db.WithColumns(db.Columns.User.Language)); err != nil {
+		return fmt.Errorf("failed to update language: %w", err)
+	}
+
+	s.log.Print(ctx, "language changed", "user_id", userID, "language", language)
+
+	return nil
+}
+
+// SetUserTimezone sets IANA time zone that user's dates and periods are calculated in, digests are moved to morning of it
+func (s *Manager) SetUserTimezone(ctx context.Context, userID int, timezone string) error {
+	user := &db.User{ID: userID, Timezone: timezone}
+	if _, err := s.cr.UpdateUser(ctx, user, db.WithColumns(db.Columns.User.Timezone)); err != nil {
+		return fmt.Errorf("failed to update timezone: %w", err)
+	}
+	if err := s.rescheduleDigests(ctx, userID, timezone, time.Now()); err != nil {
+		return err
+	}
+
+	s.log.Print(ctx, "timezone changed", "user_id", userID, "timezone", timezone)
+
+	return nil
+}
+
+// SetUserDefaultCurrency sets currency of user's amounts that are entered without currency
+func (s *Manager) SetUserDefaultCurrency(ctx context.Context, userID int, currency string) error {
+	user := &db.User{ID: userID, DefaultCurrency: currency}
+	if _, err := s.cr.UpdateUser(ctx, user, db.WithColumns(db.Columns.User.DefaultCurrency)); err != nil {
+		return fmt.Errorf("failed to update default currency: %w", err)
+	}
+
+	s.log.Print(ctx, "default currency changed", "user_id", userID, "currency", currency)
+
+	return nil
+}
+
+// SetUserWeekStart sets first day of week for user's weekly periods
+func (s *Manager) SetUserWeekStart(ctx context.Context, userID int, weekStart time.Weekday) error {
+	user := &db.User{ID: userID, WeekStart: int(weekStart)}
+	if _, err := s.cr.UpdateUser(ctx, user, db.WithColumns(db.Columns.User.WeekStart)); err != nil {
+		return fmt.Errorf("failed to update week start: %w", err)
+	}
+
+	s.log.Print(ctx, "week start changed", "user_id", userID, "week_start", weekStart)
+
+	return nil
+}
+
 // Category methods
 
-// GetUserCategories returns all categories for a user
-func (s *Manager) GetUserCategories(ctx context.Context, userID int) ([]Category, error) {
-	categories, err := s.cr.CategoriesByFilters(ctx, &db.CategorySearch{
-		UserID: &userID,
+// Category kinds separate expense categories from income ones
+const (
+	CategoryKindExpense = "expense"
+	CategoryKindIncome  = "income"
+)
+
+// GetCategories returns all expense categories of a ledger
+func (s *Manager) GetCategories(ctx context.Context, ledgerID int) ([]Category, error) {
+	return s.getCategoriesByKind(ctx, ledgerID, CategoryKindExpense)
+}
+
+// GetIncomeCategories returns all income categories of a ledger
+func (s *Manager) GetIncomeCategories(ctx context.Context, ledgerID int) ([]Category, error) {
+	return s.getCategoriesByKind(ctx, ledgerID, CategoryKindIncome)
+}
+
+func (s *Manager) getCategoriesByKind(ctx context.Context, ledgerID int, kind string) ([]Category, error) {
+	categories, err := s.ecr.CategoriesByFilters(ctx, &db.CategorySearch{
+		LedgerID: &ledgerID,
+		Kind:     &kind,
 	}, db.PagerDefault, s.cr.FullCategory())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get categories: %w", err)
@@ -92,7 +187,7 @@ func (s *Manager) GetUserCategories(ctx context.Context, userID int) ([]Category
 
 // GetCategoryByID returns category by ID
 func (s *Manager) GetCategoryByID(ctx context.Context, categoryID int) (*Category, error) {
-	category, err := s.cr.CategoryByID(ctx, categoryID)
+	category, err := s.ecr.CategoryByID(ctx, categoryID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category: %w", err)
 	}
@@ -100,31 +195,53 @@ func (s *Manager) GetCategoryByID(ctx context.Context, categoryID int) (*Categor
 	return NewCategory(category), nil
 }
 
-// CreateCategory creates a new category for a user
-func (s *Manager) CreateCategory(ctx context.Context, userID int, title string, emoji *string) (*Category, error) {
+// CreateCategory creates a new category of given kind in a ledger, userID is author of category.
+// Emoji fitting the title is picked when it is nil.
+func (s *Manager) CreateCategory(ctx context.Context, ledgerID, userID int, title, kind string, emoji *string) (*Category, error) {
+	if emoji == nil {
+		picked := s.pickCategoryEmoji(ctx, title, kind)
+		emoji = &picked
+	}
+
 	category := &db.Category{
 		UserID:   userID,
+		LedgerID: ledgerID,
 		Title:    title,
 		Emoji:    emoji,
+		Kind:     kind,
 		StatusID: db.StatusEnabled,
 	}
+	if _, ok := category.Validate(); !ok {
+		category.Emoji = nil
+	}
 
 	createdCategory, err := s.cr.AddCategory(ctx, category)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create category: %w", err)
 	}
 
-	s.log.Print(ctx, "category created", "category_id", createdCategory.ID, "user_id", userID, "title", title)
+	s.log.Print(ctx, "category created", "category_id", createdCategory.ID, "ledger_id", ledgerID, "user_id", userID, "title", title, "kind", kind)
 
 	return NewCategory(createdCategory), nil
 }
 
-// FindOrCreateCategoryByTitle finds category by title or creates a new one
-func (s *Manager) FindOrCreateCategoryByTitle(ctx context.Context, userID int, title string) (*Category, error) {
+// FindOrCreateCategoryByTitle finds expense category by title or creates a new one
+func (s *Manager) FindOrCreateCategoryByTitle(ctx context.Context, ledgerID, userID int, title string) (*Category, error) {
+	return s.findOrCreateCategory(ctx, ledgerID, userID, title, CategoryKindExpense, nil)
+}
+
+// FindOrCreateIncomeCategoryByTitle finds income category by title or creates a new one
+func (s *Manager) FindOrCreateIncomeCategoryByTitle(ctx context.Context, ledgerID, userID int, title string) (*Category, error) {
+	return s.findOrCreateCategory(ctx, ledgerID, userID, title, CategoryKindIncome, nil)
+}
+
+// findOrCreateCategory finds category by title or creates a new one with emoji, nil emoji is picked for title
+func (s *Manager) findOrCreateCategory(ctx context.Context, ledgerID, userID int, title, kind string, emoji *string) (*Category, error) {
 	// Try to find existing category
-	categories, err := s.cr.CategoriesByFilters(ctx, &db.CategorySearch{
-		UserID: &userID,
-		Title:  &title,
+	categories, err := s.ecr.CategoriesByFilters(ctx, &db.CategorySearch{
+		LedgerID: &ledgerID,
+		Title:    &title,
+		Kind:     &kind,
 	}, db.PagerOne, s.cr.FullCategory())
 	if err != nil {
 		return nil, fmt.Errorf("failed to search category: %w", err)
@@ -135,20 +252,23 @@ func (s *Manager) FindOrCreateCategoryByTitle(ctx context.Context, userID int, t
 	}
 
 	// Create new category
-	return s.CreateCategory(ctx, userID, title, nil)
+	return s.CreateCategory(ctx, ledgerID, userID, title, kind, emoji)
 }
 
 // Expense methods
 
-// CreateExpense creates a new expense
-func (s *Manager) CreateExpense(ctx context.Context, userID int, categoryID *int, amount int64, currency, description string) (*Expense, error) {
+// CreateExpense creates a new expense in a ledger marked by tags, userID is member who spent money at spentAt
+func (s *Manager) CreateExpense(ctx context.Context, ledgerID, userID int, categoryID *int, amount int64, currency, description string, tags []string, spentAt time.Time) (*Expense, error) {
 	expense := &db.Expense{
 		UserID:      userID,
+		LedgerID:    ledgerID,
 		CategoryID:  categoryID,
 		Amount:      amount,
 		Currency:    currency,
 		Description: description,
 		StatusID:    db.StatusEnabled,
+		SpentAt:     spentAt,
+		Tags:        NormalizeTags(tags),
 	}
 
 	createdExpense, err := s.cr.AddExpense(ctx, expense)
@@ -158,6 +278,7 @@ func (s *Manager) CreateExpense(ctx context.Context, userID int, categoryID *int
 
 	s.log.Print(ctx, "expense created",
 		"expense_id", createdExpense.ID,
+		"ledger_id", ledgerID,
 		"user_id", userID,
 		"amount", amount,
 		"currency", currency,
@@ -167,25 +288,33 @@ func (s *Manager) CreateExpense(ctx context.Context, userID int, categoryID *int
 }
 
 // CreateExpenseWithCategory creates expense and finds/creates category if needed
-func (s *Manager) CreateExpenseWithCategory(ctx context.Context, userID int, amount int64, currency, categoryTitle, description string) (*Expense, error) {
+func (s *Manager) CreateExpenseWithCategory(ctx context.Context, ledgerID, userID int, amount int64, currency, categoryTitle, description string, tags []string, spentAt time.Time) (*Expense, error) {
 	var categoryID *int
 
 	if categoryTitle != "" {
-		category, err := s.FindOrCreateCategoryByTitle(ctx, userID, categoryTitle)
+		category, err := s.FindOrCreateCategoryByTitle(ctx, ledgerID, userID, categoryTitle)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find or create category: %w", err)
 		}
 		categoryID = &category.ID
 	}
 
-	return s.CreateExpense(ctx, userID, categoryID, amount, currency, description)
+	return s.CreateExpense(ctx, ledgerID, userID, categoryID, amount, currency, description, tags, spentAt)
 }
 
-// GetUserExpenses returns expenses for a user with optional filters
-func (s *Manager) GetUserExpenses(ctx context.Context, userID int) ([]Expense, error) {
-	expenses, err := s.cr.ExpensesByFilters(ctx, &db.ExpenseSearch{
-		UserID: &userID,
-	}, db.PagerDefault, s.cr.FullExpense())
+// GetExpensesForPeriod returns all ledger's expenses spent within period ordered by date,
+// zero memberID returns expenses of all members
+func (s *Manager) GetExpensesForPeriod(ctx context.Context, ledgerID, memberID int, from, to time.Time) ([]Expense, error) {
+	search := &db.ExpenseSearch{
+		LedgerID:    &ledgerID,
+		SpentAtFrom: &from,
+		SpentAtTo:   &to,
+	}
+	if memberID != 0 {
+		search.UserID = &memberID
+	}
+
+	expenses, err := s.ecr.ExpensesByFilters(ctx, search, db.PagerNoLimit, s.cr.FullExpense(), db.WithSort(db.NewSortField(db.Columns.Expense.SpentAt, false)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get expenses: %w", err)
 	}
@@ -193,6 +322,281 @@ func (s *Manager) GetUserExpenses(ctx context.Context, userID int) ([]Expense, e
 	return NewExpenses(expenses), nil
 }
 
+// ExpenseTotal is sum of expenses of one category in one currency spent in one day
+type ExpenseTotal struct {
+	db.ExpenseTotal
+	Category *Category
+}
+
+// GetExpenseTotals returns ledger's expenses spent within period summed by category, currency and day in loc,
+// zero memberID sums expenses of all members
+func (s *Manager) GetExpenseTotals(ctx context.Context, ledgerID, memberID int, from, to time.Time, loc *time.Location) ([]ExpenseTotal, error) {
+	search := &db.ExpenseSearch{
+		LedgerID:    &ledgerID,
+		SpentAtFrom: &from,
+		SpentAtTo:   &to,
+	}
+	if memberID != 0 {
+		search.UserID = &memberID
+	}
+
+	totals, err := s.ecr.SumExpensesByCategory(ctx, search, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum expenses: %w", err)
+	}
+
+	var categoryIDs []int
+	for _, total := range totals {
+		if total.CategoryID != nil {
+			categoryIDs = append(categoryIDs, *total.CategoryID)
+		}
+	}
+
+	categories := make(map[int]*Category)
+	if len(categoryIDs) > 0 {
+		list, err := s.cr.CategoriesByFilters(ctx, &db.CategorySearch{IDs: categoryIDs}, db.PagerNoLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get categories: %w", err)
+		}
+		for i := range list {
+			categories[list[i].ID] = NewCategory(&list[i])
+		}
+	}
+
+	result := make([]ExpenseTotal, len(totals))
+	for i, total := range totals {
+		result[i] = ExpenseTotal{ExpenseTotal: total}
+		if total.CategoryID != nil {
+			result[i].Category = categories[*total.CategoryID]
+		}
+	}
+
+	return result, nil
+}
+
+// GetExpense returns ledger's expense by ID or nil if it does not exist or belongs to another ledger
+func (s *Manager) GetExpense(ctx context.Context, ledgerID, expenseID int) (*Expense, error) {
+	expense, err := s.ecr.OneExpense(ctx, &db.ExpenseSearch{
+		ID:       &expenseID,
+		LedgerID: &ledgerID,
+	}, s.ecr.FullExpense())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense: %w", err)
+	}
+
+	return NewExpense(expense), nil
+}
+
+// UpdateExpense saves amount, currency, category and description of expense and refreshes updatedAt
+func (s *Manager) UpdateExpense(ctx context.Context, expense *Expense) error {
+	expense.UpdatedAt = time.Now()
+
+	_, err := s.cr.UpdateExpense(ctx, &expense.Expense, db.WithColumns(
+		db.Columns.Expense.CategoryID,
+		db.Columns.Expense.Amount,
+		db.Columns.Expense.Currency,
+		db.Columns.Expense.Description,
+		db.Columns.Expense.UpdatedAt,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to update expense: %w", err)
+	}
+
+	s.log.Print(ctx, "expense updated",
+		"expense_id", expense.ID,
+		"user_id", expense.UserID,
+		"amount", expense.Amount,
+		"currency", expense.Currency,
+	)
+
+	return nil
+}
+
+// DeleteExpense moves ledger's expense to trash by setting deleted status
+func (s *Manager) DeleteExpense(ctx context.Context, ledgerID, expenseID int) (*Expense, error) {
+	expense, err := s.GetExpense(ctx, ledgerID, expenseID)
+	if err != nil {
+		return nil, err
+	} else if expense == nil {
+		return nil, nil
+	}
+
+	if err := s.setExpenseStatus(ctx, expense, db.StatusDeleted); err != nil {
+		return nil, fmt.Errorf("failed to delete expense: %w", err)
+	}
+
+	s.log.Print(ctx, "expense deleted", "expense_id", expense.ID, "ledger_id", ledgerID)
+
+	return expense, nil
+}
+
+// DeleteLastExpense moves the most recent expense created by user in a ledger to trash,
+// other members' expenses of shared ledger are not touched
+func (s *Manager) DeleteLastExpense(ctx context.Context, ledgerID, userID int) (*Expense, error) {
+	expenses, err := s.ecr.ExpensesByFilters(ctx, &db.ExpenseSearch{
+		LedgerID: &ledgerID,
+		UserID:   &userID,
+	}, db.PagerOne, db.WithSort(
+		db.NewSortField(db.Columns.Expense.CreatedAt, true),
+		db.NewSortField(db.Columns.Expense.ID, true),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last expense: %w", err)
+	} else if len(expenses) == 0 {
+		return nil, nil
+	}
+
+	return s.DeleteExpense(ctx, ledgerID, expenses[0].ID)
+}
+
+// GetDeletedExpenses returns ledger's expenses from trash, recently deleted first
+func (s *Manager) GetDeletedExpenses(ctx context.Context, ledgerID int) ([]Expense, error) {
+	dcr := s.cr.WithDeletedOnly()
+	expenses, err := dcr.ExpensesByFilters(ctx, &db.ExpenseSearch{
+		LedgerID: &ledgerID,
+	}, db.PagerDefault, dcr.FullExpense(), db.WithSort(db.NewSortField(db.Columns.Expense.UpdatedAt, true)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted expenses: %w", err)
+	}
+
+	return NewExpenses(expenses), nil
+}
+
+// RestoreExpense returns ledger's expense from trash
+func (s *Manager) RestoreExpense(ctx context.Context, ledgerID, expenseID int) (*Expense, error) {
+	dcr := s.cr.WithDeletedOnly()
+	expense, err := dcr.OneExpense(ctx, &db.ExpenseSearch{
+		ID:       &expenseID,
+		LedgerID: &ledgerID,
+	}, dcr.FullExpense())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted expense: %w", err)
+	} else if expense == nil {
+		return nil, nil
+	}
+
+	restored := NewExpense(expense)
+	if err := s.setExpenseStatus(ctx, restored, db.StatusEnabled); err != nil {
+		return nil, fmt.Errorf("failed to restore expense: %w", err)
+	}
+
+	s.log.Print(ctx, "expense restored", "expense_id", expense.ID, "ledger_id", ledgerID)
+
+	return restored, nil
+}
+
+// setExpenseStatus updates status of expense and refreshes updatedAt
+func (s *Manager) setExpenseStatus(ctx context.Context, expense *Expense, statusID int) error {
+	expense.StatusID = statusID
+	expense.UpdatedAt = time.Now()
+
+	_, err := s.cr.UpdateExpense(ctx, &expense.Expense, db.WithColumns(
+		db.Columns.Expense.StatusID,
+		db.Columns.Expense.UpdatedAt,
+	))
+
+	return err
+}
+
+// Income methods
+
+// CreateIncomeWithCategory creates income received at receivedAt and finds/creates income category if needed
+func (s *Manager) CreateIncomeWithCategory(ctx context.Context, ledgerID, userID int, amount int64, currency, categoryTitle, description string, receivedAt time.Time) (*Income, error) {
+	var categoryID *int
+
+	if categoryTitle != "" {
+		category, err := s.FindOrCreateIncomeCategoryByTitle(ctx, ledgerID, userID, categoryTitle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find or create category: %w", err)
+		}
+		categoryID = &category.ID
+	}
+
+	income := &db.Income{
+		UserID:      userID,
+		LedgerID:    ledgerID,
+		CategoryID:  categoryID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: description,
+		StatusID:    db.StatusEnabled,
+		ReceivedAt:  receivedAt,
+	}
+
+	createdIncome, err := s.cr.AddIncome(ctx, income)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create income: %w", err)
+	}
+
+	s.log.Print(ctx, "income created",
+		"income_id", createdIncome.ID,
+		"ledger_id", ledgerID,
+		"user_id", userID,
+		"amount", amount,
+		"currency", currency,
+	)
+
+	return NewIncome(createdIncome), nil
+}
+
+// GetIncomesForPeriod returns all ledger's incomes received within period ordered by date,
+// zero memberID returns incomes of all members
+func (s *Manager) GetIncomesForPeriod(ctx context.Context, ledgerID, memberID int, from, to time.Time) ([]Income, error) {
+	search := &db.IncomeSearch{
+		LedgerID:       &ledgerID,
+		ReceivedAtFrom: &from,
+		ReceivedAtTo:   &to,
+	}
+	if memberID != 0 {
+		search.UserID = &memberID
+	}
+
+	incomes, err := s.ecr.IncomesByFilters(ctx, search, db.PagerNoLimit, s.cr.FullIncome(), db.WithSort(db.NewSortField(db.Columns.Income.ReceivedAt, false)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incomes: %w", err)
+	}
+
+	return NewIncomes(incomes), nil
+}
+
+// DeleteIncome marks ledger's income as deleted
+func (s *Manager) DeleteIncome(ctx context.Context, ledgerID, incomeID int) (*Income, error) {
+	return s.setIncomeStatus(ctx, s.ecr, ledgerID, incomeID, db.StatusDeleted)
+}
+
+// RestoreIncome returns deleted ledger's income back
+func (s *Manager) RestoreIncome(ctx context.Context, ledgerID, incomeID int) (*Income, error) {
+	return s.setIncomeStatus(ctx, s.cr.WithDeletedOnly(), ledgerID, incomeID, db.StatusEnabled)
+}
+
+// setIncomeStatus finds ledger's income with given repo and updates its status
+func (s *Manager) setIncomeStatus(ctx context.Context, cr db.CommonRepo, ledgerID, incomeID, statusID int) (*Income, error) {
+	income, err := cr.OneIncome(ctx, &db.IncomeSearch{
+		ID:       &incomeID,
+		LedgerID: &ledgerID,
+	}, cr.FullIncome())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get income: %w", err)
+	} else if income == nil {
+		return nil, nil
+	}
+
+	income.StatusID = statusID
+	income.UpdatedAt = time.Now()
+
+	_, err = s.cr.UpdateIncome(ctx, income, db.WithColumns(
+		db.Columns.Income.StatusID,
+		db.Columns.Income.UpdatedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update income status: %w", err)
+	}
+
+	s.log.Print(ctx, "income status changed", "income_id", income.ID, "ledger_id", ledgerID, "status_id", statusID)
+
+	return NewIncome(income), nil
+}
+
 // GetAllExpenses returns all expenses (for metrics initialization)
 func (s *Manager) GetAllExpenses(ctx context.Context) ([]Expense, error) {
 	expenses, err := s.cr.ExpensesByFilters(ctx, &db.ExpenseSearch{}, db.PagerDefault, s.cr.FullExpense())
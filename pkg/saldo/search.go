@@ -0,0 +1,101 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"saldo/pkg/db"
+)
+
+// ExpenseFilter is a search query over expenses, empty fields are not used
+type ExpenseFilter struct {
+	Text       string // words searched in descriptions by full-text search
+	AmountFrom *int64 // in cents
+	AmountTo   *int64 // in cents
+	Category   string // title of category, expenses of its sub-categories are found too
+	Currency   string
+	From       *time.Time
+	To         *time.Time
+	Tag        string
+}
+
+// FindExpenses returns page of ledger's expenses matching filter, newest first, and total number of matching expenses.
+// Pages start from 1.
+func (s *Manager) FindExpenses(ctx context.Context, ledgerID int, filter ExpenseFilter, page, pageSize int) ([]Expense, int, error) {
+	search := &db.ExpenseSearch{
+		LedgerID:    &ledgerID,
+		SpentAtFrom: filter.From,
+		SpentAtTo:   filter.To,
+		AmountFrom:  filter.AmountFrom,
+		AmountTo:    filter.AmountTo,
+	}
+	if filter.Text != "" {
+		search.DescriptionText = &filter.Text
+	}
+	if filter.Currency != "" {
+		search.Currency = &filter.Currency
+	}
+	if filter.Tag != "" {
+		search.Tag = &filter.Tag
+	}
+
+	if filter.Category != "" {
+		categoryIDs, err := s.matchCategories(ctx, ledgerID, filter.Category)
+		if err != nil {
+			return nil, 0, err
+		} else if len(categoryIDs) == 0 {
+			return nil, 0, nil
+		}
+		search.CategoryIDs = categoryIDs
+	}
+
+	total, err := s.ecr.CountExpenses(ctx, search)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count expenses: %w", err)
+	} else if total == 0 {
+		return nil, 0, nil
+	}
+
+	expenses, err := s.ecr.ExpensesByFilters(ctx, search, db.NewPager(page, pageSize), s.cr.FullExpense(),
+		db.WithSort(db.NewSortField(db.Columns.Expense.SpentAt, true), db.NewSortField(db.Columns.Expense.ID, true)))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find expenses: %w", err)
+	}
+
+	return NewExpenses(expenses), total, nil
+}
+
+// matchCategories returns IDs of ledger's expense categories with title, or containing it if no title is equal,
+// and IDs of their sub-categories
+func (s *Manager) matchCategories(ctx context.Context, ledgerID int, title string) ([]int, error) {
+	categories, err := s.GetCategories(ctx, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[int]bool)
+	for _, category := range categories {
+		if strings.EqualFold(category.Title, title) {
+			matched[category.ID] = true
+		}
+	}
+	if len(matched) == 0 {
+		title = strings.ToLower(title)
+		for _, category := range categories {
+			if strings.Contains(strings.ToLower(category.Title), title) {
+				matched[category.ID] = true
+			}
+		}
+	}
+
+	var ids []int
+	for _, category := range categories {
+		if matched[category.ID] || (category.ParentID != nil && matched[*category.ParentID]) {
+			ids = append(ids, category.ID)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,56 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"saldo/pkg/db"
+)
+
+// GetUserState returns conversation state data of telegram user in chat or empty string if it is absent or expired
+func (s *Manager) GetUserState(ctx context.Context, telegramID, chatID int64) (string, error) {
+	state, err := s.cr.OneUserState(ctx, &db.UserStateSearch{TelegramID: &telegramID, ChatID: &chatID})
+	if err != nil {
+		return "", fmt.Errorf("failed to get user state: %w", err)
+	} else if state == nil || state.ExpiresAt.Before(time.Now()) {
+		return "", nil
+	}
+
+	return state.Data, nil
+}
+
+// SaveUserState replaces conversation state data of telegram user in chat, state is kept until expiresAt
+func (s *Manager) SaveUserState(ctx context.Context, telegramID, chatID int64, data string, expiresAt time.Time) error {
+	state := &db.UserState{
+		TelegramID: telegramID,
+		ChatID:     chatID,
+		Data:       data,
+		ExpiresAt:  expiresAt,
+	}
+
+	if _, err := s.cr.AddUserState(ctx, state, db.OnConflict(`("telegramId", "chatId") DO UPDATE`)); err != nil {
+		return fmt.Errorf("failed to save user state: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUserState deletes conversation state of telegram user in chat
+func (s *Manager) DeleteUserState(ctx context.Context, telegramID, chatID int64) error {
+	if err := s.cr.DeleteUserStateByTelegramID(ctx, telegramID, chatID); err != nil {
+		return fmt.Errorf("failed to delete user state: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredUserStates deletes conversation states expired before now and returns their count
+func (s *Manager) DeleteExpiredUserStates(ctx context.Context, now time.Time) (int, error) {
+	count, err := s.cr.DeleteExpiredUserStates(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired user states: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,109 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"saldo/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// ImportedExpense is expense of bank statement with category chosen for it
+type ImportedExpense struct {
+	Date        time.Time
+	Amount      int64 // in cents
+	Currency    string
+	Category    string
+	Description string
+	Tags        []string
+}
+
+// ImportExpenses creates expenses with their original dates, e.g. of bank statement or receipt, in one transaction
+func (s *Manager) ImportExpenses(ctx context.Context, ledgerID, userID int, list []ImportedExpense) ([]Expense, error) {
+	emojis, err := s.newCategoryEmojis(ctx, ledgerID, list)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []Expense
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		tm := s.withTransaction(tx)
+		categories := make(map[string]*Category)
+
+		for _, e := range list {
+			expense := &db.Expense{
+				UserID:      userID,
+				LedgerID:    ledgerID,
+				Amount:      e.Amount,
+				Currency:    e.Currency,
+				Description: e.Description,
+				StatusID:    db.StatusEnabled,
+				SpentAt:     e.Date,
+				Tags:        NormalizeTags(e.Tags),
+			}
+
+			if e.Category != "" {
+				category, ok := categories[e.Category]
+				if !ok {
+					// emoji is never picked by LLM inside transaction, category created meanwhile gets one of keywords
+					emoji, ok := emojis[e.Category]
+					if !ok {
+						emoji = keywordEmoji(e.Category, CategoryKindExpense)
+					}
+
+					var err error
+					if category, err = tm.findOrCreateCategory(ctx, ledgerID, userID, e.Category, CategoryKindExpense, &emoji); err != nil {
+						return fmt.Errorf("failed to find or create category: %w", err)
+					}
+					categories[e.Category] = category
+				}
+				expense.CategoryID = &category.ID
+				expense.Category = &category.Category
+			}
+
+			// spentAt is set from statement, createdAt and updatedAt are filled by database
+			if _, err := tm.cr.AddExpense(ctx, expense, db.WithoutColumns(db.Columns.Expense.CreatedAt, db.Columns.Expense.UpdatedAt)); err != nil {
+				return fmt.Errorf("failed to import expense: %w", err)
+			}
+			created = append(created, *NewExpense(expense))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Print(ctx, "expenses imported", "ledger_id", ledgerID, "user_id", userID, "count", len(created))
+
+	return created, nil
+}
+
+// newCategoryEmojis picks emojis for expense categories of imported expenses that do not exist in ledger yet
+func (s *Manager) newCategoryEmojis(ctx context.Context, ledgerID int, list []ImportedExpense) (map[string]string, error) {
+	emojis := make(map[string]string)
+	checked := make(map[string]bool)
+	kind := CategoryKindExpense
+
+	for _, e := range list {
+		if e.Category == "" || checked[e.Category] {
+			continue
+		}
+		checked[e.Category] = true
+
+		existing, err := s.ecr.CategoriesByFilters(ctx, &db.CategorySearch{
+			LedgerID: &ledgerID,
+			Title:    &e.Category,
+			Kind:     &kind,
+		}, db.PagerOne)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search category: %w", err)
+		} else if len(existing) == 0 {
+			emojis[e.Category] = s.pickCategoryEmoji(ctx, e.Category, kind)
+		}
+	}
+
+	return emojis, nil
+}
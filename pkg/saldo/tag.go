@@ -0,0 +1,91 @@
+package saldo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"saldo/pkg/db"
+)
+
+// tagMaxBytes is max length of tag in bytes, longer hashtags are cut so that tag fits telegram callback data
+const tagMaxBytes = 48
+
+// tagRegexp matches hashtag of letters, digits and underscores, e.g. #отпуск or #trip_2024
+var tagRegexp = regexp.MustCompile(`#([\p{L}\p{N}_]+)`)
+
+// TagUsage is a tag with number of expenses marked by it
+type TagUsage struct {
+	Tag           string
+	ExpensesCount int
+}
+
+// ParseTags cuts hashtags out of text and returns the rest of text and lowercase tags without repeats
+func ParseTags(text string) (string, []string) {
+	var tags []string
+	for _, match := range tagRegexp.FindAllStringSubmatch(text, -1) {
+		tags = append(tags, match[1])
+	}
+
+	rest := strings.Join(strings.Fields(tagRegexp.ReplaceAllString(text, "")), " ")
+
+	return rest, NormalizeTags(tags)
+}
+
+// NormalizeTags lowercases and shortens tags and drops empty ones and repeats, result is never nil
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		for len(tag) > tagMaxBytes {
+			_, size := utf8.DecodeLastRuneInString(tag)
+			tag = tag[:len(tag)-size]
+		}
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// GetTagsUsage returns tags of ledger's expenses with numbers of their expenses, most used first
+func (s *Manager) GetTagsUsage(ctx context.Context, ledgerID int) ([]TagUsage, error) {
+	counts, err := s.ecr.CountExpensesByTag(ctx, &db.ExpenseSearch{LedgerID: &ledgerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expenses by tag: %w", err)
+	}
+
+	usage := make([]TagUsage, 0, len(counts))
+	for tag, count := range counts {
+		usage = append(usage, TagUsage{Tag: tag, ExpensesCount: count})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].ExpensesCount != usage[j].ExpensesCount {
+			return usage[i].ExpensesCount > usage[j].ExpensesCount
+		}
+		return usage[i].Tag < usage[j].Tag
+	})
+
+	return usage, nil
+}
+
+// GetExpensesByTag returns all ledger's expenses marked by tag ordered by date
+func (s *Manager) GetExpensesByTag(ctx context.Context, ledgerID int, tag string) ([]Expense, error) {
+	expenses, err := s.ecr.ExpensesByFilters(ctx, &db.ExpenseSearch{
+		LedgerID: &ledgerID,
+		Tag:      &tag,
+	}, db.PagerNoLimit, s.cr.FullExpense(), db.WithSort(db.NewSortField(db.Columns.Expense.SpentAt, false)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	return NewExpenses(expenses), nil
+}
@@ -0,0 +1,30 @@
+package saldo
+
+import (
+	"time"
+	// embedded time zone database, containers often have no system one
+	_ "time/tzdata"
+)
+
+// DefaultTimezone is time zone of users who have not chosen their own
+const DefaultTimezone = "Europe/Moscow"
+
+// LoadLocation returns time zone by IANA name, default time zone is returned for unknown names
+func LoadLocation(name string) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil && name != "" {
+		return loc
+	}
+	if loc, err := time.LoadLocation(DefaultTimezone); err == nil {
+		return loc
+	}
+	return time.Local
+}
+
+// ValidTimezone reports whether name is known IANA time zone like "Asia/Yekaterinburg"
+func ValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+
+	"saldo/pkg/i18n"
 )
 
 type LocalWhisper struct{}
@@ -13,7 +15,7 @@ func NewLocalWhisper() *LocalWhisper {
 	return &LocalWhisper{}
 }
 
-func (w *LocalWhisper) Transcribe(ctx context.Context, oggFilePath string) (string, error) {
+func (w *LocalWhisper) Transcribe(ctx context.Context, oggFilePath string, lang i18n.Lang) (string, error) {
 	tmpWav, err := ConvertOggToWav(ctx, oggFilePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to convert ogg to wav: %w", err)
@@ -23,7 +25,7 @@ func (w *LocalWhisper) Transcribe(ctx context.Context, oggFilePath string) (stri
 	cmd := exec.CommandContext(ctx,
 		"whisper-cli",
 		"-m", "models/ggml-base.bin",
-		"-l", "ru",
+		"-l", string(lang),
 		"-f", tmpWav,
 		"-otxt",
 		"-of", "-",
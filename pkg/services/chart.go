@@ -0,0 +1,248 @@
+package services
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ChartColors are colours of chart slices, the last one is used for the rest of small slices
+var ChartColors = []color.RGBA{
+	{R: 0xe5, G: 0x39, B: 0x35, A: 0xff},
+	{R: 0xfb, G: 0x8c, B: 0x00, A: 0xff},
+	{R: 0xfd, G: 0xd8, B: 0x35, A: 0xff},
+	{R: 0x43, G: 0xa0, B: 0x47, A: 0xff},
+	{R: 0x1e, G: 0x88, B: 0xe5, A: 0xff},
+	{R: 0x8e, G: 0x24, B: 0xaa, A: 0xff},
+	{R: 0x6d, G: 0x4c, B: 0x41, A: 0xff},
+	{R: 0x42, G: 0x42, B: 0x42, A: 0xff},
+}
+
+// ChartColorMarks are emoji squares of ChartColors, they make legend of chart in message caption
+var ChartColorMarks = []string{"🟥", "🟧", "🟨", "🟩", "🟦", "🟪", "🟫", "⬛"}
+
+// ChartBar is one bar of bar chart
+type ChartBar struct {
+	Label string // digits and dots drawn under the bar, e.g. day of month
+	Value int64  // in cents
+}
+
+const (
+	pieChartSize = 600
+	pieHoleRatio = 0.45 // radius of hole in the middle of pie relative to its radius
+
+	barChartWidth  = 900
+	barChartHeight = 450
+	chartMargin    = 20
+	barChartLines  = 4 // horizontal grid lines above zero line
+
+	glyphScale = 3 // size of glyph pixel in image pixels
+)
+
+var (
+	chartBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	chartGrid       = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+	chartText       = color.RGBA{R: 0x61, G: 0x61, B: 0x61, A: 0xff}
+)
+
+// glyphs is a 3x5 pixel font of chart labels, each row is 3 bits from left to right
+var glyphs = map[rune][5]uint8{
+	'0': {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1': {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2': {0b111, 0b001, 0b111, 0b100, 0b111},
+	'3': {0b111, 0b001, 0b111, 0b001, 0b111},
+	'4': {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5': {0b111, 0b100, 0b111, 0b001, 0b111},
+	'6': {0b111, 0b100, 0b111, 0b101, 0b111},
+	'7': {0b111, 0b001, 0b010, 0b010, 0b010},
+	'8': {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9': {0b111, 0b101, 0b111, 0b001, 0b111},
+	'.': {0b000, 0b000, 0b000, 0b000, 0b010},
+}
+
+// WritePieChart writes PNG donut chart of values, slice i has colour ChartColors[i]
+func WritePieChart(w io.Writer, values []int64) error {
+	if len(values) > len(ChartColors) {
+		return fmt.Errorf("too many chart values: %d", len(values))
+	}
+
+	var total int64
+	for _, v := range values {
+		if v < 0 {
+			return fmt.Errorf("negative chart value: %d", v)
+		}
+		total += v
+	}
+	if total == 0 {
+		return fmt.Errorf("no chart values")
+	}
+
+	// bounds[i] is angle where slice i ends, slices go clockwise from the top
+	bounds := make([]float64, len(values))
+	var sum int64
+	for i, v := range values {
+		sum += v
+		bounds[i] = 2 * math.Pi * float64(sum) / float64(total)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, pieChartSize, pieChartSize))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: chartBackground}, image.Point{}, draw.Src)
+
+	center := float64(pieChartSize) / 2
+	radius := center - chartMargin
+	hole := radius * pieHoleRatio
+
+	// every pixel is sampled in 4 points, so edges of slices are smooth
+	offsets := []float64{0.25, 0.75}
+	for y := 0; y < pieChartSize; y++ {
+		for x := 0; x < pieChartSize; x++ {
+			var r, g, b int
+			for _, oy := range offsets {
+				for _, ox := range offsets {
+					c := chartBackground
+					dx, dy := float64(x)+ox-center, float64(y)+oy-center
+					if d := math.Hypot(dx, dy); d <= radius && d >= hole {
+						angle := math.Atan2(dx, -dy)
+						if angle < 0 {
+							angle += 2 * math.Pi
+						}
+						for i, bound := range bounds {
+							if angle <= bound {
+								c = ChartColors[i]
+								break
+							}
+						}
+					}
+					r, g, b = r+int(c.R), g+int(c.G), b+int(c.B)
+				}
+			}
+			img.SetRGBA(x, y, color.RGBA{R: uint8(r / 4), G: uint8(g / 4), B: uint8(b / 4), A: 0xff})
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// WriteBarChart writes PNG bar chart of bars from left to right with grid lines labelled in whole units
+func WriteBarChart(w io.Writer, bars []ChartBar) error {
+	if len(bars) == 0 {
+		return fmt.Errorf("no chart bars")
+	}
+
+	var maxValue int64
+	for _, bar := range bars {
+		if bar.Value < 0 {
+			return fmt.Errorf("negative chart value: %d", bar.Value)
+		}
+		maxValue = max(maxValue, bar.Value)
+	}
+	top := chartAxisTop(float64(maxValue) / 100)
+
+	img := image.NewRGBA(image.Rect(0, 0, barChartWidth, barChartHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: chartBackground}, image.Point{}, draw.Src)
+
+	// grid line labels are on the left, bar labels are at the bottom
+	glyphHeight := 5 * glyphScale
+	left := chartMargin + labelWidth(formatChartValue(top)) + chartMargin/2
+	bottom := barChartHeight - chartMargin - glyphHeight - chartMargin/2
+	plotTop := chartMargin + glyphHeight/2
+	plotHeight := bottom - plotTop
+
+	for i := 0; i <= barChartLines; i++ {
+		y := bottom - plotHeight*i/barChartLines
+		fillRect(img, image.Rect(left, y, barChartWidth-chartMargin, y+1), chartGrid)
+		label := formatChartValue(top * float64(i) / barChartLines)
+		drawLabel(img, left-chartMargin/2-labelWidth(label), y-glyphHeight/2, label)
+	}
+
+	// labels are skipped when they do not fit under their bars
+	slot := float64(barChartWidth-chartMargin-left) / float64(len(bars))
+	labelStep := 1
+	for _, bar := range bars {
+		for float64(labelWidth(bar.Label)+glyphScale*2) > slot*float64(labelStep) {
+			labelStep++
+		}
+	}
+
+	gap := max(1, int(slot/5))
+	for i, bar := range bars {
+		x0 := left + int(slot*float64(i)) + gap/2
+		x1 := left + int(slot*float64(i+1)) - gap/2
+		if x1 <= x0 {
+			x1 = x0 + 1
+		}
+
+		height := int(float64(bar.Value) / 100 / top * float64(plotHeight))
+		fillRect(img, image.Rect(x0, bottom-height, x1, bottom), ChartColors[4])
+
+		if i%labelStep == 0 {
+			x := (x0+x1)/2 - labelWidth(bar.Label)/2
+			drawLabel(img, x, bottom+chartMargin/2, bar.Label)
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// chartAxisTop returns value of the top grid line: 1, 2 or 5 times power of 10 not less than value
+func chartAxisTop(value float64) float64 {
+	if value <= 0 {
+		return 1
+	}
+
+	power := math.Pow(10, math.Floor(math.Log10(value)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if value <= m*power {
+			return m * power
+		}
+	}
+	return 10 * power
+}
+
+// formatChartValue formats value of grid line without trailing zeros
+func formatChartValue(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// labelWidth returns width of label drawn by drawLabel in pixels
+func labelWidth(label string) int {
+	n := 0
+	for _, r := range label {
+		if _, ok := glyphs[r]; ok {
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return n*4*glyphScale - glyphScale
+}
+
+// drawLabel draws label with top left corner at x, y, characters missing in glyphs are skipped
+func drawLabel(img *image.RGBA, x, y int, label string) {
+	for _, r := range label {
+		glyph, ok := glyphs[r]
+		if !ok {
+			continue
+		}
+		for row, bits := range glyph {
+			for col := 0; col < 3; col++ {
+				if bits&(0b100>>col) != 0 {
+					px, py := x+col*glyphScale, y+row*glyphScale
+					fillRect(img, image.Rect(px, py, px+glyphScale, py+glyphScale), chartText)
+				}
+			}
+		}
+		x += 4 * glyphScale
+	}
+}
+
+// fillRect fills rectangle of image with colour
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	draw.Draw(img, rect, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExportFormat is a file format of exported expenses
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportXLSX ExportFormat = "xlsx"
+)
+
+// ExportRow is one expense line of exported table
+type ExportRow struct {
+	Date        time.Time
+	Amount      int64 // in cents
+	Currency    string
+	Category    string
+	Description string
+}
+
+// exportHeader lists columns of exported table
+var exportHeader = []string{"date", "amount", "currency", "category", "description"}
+
+// WriteExport writes rows in given format
+func WriteExport(w io.Writer, format ExportFormat, rows []ExportRow) error {
+	switch format {
+	case ExportCSV:
+		return WriteCSV(w, rows)
+	case ExportXLSX:
+		return WriteXLSX(w, rows)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// WriteCSV writes rows as CSV with UTF-8 BOM, so spreadsheets detect encoding of cyrillic text
+func WriteCSV(w io.Writer, rows []ExportRow) error {
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write([]string{formatExportDate(r.Date), formatExportAmount(r.Amount), exportText(r.Currency), exportText(r.Category), exportText(r.Description)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteXLSX writes rows as single sheet XLSX workbook, amounts are stored as numbers
+func WriteXLSX(w io.Writer, rows []ExportRow) error {
+	zw := zip.NewWriter(w)
+
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/worksheets/sheet1.xml", xlsxSheet(rows)},
+	} {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// xlsxSheet builds worksheet with header and one row per expense
+func xlsxSheet(rows []ExportRow) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	b.WriteString(`<row r="1">`)
+	for i, title := range exportHeader {
+		writeXLSXString(&b, i, 1, title)
+	}
+	b.WriteString(`</row>`)
+
+	for i, r := range rows {
+		n := i + 2
+		fmt.Fprintf(&b, `<row r="%d">`, n)
+		writeXLSXString(&b, 0, n, formatExportDate(r.Date))
+		fmt.Fprintf(&b, `<c r="B%d"><v>%s</v></c>`, n, formatExportAmount(r.Amount))
+		writeXLSXString(&b, 2, n, exportText(r.Currency))
+		writeXLSXString(&b, 3, n, exportText(r.Category))
+		writeXLSXString(&b, 4, n, exportText(r.Description))
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// writeXLSXString writes inline string cell at column col (0 is A) and row
+func writeXLSXString(b *strings.Builder, col, row int, value string) {
+	fmt.Fprintf(b, `<c r="%c%d" t="inlineStr"><is><t xml:space="preserve">`, 'A'+col, row)
+	_ = xml.EscapeText(b, []byte(value))
+	b.WriteString(`</t></is></c>`)
+}
+
+// exportText escapes text of user that spreadsheets would take for formula, e.g. "=HYPERLINK(...)".
+// Such text is prefixed with quote, so it is shown as is.
+func exportText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// formatExportDate formats date in ISO format that spreadsheets recognize
+func formatExportDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// formatExportAmount formats amount in cents as decimal number with dot separator
+func formatExportAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="Expenses" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "", want: ""},
+		{text: "Кофе", want: "Кофе"},
+		{text: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{text: "+7 999 123-45-67", want: "'+7 999 123-45-67"},
+		{text: "-2+3", want: "'-2+3"},
+		{text: "@SUM(A1:A2)", want: "'@SUM(A1:A2)"},
+		{text: "a=b", want: "a=b"},
+	}
+
+	for _, tt := range tests {
+		if got := exportText(tt.text); got != tt.want {
+			t.Errorf("exportText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	rows := []ExportRow{
+		{Date: time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC), Amount: 123450, Currency: "RUB", Category: "=1+1", Description: "@cmd <b>"},
+		{Date: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC), Amount: -5, Currency: "USD", Category: "Еда", Description: ""},
+	}
+
+	var csvOut bytes.Buffer
+	if err := WriteExport(&csvOut, ExportCSV, rows); err != nil {
+		t.Fatal(err)
+	}
+	wantCSV := "\uFEFFdate,amount,currency,category,description\n" +
+		"2024-03-15,1234.50,RUB,'=1+1,'@cmd <b>\n" +
+		"2024-03-16,-0.05,USD,Еда,\n"
+	if csvOut.String() != wantCSV {
+		t.Errorf("CSV = %q, want %q", csvOut.String(), wantCSV)
+	}
+
+	var xlsxOut bytes.Buffer
+	if err := WriteExport(&xlsxOut, ExportXLSX, rows); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(xlsxOut.Bytes()), int64(xlsxOut.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := zr.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	sheet, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<c r="B2"><v>1234.50</v></c>`,
+		`<c r="D2" t="inlineStr"><is><t xml:space="preserve">&#39;=1+1</t></is></c>`,
+		`<c r="E2" t="inlineStr"><is><t xml:space="preserve">&#39;@cmd &lt;b&gt;</t></is></c>`,
+		`<c r="B3"><v>-0.05</v></c>`,
+	} {
+		if !strings.Contains(string(sheet), want) {
+			t.Errorf("sheet has no %s", want)
+		}
+	}
+
+	if err := WriteExport(io.Discard, "pdf", rows); err == nil {
+		t.Error("WriteExport() error = nil for unsupported format")
+	}
+}
@@ -3,16 +3,46 @@ package services
 import (
 	"context"
 	"fmt"
+	"html"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	"saldo/pkg/i18n"
 
 	"github.com/vmkteam/embedlog"
 )
 
-// LLM handles expense parsing from text
+// LLM handles expense and income parsing from text in language of user.
+// Relative dates like "вчера" are resolved against now, its location is user's time zone.
+// Currency is used for amounts mentioned without currency.
+// Category with sub-categories is passed as "Parent (Child, Child)", see CategoryTreeNames.
 type LLM interface {
-	ParseExpenses(ctx context.Context, text string, userCategories []string) ([]ParsedExpense, error)
+	ParseExpenses(ctx context.Context, text string, lang i18n.Lang, now time.Time, currency string, expenseCategories, incomeCategories []string) ([]ParsedExpense, error)
+}
+
+// EmojiPicker picks emoji that fits title of new category
+type EmojiPicker interface {
+	CategoryEmoji(ctx context.Context, title string) (string, error)
+}
+
+// CategoryNode is category title with titles of its sub-categories
+type CategoryNode struct {
+	Title    string
+	Children []string
+}
+
+// CategoryTreeNames formats category tree for LLM prompt, category with sub-categories is written as "Parent (Child, Child)"
+func CategoryTreeNames(tree []CategoryNode) []string {
+	names := make([]string, len(tree))
+	for i, node := range tree {
+		names[i] = node.Title
+		if len(node.Children) > 0 {
+			names[i] += " (" + strings.Join(node.Children, ", ") + ")"
+		}
+	}
+	return names
 }
 
 // MockLLMService is a mock implementation of LLMService
@@ -26,11 +56,12 @@ func NewMockLLMService(logger embedlog.Logger) *MockLLMService {
 }
 
 // ParseExpenses mocks parsing of expense text using LLM
-func (m *MockLLMService) ParseExpenses(ctx context.Context, text string, userCategories []string) (*ParsedExpense, error) {
+func (m *MockLLMService) ParseExpenses(ctx context.Context, text string, userCategories, _ []string) (*ParsedExpense, error) {
 	m.logger.Print(ctx, "mock llm parse expense", "text", text, "categories", userCategories)
 
 	// Simple pattern matching (mock LLM behavior)
 	parsed := &ParsedExpense{
+		Type:     OperationExpense,
 		Currency: "RUB",
 	}
 
@@ -113,14 +144,27 @@ func (m *MockLLMService) ParseExpenses(ctx context.Context, text string, userCat
 	return parsed, nil
 }
 
-// FormatExpenseDetails formats expenses details for user confirmation
+// FormatExpenseDetails formats expenses and incomes details for user confirmation in HTML message
 func FormatExpenseDetails(expenses []ParsedExpense) string {
 	var b strings.Builder
 
 	for _, e := range expenses {
-		fmt.Fprintf(&b, "💰 %.2f %s — %s", e.Amount, e.Currency, e.Category)
+		prefix := "💰 "
+		if e.IsIncome() {
+			prefix = "📈 +"
+		}
+		fmt.Fprintf(&b, "%s%.2f %s", prefix, e.Amount, e.Currency)
+		if e.Category != "" {
+			fmt.Fprintf(&b, " — %s", html.EscapeString(e.Category))
+		}
 		if e.Description != "" {
-			fmt.Fprintf(&b, " (%s)", e.Description)
+			fmt.Fprintf(&b, " (%s)", html.EscapeString(e.Description))
+		}
+		for _, tag := range e.Tags {
+			fmt.Fprintf(&b, " #%s", html.EscapeString(tag))
+		}
+		if !e.Date.IsZero() {
+			fmt.Fprintf(&b, " 📅 %s", e.Date.Format("02.01.2006"))
 		}
 		b.WriteString("\n")
 	}
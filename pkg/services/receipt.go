@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+var (
+	// ErrQRNotFound is returned when image has no readable QR code
+	ErrQRNotFound = errors.New("qr code not found")
+
+	// ErrNotReceipt is returned when QR code text is not a fiscal receipt
+	ErrNotReceipt = errors.New("qr code is not a fiscal receipt")
+
+	// ErrReceiptNotPurchase is returned for receipts of refunds and seller's expenses, they are not user's expenses
+	ErrReceiptNotPurchase = errors.New("receipt is not a purchase")
+)
+
+// ReceiptPurchase is operation type of receipt for purchase, other types are refunds and seller's expenses
+const ReceiptPurchase = "1"
+
+// Receipt is fiscal receipt data encoded in its QR code
+type Receipt struct {
+	Date          time.Time
+	Amount        int64  // in cents
+	FiscalDrive   string // fn, number of fiscal drive
+	Document      string // i, number of fiscal document
+	FiscalSign    string // fp, fiscal sign of document
+	OperationType string // n, 1 is purchase
+}
+
+// qrHints make reader search QR code on the whole photo and read text without ECI header as UTF-8
+var qrHints = map[gozxing.DecodeHintType]interface{}{
+	gozxing.DecodeHintType_TRY_HARDER:    true,
+	gozxing.DecodeHintType_CHARACTER_SET: "UTF-8",
+}
+
+// ReadQR returns text of QR code of image
+func ReadQR(img image.Image) (string, error) {
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+
+	// missing, damaged and unsupported codes are reported by different reader exceptions, all of them mean no code
+	result, err := qrcode.NewQRCodeReader().Decode(bitmap, qrHints)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrQRNotFound, err)
+	}
+
+	return result.GetText(), nil
+}
+
+// ParseReceiptQR parses QR code of Russian fiscal receipt like
+// t=20240115T1830&s=1250.00&fn=7380440700076549&i=12345&fp=1234567890&n=1.
+// Some receipts have the same query in URL of OFD site. Receipt time is parsed in loc.
+func ParseReceiptQR(text string, loc *time.Location) (*Receipt, error) {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexByte(text, '?'); i >= 0 {
+		text = text[i+1:]
+	}
+
+	values, err := url.ParseQuery(text)
+	if err != nil || !values.Has("t") || !values.Has("s") {
+		return nil, ErrNotReceipt
+	}
+
+	date, err := parseReceiptDate(values.Get("t"), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := parseStatementAmount(values.Get("s"))
+	if err != nil || amount <= 0 {
+		return nil, fmt.Errorf("invalid receipt amount %q", values.Get("s"))
+	}
+
+	r := &Receipt{
+		Date:          date,
+		Amount:        amount,
+		FiscalDrive:   values.Get("fn"),
+		Document:      values.Get("i"),
+		FiscalSign:    values.Get("fp"),
+		OperationType: values.Get("n"),
+	}
+	if r.OperationType != "" && r.OperationType != ReceiptPurchase {
+		return nil, ErrReceiptNotPurchase
+	}
+
+	return r, nil
+}
+
+// parseReceiptDate parses receipt time YYYYMMDDTHHMM[SS] which is local time of the shop
+func parseReceiptDate(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"20060102T1504", "20060102T150405"} {
+		if date, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid receipt date %q", s)
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"errors"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseReceiptQR(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    *Receipt
+		wantErr error
+		invalid bool
+	}{
+		{
+			name: "purchase",
+			text: "t=20240115T1830&s=1250.00&fn=7380440700076549&i=12345&fp=1234567890&n=1",
+			want: &Receipt{
+				Date:          time.Date(2024, 1, 15, 18, 30, 0, 0, moscow),
+				Amount:        125000,
+				FiscalDrive:   "7380440700076549",
+				Document:      "12345",
+				FiscalSign:    "1234567890",
+				OperationType: ReceiptPurchase,
+			},
+		},
+		{
+			name: "seconds and no operation type",
+			text: " t=20240229T090507&s=99.9&fn=9287440300123456&i=7&fp=42 \n",
+			want: &Receipt{
+				Date:        time.Date(2024, 2, 29, 9, 5, 7, 0, moscow),
+				Amount:      9990,
+				FiscalDrive: "9287440300123456",
+				Document:    "7",
+				FiscalSign:  "42",
+			},
+		},
+		{
+			name: "url of ofd site",
+			text: "https://check.ofd.ru/rec?fn=9287440300123456&i=7&fp=42&t=20231231T2359&s=1&n=1",
+			want: &Receipt{
+				Date:          time.Date(2023, 12, 31, 23, 59, 0, 0, moscow),
+				Amount:        100,
+				FiscalDrive:   "9287440300123456",
+				Document:      "7",
+				FiscalSign:    "42",
+				OperationType: ReceiptPurchase,
+			},
+		},
+		{name: "refund", text: "t=20240115T1830&s=1250.00&fn=1&i=2&fp=3&n=2", wantErr: ErrReceiptNotPurchase},
+		{name: "not a receipt", text: "https://example.com/menu", wantErr: ErrNotReceipt},
+		{name: "no amount", text: "t=20240115T1830&fn=1&i=2&fp=3&n=1", wantErr: ErrNotReceipt},
+		{name: "invalid date", text: "t=2024-01-15&s=1250.00&n=1", invalid: true},
+		{name: "zero amount", text: "t=20240115T1830&s=0.00&n=1", invalid: true},
+		{name: "invalid amount", text: "t=20240115T1830&s=abc&n=1", invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReceiptQR(tt.text, moscow)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseReceiptQR() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.invalid:
+				if err == nil {
+					t.Fatalf("ParseReceiptQR() = %+v, want error", got)
+				}
+				return
+			case err != nil:
+				t.Fatalf("ParseReceiptQR() error = %v", err)
+			}
+
+			if !got.Date.Equal(tt.want.Date) || got.Date.Location() != moscow {
+				t.Errorf("Date = %v, want %v", got.Date, tt.want.Date)
+			}
+			got.Date = tt.want.Date
+			if *got != *tt.want {
+				t.Errorf("ParseReceiptQR() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadQR(t *testing.T) {
+	const receipt = "t=20240315T1530&s=1234.50&fn=9287440300123456&i=12345&fp=1234567890&n=1"
+
+	tests := []struct {
+		file string
+		want string
+		err  error
+	}{
+		{file: "version2.png", want: "hello, saldo"},
+		{file: "receipt.png", want: receipt},
+		{file: "receipt_rotated.png", want: receipt},
+		{file: "version5q.png", want: "fn=9287440300123456&i=12345&fp=1234567890"},
+		{file: "version7.png", want: "Кофе 250 ₽ " + receipt},
+		// 48 modules of data codewords are flipped, they are fixed by Reed-Solomon correction
+		{file: "receipt_damaged.png", want: receipt},
+		// too many codewords are damaged to be corrected
+		{file: "receipt_destroyed.png", err: ErrQRNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			img, err := png.Decode(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadQR(img)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ReadQR() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadQR() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrUnknownStatement is returned when statement file matches neither OFX nor any CSV mapping
+var ErrUnknownStatement = errors.New("unknown statement format")
+
+// StatementLine is one outgoing payment of bank statement
+type StatementLine struct {
+	Date        time.Time
+	Amount      int64 // in cents, always positive
+	Currency    string
+	Description string
+}
+
+// CSVMapping describes columns of bank CSV export. Columns are matched by header titles, case-insensitive.
+// Amount is taken from Debit column if it is set, otherwise from Amount column where payments are negative.
+type CSVMapping struct {
+	Name        string
+	Delimiter   string
+	Date        string
+	DateFormat  string // Go layout, e.g. "02.01.2006 15:04:05"
+	Amount      string
+	Debit       string
+	Currency    string // optional, DefaultCurrency is used if column is absent
+	Description string
+
+	DefaultCurrency string
+}
+
+// DefaultCSVMappings are mappings of common Russian bank exports
+var DefaultCSVMappings = []CSVMapping{
+	{
+		Name:        "Т-Банк",
+		Delimiter:   ";",
+		Date:        "Дата операции",
+		DateFormat:  "02.01.2006 15:04:05",
+		Amount:      "Сумма операции",
+		Currency:    "Валюта операции",
+		Description: "Описание",
+	},
+	{
+		Name:        "Альфа-Банк",
+		Delimiter:   ";",
+		Date:        "Дата операции",
+		DateFormat:  "02.01.06",
+		Debit:       "Расход",
+		Currency:    "Валюта",
+		Description: "Описание операции",
+	},
+	{
+		Name:            "Сбербанк",
+		Delimiter:       ";",
+		Date:            "Дата",
+		DateFormat:      "02.01.2006",
+		Amount:          "Сумма",
+		Description:     "Описание",
+		DefaultCurrency: "RUB",
+	},
+}
+
+// statementHeaderLines is how many first lines of CSV are checked for header row
+const statementHeaderLines = 10
+
+// ParseStatement parses OFX or CSV bank statement and returns outgoing payments only.
+// The first CSV mapping whose columns are all present in file header is used.
+// Dates without time zone are parsed in loc.
+func ParseStatement(filename string, data []byte, mappings []CSVMapping, loc *time.Location) ([]StatementLine, string, error) {
+	text := DecodeText(data)
+
+	switch ext := strings.ToLower(filepath.Ext(filename)); {
+	case ext == ".ofx" || ext == ".qfx" || strings.Contains(text[:min(len(text), 1024)], "<OFX"):
+		lines, err := parseOFX(text, loc)
+		return lines, "OFX", err
+	default:
+		return parseStatementCSV(text, mappings, loc)
+	}
+}
+
+// DecodeText returns data as UTF-8 text, data that is not valid UTF-8 is decoded as windows-1251
+func DecodeText(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\uFEFF"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	return DecodeWindows1251(data)
+}
+
+// windows1251High maps bytes 0x80-0xBF of windows-1251, byte 0x98 is not defined in it
+var windows1251High = [64]rune{
+	0x0402, 0x0403, 0x201A, 0x0453, 0x201E, 0x2026, 0x2020, 0x2021, // 0x80
+	0x20AC, 0x2030, 0x0409, 0x2039, 0x040A, 0x040C, 0x040B, 0x040F, // 0x88
+	0x0452, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014, // 0x90
+	0xFFFD, 0x2122, 0x0459, 0x203A, 0x045A, 0x045C, 0x045B, 0x045F, // 0x98
+	0x00A0, 0x040E, 0x045E, 0x0408, 0x00A4, 0x0490, 0x00A6, 0x00A7, // 0xA0
+	0x0401, 0x00A9, 0x0404, 0x00AB, 0x00AC, 0x00AD, 0x00AE, 0x0407, // 0xA8
+	0x00B0, 0x00B1, 0x0406, 0x0456, 0x0491, 0x00B5, 0x00B6, 0x00B7, // 0xB0
+	0x0451, 0x2116, 0x0454, 0x00BB, 0x0458, 0x0405, 0x0455, 0x0457, // 0xB8
+}
+
+// DecodeWindows1251 decodes windows-1251 text
+func DecodeWindows1251(data []byte) string {
+	var b strings.Builder
+	for _, c := range data {
+		switch {
+		case c < 0x80:
+			b.WriteByte(c)
+		case c >= 0xC0:
+			b.WriteRune(rune(0x0410 + int(c) - 0xC0))
+		default:
+			b.WriteRune(windows1251High[c-0x80])
+		}
+	}
+
+	return b.String()
+}
+
+var (
+	ofxTransactionRegex = regexp.MustCompile(`(?is)<STMTTRN>(.*?)(?:</STMTTRN>|<STMTTRN>|</BANKTRANLIST>)`)
+	ofxFieldRegex       = regexp.MustCompile(`(?i)<(TRNAMT|DTPOSTED|NAME|MEMO|CURSYM)>([^<\r\n]*)`)
+	ofxCurrencyRegex    = regexp.MustCompile(`(?i)<CURDEF>\s*([A-Z]{3})`)
+	ofxUnescaper        = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'")
+)
+
+// parseOFX parses STMTTRN entries of OFX 1.x (SGML) or 2.x (XML) statement
+func parseOFX(text string, loc *time.Location) ([]StatementLine, error) {
+	if !strings.Contains(strings.ToUpper(text), "<STMTTRN>") && !strings.Contains(strings.ToUpper(text), "<BANKTRANLIST>") {
+		return nil, ErrUnknownStatement
+	}
+
+	currency := "RUB"
+	if m := ofxCurrencyRegex.FindStringSubmatch(text); m != nil {
+		currency = strings.ToUpper(m[1])
+	}
+
+	var lines []StatementLine
+	for _, m := range ofxTransactionRegex.FindAllStringSubmatch(text, -1) {
+		fields := ofxFields(m[1])
+
+		amount, err := parseStatementAmount(fields["TRNAMT"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction amount: %w", err)
+		} else if amount >= 0 {
+			continue
+		}
+
+		date, err := parseOFXDate(fields["DTPOSTED"], loc)
+		if err != nil {
+			return nil, err
+		}
+
+		description := fields["NAME"]
+		if memo := fields["MEMO"]; memo != "" && memo != description {
+			description = strings.TrimSpace(description + " " + memo)
+		}
+
+		lineCurrency := currency
+		if c := fields["CURSYM"]; len(c) == 3 {
+			lineCurrency = strings.ToUpper(c)
+		}
+
+		lines = append(lines, StatementLine{Date: date, Amount: -amount, Currency: lineCurrency, Description: description})
+	}
+
+	return lines, nil
+}
+
+// ofxFields returns values of leaf elements of transaction, closing tags are optional in OFX 1.x
+func ofxFields(block string) map[string]string {
+	fields := make(map[string]string)
+	for _, m := range ofxFieldRegex.FindAllStringSubmatch(block, -1) {
+		tag := strings.ToUpper(m[1])
+		if _, ok := fields[tag]; !ok {
+			fields[tag] = strings.TrimSpace(ofxUnescaper.Replace(m[2]))
+		}
+	}
+	return fields
+}
+
+// parseOFXDate parses OFX datetime YYYYMMDD[HHMMSS[.XXX]][[gmt offset:tz name]]
+func parseOFXDate(s string, loc *time.Location) (time.Time, error) {
+	if i := strings.IndexAny(s, ".["); i >= 0 {
+		s = s[:i]
+	}
+
+	switch len(s) {
+	case 8:
+		return time.ParseInLocation("20060102", s, loc)
+	case 12:
+		return time.ParseInLocation("200601021504", s, loc)
+	case 14:
+		return time.ParseInLocation("20060102150405", s, loc)
+	default:
+		return time.Time{}, fmt.Errorf("invalid transaction date %q", s)
+	}
+}
+
+// parseStatementCSV finds mapping by header row and parses payments with it
+func parseStatementCSV(text string, mappings []CSVMapping, loc *time.Location) ([]StatementLine, string, error) {
+	for _, mapping := range mappings {
+		records, columns, ok := readMappedCSV(text, mapping)
+		if !ok {
+			continue
+		}
+
+		lines, err := parseMappedRecords(records, columns, mapping, loc)
+		return lines, mapping.Name, err
+	}
+
+	return nil, "", ErrUnknownStatement
+}
+
+// csvColumns are indexes of mapped columns, -1 if column is not used
+type csvColumns struct {
+	date, amount, debit, currency, description int
+}
+
+// readMappedCSV reads records after header row that contains all mapping's columns
+func readMappedCSV(text string, mapping CSVMapping) ([][]string, csvColumns, bool) {
+	r := csv.NewReader(strings.NewReader(text))
+	if mapping.Delimiter != "" {
+		r.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	}
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, csvColumns{}, false
+	}
+
+	for i := 0; i < len(records) && i < statementHeaderLines; i++ {
+		if columns, ok := mapping.columns(records[i]); ok {
+			return records[i+1:], columns, true
+		}
+	}
+
+	return nil, csvColumns{}, false
+}
+
+// columns returns indexes of mapped columns in header row, false if some column is missing
+func (m CSVMapping) columns(header []string) (csvColumns, bool) {
+	index := make(map[string]int, len(header))
+	for i, title := range header {
+		index[strings.ToLower(strings.TrimSpace(title))] = i
+	}
+
+	missing := false
+	column := func(title string) int {
+		if title == "" {
+			return -1
+		}
+		i, ok := index[strings.ToLower(title)]
+		if !ok {
+			missing = true
+		}
+		return i
+	}
+
+	columns := csvColumns{
+		date:        column(m.Date),
+		amount:      column(m.Amount),
+		debit:       column(m.Debit),
+		currency:    column(m.Currency),
+		description: column(m.Description),
+	}
+	if missing || columns.date < 0 || (columns.amount < 0 && columns.debit < 0) {
+		return csvColumns{}, false
+	}
+
+	return columns, true
+}
+
+// parseMappedRecords converts CSV records to payments, incoming operations and empty rows are skipped
+func parseMappedRecords(records [][]string, columns csvColumns, mapping CSVMapping, loc *time.Location) ([]StatementLine, error) {
+	field := func(record []string, i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var lines []StatementLine
+	for n, record := range records {
+		dateText := field(record, columns.date)
+		if dateText == "" {
+			continue
+		}
+
+		var (
+			amount int64
+			err    error
+		)
+		if columns.debit >= 0 {
+			amount, err = parseStatementAmount(field(record, columns.debit))
+		} else {
+			amount, err = parseStatementAmount(field(record, columns.amount))
+			amount = -amount
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount: %w", n+1, err)
+		} else if amount <= 0 {
+			continue
+		}
+
+		date, err := time.ParseInLocation(mapping.DateFormat, dateText, loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", n+1, dateText)
+		}
+
+		currency := strings.ToUpper(field(record, columns.currency))
+		if currency == "" {
+			currency = mapping.DefaultCurrency
+		}
+		if currency == "" || currency == "RUR" {
+			currency = "RUB"
+		}
+
+		lines = append(lines, StatementLine{
+			Date:        date,
+			Amount:      amount,
+			Currency:    currency,
+			Description: field(record, columns.description),
+		})
+	}
+
+	return lines, nil
+}
+
+// parseStatementAmount parses amount like "-1 234,56" or "1234.56" into cents, empty amount is zero
+func parseStatementAmount(s string) (int64, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(s)
+	if s == "" {
+		return 0, nil
+	}
+	s = strings.ReplaceAll(s, ",", ".")
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	cents := value * 100
+	if cents < 0 {
+		return int64(cents - 0.5), nil
+	}
+	return int64(cents + 0.5), nil
+}
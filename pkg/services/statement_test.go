@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWindows1251Decode(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{data: []byte("Shop 42"), want: "Shop 42"},
+		{data: []byte{0xCF, 0xF0, 0xE8, 0xE2, 0xE5, 0xF2}, want: "Привет"},
+		{data: []byte{0xA8, 0xEB, 0xEA, 0xE0, 0x20, 0xB8, 0xE6}, want: "Ёлка ёж"},
+		{data: []byte{0xB9, 0x31, 0xA0, 0xAB, 0xEE, 0xBB, 0x20, 0x96, 0x20, 0x97}, want: "№1 «о» – —"},
+		{data: []byte{0x80, 0x88, 0x99, 0xB2, 0xBF}, want: "Ђ€™Ії"},
+		{data: []byte{0x98}, want: "�"},
+	}
+
+	for _, tt := range tests {
+		if got := DecodeWindows1251(tt.data); got != tt.want {
+			t.Errorf("DecodeWindows1251(% X) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+const testOFX1 = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>RUB
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240315120000.000[+3:MSK]
+<TRNAMT>-1234.50
+<NAME>YANDEX.TAXI
+<MEMO>Поездка
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240316
+<TRNAMT>5000.00
+<NAME>Salary
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240317
+<TRNAMT>-15,99
+<NAME>Steam &amp; Co
+<CURRENCY><CURSYM>usd</CURRENCY>
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const testOFX2 = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="211"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>EUR</CURDEF>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>202403181530</DTPOSTED><TRNAMT>-7.20</TRNAMT><NAME>Cafe</NAME><MEMO>Cafe</MEMO></STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+const testTBankCSV = `"Дата операции";"Дата платежа";"Номер карты";"Статус";"Сумма операции";"Валюта операции";"Описание"
+"15.03.2024 18:30:00";"16.03.2024";"*1234";"OK";"-350,00";"RUB";"Кофейня"
+"15.03.2024 19:00:00";"16.03.2024";"*1234";"OK";"1000,00";"RUB";"Кэшбэк"
+"16.03.2024 10:05:00";"17.03.2024";"*1234";"OK";"-12,5";"usd";"Netflix"
+`
+
+const testAlfaCSV = `Дата операции;Описание операции;Приход;Расход;Валюта
+18.03.24;Магнит;;"1 020,00";RUR
+19.03.24;Возврат;"500,00";;RUR
+`
+
+func TestParseStatement(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sber, err := os.ReadFile(filepath.Join("testdata", "sber.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	custom := CSVMapping{
+		Name:            "Мой банк",
+		Delimiter:       ",",
+		Date:            "date",
+		DateFormat:      "2006-01-02",
+		Amount:          "amount",
+		Description:     "payee",
+		DefaultCurrency: "GEL",
+	}
+
+	tests := []struct {
+		name       string
+		filename   string
+		data       []byte
+		mappings   []CSVMapping
+		wantSource string
+		want       []StatementLine
+		wantErr    error
+	}{
+		{
+			name:       "ofx 1.x",
+			filename:   "statement.ofx",
+			data:       []byte(testOFX1),
+			wantSource: "OFX",
+			want: []StatementLine{
+				{Date: time.Date(2024, 3, 15, 12, 0, 0, 0, moscow), Amount: 123450, Currency: "RUB", Description: "YANDEX.TAXI Поездка"},
+				{Date: time.Date(2024, 3, 17, 0, 0, 0, 0, moscow), Amount: 1599, Currency: "USD", Description: "Steam & Co"},
+			},
+		},
+		{
+			name:       "ofx 2.x without extension",
+			filename:   "export",
+			data:       []byte(testOFX2),
+			wantSource: "OFX",
+			want: []StatementLine{
+				{Date: time.Date(2024, 3, 18, 15, 30, 0, 0, moscow), Amount: 720, Currency: "EUR", Description: "Cafe"},
+			},
+		},
+		{
+			name:       "t-bank csv",
+			filename:   "operations.csv",
+			data:       []byte("\uFEFF" + testTBankCSV),
+			mappings:   DefaultCSVMappings,
+			wantSource: "Т-Банк",
+			want: []StatementLine{
+				{Date: time.Date(2024, 3, 15, 18, 30, 0, 0, moscow), Amount: 35000, Currency: "RUB", Description: "Кофейня"},
+				{Date: time.Date(2024, 3, 16, 10, 5, 0, 0, moscow), Amount: 1250, Currency: "USD", Description: "Netflix"},
+			},
+		},
+		{
+			name:       "alfa-bank csv with debit column",
+			filename:   "alfa.csv",
+			data:       []byte(testAlfaCSV),
+			mappings:   DefaultCSVMappings,
+			wantSource: "Альфа-Банк",
+			want: []StatementLine{
+				{Date: time.Date(2024, 3, 18, 0, 0, 0, 0, moscow), Amount: 102000, Currency: "RUB", Description: "Магнит"},
+			},
+		},
+		{
+			name:       "sberbank csv in windows-1251",
+			filename:   "sber.csv",
+			data:       sber,
+			mappings:   DefaultCSVMappings,
+			wantSource: "Сбербанк",
+			want: []StatementLine{
+				{Date: time.Date(2024, 3, 15, 0, 0, 0, 0, moscow), Amount: 123456, Currency: "RUB", Description: "Супермаркет «Пятёрочка» — №123"},
+				{Date: time.Date(2024, 3, 17, 0, 0, 0, 0, moscow), Amount: 250000, Currency: "RUB", Description: "АЗС Лукойл"},
+			},
+		},
+		{
+			name:       "custom mapping goes first",
+			filename:   "custom.csv",
+			data:       []byte("date,payee,amount\n2024-03-20,Carrefour,-45.10\n"),
+			mappings:   append([]CSVMapping{custom}, DefaultCSVMappings...),
+			wantSource: "Мой банк",
+			want: []StatementLine{
+				{Date: time.Date(2024, 3, 20, 0, 0, 0, 0, moscow), Amount: 4510, Currency: "GEL", Description: "Carrefour"},
+			},
+		},
+		{
+			name:     "unknown csv",
+			filename: "report.csv",
+			data:     []byte("a;b;c\n1;2;3\n"),
+			mappings: DefaultCSVMappings,
+			wantErr:  ErrUnknownStatement,
+		},
+		{
+			name:     "ofx without transactions",
+			filename: "empty.ofx",
+			data:     []byte("<OFX></OFX>"),
+			wantErr:  ErrUnknownStatement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, source, err := ParseStatement(tt.filename, tt.data, tt.mappings, moscow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseStatement() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if source != tt.wantSource {
+				t.Errorf("ParseStatement() source = %q, want %q", source, tt.wantSource)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStatement() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatementErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "invalid amount", data: "Дата;Описание;Сумма\n15.03.2024;Магазин;много\n"},
+		{name: "invalid date", data: "Дата;Описание;Сумма\n2024-03-15;Магазин;-100\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseStatement("sber.csv", []byte(tt.data), DefaultCSVMappings, time.UTC); err == nil {
+				t.Error("ParseStatement() error = nil, want error")
+			}
+		})
+	}
+}
@@ -2,21 +2,40 @@ package services
 
 import (
 	"context"
+	"time"
+
+	"saldo/pkg/i18n"
 
 	"github.com/vmkteam/embedlog"
 )
 
-// Transcriber handles voice transcription
+// Transcriber handles voice transcription in language of user
 type Transcriber interface {
-	Transcribe(ctx context.Context, oggFilePath string) (string, error)
+	Transcribe(ctx context.Context, oggFilePath string, lang i18n.Lang) (string, error)
 }
 
-// ParsedExpense represents parsed expense data from LLM
+// OperationType is a direction of money operation returned by LLM
+type OperationType string
+
+const (
+	OperationExpense OperationType = "expense"
+	OperationIncome  OperationType = "income"
+)
+
+// ParsedExpense represents parsed expense or income data from LLM
 type ParsedExpense struct {
-	Amount      float64 `json:"amount"`
-	Currency    string  `json:"currency"`
-	Category    string  `json:"category"`
-	Description string  `json:"description"`
+	Type        OperationType `json:"type"`
+	Amount      float64       `json:"amount"`
+	Currency    string        `json:"currency"`
+	Category    string        `json:"category"`
+	Description string        `json:"description"`
+	Date        time.Time     `json:"-"` // operation date if it is known, e.g. from receipt or mentioned in text, zero means now
+	Tags        []string      `json:"-"` // hashtags of user's message, they are cut before text is given to LLM
+}
+
+// IsIncome reports whether LLM recognized operation as income. Empty type means expense.
+func (p ParsedExpense) IsIncome() bool {
+	return p.Type == OperationIncome
 }
 
 // MockTranscriber is a mock implementation of Transcriber
@@ -30,8 +49,8 @@ func NewMockTranscriber(logger embedlog.Logger) *MockTranscriber {
 }
 
 // Transcribe mocks transcription of audio file
-func (m *MockTranscriber) Transcribe(ctx context.Context, oggFilePath string) (string, error) {
-	m.logger.Print(ctx, "mock transcriber", "file", oggFilePath)
+func (m *MockTranscriber) Transcribe(ctx context.Context, oggFilePath string, lang i18n.Lang) (string, error) {
+	m.logger.Print(ctx, "mock transcriber", "file", oggFilePath, "lang", lang)
 
 	// Mock response - in real implementation this would call whisper.cpp
 	return "купил еды на 500 рублей в категории еда", nil
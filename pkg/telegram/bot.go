@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"saldo/pkg/saldo"
@@ -23,12 +26,16 @@ type Bot struct {
 	transcriber      services.Transcriber
 	llm              services.LLM
 	prometheusClient *services.PrometheusClient
+	csvMappings      []services.CSVMapping
+	username         string   // username of bot for invite links, it is known after start
+	importing        sync.Map // users whose uploaded statement is being categorized
 }
 
 type Config struct {
-	Token     string
-	Debug     bool
-	GroqToken string
+	Token       string
+	Debug       bool
+	GroqToken   string
+	CSVMappings []services.CSVMapping // bank statement formats checked before the default ones
 }
 
 // New creates a new Telegram bot instance
@@ -39,6 +46,7 @@ func New(ctx context.Context, cfg Config, saldoService *saldo.Manager, logger em
 
 	opts := []bot.Option{
 		bot.WithDefaultHandler(defaultHandler(logger)),
+		bot.WithMiddlewares(localeMiddleware(saldoService, logger)),
 	}
 
 	if cfg.Debug {
@@ -67,10 +75,11 @@ func New(ctx context.Context, cfg Config, saldoService *saldo.Manager, logger em
 		logger:           logger,
 		saldo:            saldoService,
 		debug:            cfg.Debug,
-		stateManager:     NewStateManager(),
+		stateManager:     NewStateManager(saldoService, logger),
 		transcriber:      groq,
 		llm:              groq,
 		prometheusClient: promClient,
+		csvMappings:      slices.Concat(cfg.CSVMappings, services.DefaultCSVMappings),
 	}
 
 	// Register command handlers
@@ -89,7 +98,18 @@ func (b *Bot) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to get bot info: %w", err)
 	}
 
+	b.username = me.Username
 	b.logger.Print(ctx, "telegram bot started", "username", me.Username, "id", me.ID)
+
+	// Charge recurring expenses in background while bot is running
+	go b.runRecurringScheduler(ctx)
+
+	// Send weekly and monthly digests to subscribed users
+	go b.runDigestScheduler(ctx)
+
+	// Remove conversation states that nobody finished
+	go b.stateManager.runCleanup(ctx)
+
 	b.api.Start(ctx)
 
 	return nil
@@ -250,9 +270,19 @@ func (b *Bot) retryMetricInitialization(ctx context.Context) {
 
 // registerHandlers registers all command handlers
 func (b *Bot) registerHandlers() {
-	// Command handlers
-	b.api.RegisterHandler(bot.HandlerTypeMessageText, "/start", bot.MatchTypeExact, b.handleStart)
-	b.api.RegisterHandler(bot.HandlerTypeMessageText, "/help", bot.MatchTypeExact, b.handleHelp)
+	// Command handlers, commands of group chats come with bot username and /start may have deep link payload
+	b.api.RegisterHandlerMatchFunc(matchCommand("/start"), b.handleStart)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/help"), b.handleHelp)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/undo"), b.handleUndo)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/trash"), b.handleTrashCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/recurring"), b.handleRecurringCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/currency"), b.handleCurrencyCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/language"), b.handleLanguageCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/settings"), b.handleSettingsCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/ledger"), b.handleLedgerCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/categories"), b.handleCategoriesCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/tags"), b.handleTagsCommand)
+	b.api.RegisterHandlerMatchFunc(matchCommand("/find"), b.handleFindCommand)
 
 	// Callback query handler for inline keyboards
 	b.api.RegisterHandler(bot.HandlerTypeCallbackQueryData, "", bot.MatchTypePrefix, b.handleCallback)
@@ -261,6 +291,19 @@ func (b *Bot) registerHandlers() {
 	b.api.RegisterHandler(bot.HandlerTypeMessageText, "", bot.MatchTypePrefix, b.handleMessage)
 }
 
+// matchCommand matches message with command, "/command@botname" and arguments after space are allowed
+func matchCommand(command string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		if update.Message == nil {
+			return false
+		}
+
+		name, _, _ := strings.Cut(update.Message.Text, " ")
+		name, _, _ = strings.Cut(name, "@")
+		return name == command
+	}
+}
+
 // defaultHandler handles unknown messages
 func defaultHandler(logger embedlog.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -268,7 +311,7 @@ func defaultHandler(logger embedlog.Logger) bot.HandlerFunc {
 			logger.Print(ctx, "unknown command", "text", update.Message.Text, "from", update.Message.From.Username)
 			_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: update.Message.Chat.ID,
-				Text:   "Неизвестная команда. Используйте /help для списка доступных команд.",
+				Text:   tr(ctx, "unknown_command"),
 			})
 			if err != nil {
 				logger.Error(ctx, "failed to send message", "err", err)
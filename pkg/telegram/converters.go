@@ -1,6 +1,11 @@
 package telegram
 
-import "saldo/pkg/saldo"
+import (
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+)
 
 // NewUser converts saldo.User to telegram.User
 func NewUser(u *saldo.User) *User {
@@ -17,10 +22,17 @@ func NewUser(u *saldo.User) *User {
 	}
 
 	return &User{
-		ID:        u.ID,
-		Username:  u.TelegramUsername,
-		FirstName: firstName,
-		LastName:  lastName,
+		ID:              u.ID,
+		Username:        u.TelegramUsername,
+		FirstName:       firstName,
+		LastName:        lastName,
+		BaseCurrency:    u.BaseCurrency,
+		Language:        i18n.Parse(u.Language),
+		Timezone:        u.Timezone,
+		DefaultCurrency: u.DefaultCurrency,
+		WeekStart:       time.Weekday(u.WeekStart),
+		WeeklyDigest:    u.WeeklyDigestAt != nil,
+		MonthlyDigest:   u.MonthlyDigestAt != nil,
 	}
 }
 
@@ -36,10 +48,11 @@ func NewCategory(c *saldo.Category) *Category {
 	}
 
 	return &Category{
-		ID:     c.ID,
-		UserID: c.UserID,
-		Title:  c.Title,
-		Emoji:  emoji,
+		ID:       c.ID,
+		UserID:   c.UserID,
+		Title:    c.Title,
+		Emoji:    emoji,
+		ParentID: c.ParentID,
 	}
 }
 
@@ -52,8 +65,46 @@ func NewCategories(categories []saldo.Category) []Category {
 	return result
 }
 
+// NewCategoriesUsage converts slice of saldo.CategoryUsage to slice of telegram.CategoryUsage
+func NewCategoriesUsage(usage []saldo.CategoryUsage) []CategoryUsage {
+	result := make([]CategoryUsage, len(usage))
+	for i := range usage {
+		result[i] = CategoryUsage{
+			Category:      *NewCategory(&usage[i].Category),
+			ExpensesCount: usage[i].ExpensesCount,
+		}
+	}
+	return result
+}
+
+// NewTagsUsage converts slice of saldo.TagUsage to slice of telegram.TagUsage
+func NewTagsUsage(usage []saldo.TagUsage) []TagUsage {
+	result := make([]TagUsage, len(usage))
+	for i := range usage {
+		result[i] = TagUsage{
+			Tag:           usage[i].Tag,
+			ExpensesCount: usage[i].ExpensesCount,
+		}
+	}
+	return result
+}
+
+// NewExpenseTotals converts slice of saldo.ExpenseTotal to slice of telegram.ExpenseTotal
+func NewExpenseTotals(totals []saldo.ExpenseTotal) []ExpenseTotal {
+	result := make([]ExpenseTotal, len(totals))
+	for i, total := range totals {
+		result[i] = ExpenseTotal{
+			Category: NewCategory(total.Category),
+			Currency: total.Currency,
+			Day:      total.Day,
+			Amount:   total.Amount,
+			Count:    total.Count,
+		}
+	}
+	return result
+}
+
 // NewExpense converts saldo.Expense to telegram.Expense
-// nolint:unused
 func NewExpense(e *saldo.Expense) *Expense {
 	if e == nil {
 		return nil
@@ -65,6 +116,11 @@ func NewExpense(e *saldo.Expense) *Expense {
 		category = NewCategory(saldoCat)
 	}
 
+	var author string
+	if e.User != nil {
+		author = NewUser(saldo.NewUser(e.User)).DisplayName()
+	}
+
 	return &Expense{
 		ID:          e.ID,
 		UserID:      e.UserID,
@@ -73,12 +129,14 @@ func NewExpense(e *saldo.Expense) *Expense {
 		Currency:    e.Currency,
 		Description: e.Description,
 		CreatedAt:   e.CreatedAt,
+		SpentAt:     e.SpentAt,
+		Author:      author,
+		Tags:        e.Tags,
 		Category:    category,
 	}
 }
 
 // NewExpenses converts slice of saldo.Expense to slice of telegram.Expense
-// nolint:unused
 func NewExpenses(expenses []saldo.Expense) []Expense {
 	result := make([]Expense, len(expenses))
 	for i, exp := range expenses {
@@ -86,3 +144,100 @@ func NewExpenses(expenses []saldo.Expense) []Expense {
 	}
 	return result
 }
+
+// NewIncome converts saldo.Income to telegram.Income
+func NewIncome(i *saldo.Income) *Income {
+	if i == nil {
+		return nil
+	}
+
+	var category *Category
+	if i.Category != nil {
+		category = NewCategory(saldo.NewCategory(i.Category))
+	}
+
+	return &Income{
+		ID:          i.ID,
+		UserID:      i.UserID,
+		CategoryID:  i.CategoryID,
+		Amount:      i.Amount,
+		Currency:    i.Currency,
+		Description: i.Description,
+		CreatedAt:   i.CreatedAt,
+		ReceivedAt:  i.ReceivedAt,
+		Category:    category,
+	}
+}
+
+// NewIncomes converts slice of saldo.Income to slice of telegram.Income
+func NewIncomes(incomes []saldo.Income) []Income {
+	result := make([]Income, len(incomes))
+	for i, inc := range incomes {
+		result[i] = *NewIncome(&inc)
+	}
+	return result
+}
+
+// NewBudget converts saldo.BudgetStatus to telegram.Budget
+func NewBudget(b *saldo.BudgetStatus) *Budget {
+	if b == nil {
+		return nil
+	}
+
+	var category *Category
+	if b.Category != nil {
+		category = NewCategory(saldo.NewCategory(b.Category))
+	}
+
+	return &Budget{
+		ID:          b.ID,
+		CategoryID:  b.CategoryID,
+		Amount:      b.Amount,
+		Currency:    b.Currency,
+		Spent:       b.Spent,
+		Percent:     b.Percent(),
+		CategoryIDs: b.CategoryIDs,
+		Category:    category,
+	}
+}
+
+// NewBudgets converts slice of saldo.BudgetStatus to slice of telegram.Budget
+func NewBudgets(budgets []saldo.BudgetStatus) []Budget {
+	result := make([]Budget, len(budgets))
+	for i, b := range budgets {
+		result[i] = *NewBudget(&b)
+	}
+	return result
+}
+
+// NewRecurringExpense converts saldo.RecurringExpense to telegram.RecurringExpense
+func NewRecurringExpense(r *saldo.RecurringExpense) *RecurringExpense {
+	if r == nil {
+		return nil
+	}
+
+	var category *Category
+	if r.Category != nil {
+		category = NewCategory(saldo.NewCategory(r.Category))
+	}
+
+	return &RecurringExpense{
+		ID:          r.ID,
+		CategoryID:  r.CategoryID,
+		Amount:      r.Amount,
+		Currency:    r.Currency,
+		Description: r.Description,
+		DayOfMonth:  r.DayOfMonth,
+		NextRunAt:   r.NextRunAt,
+		Category:    category,
+	}
+}
+
+// NewRecurringExpenses converts slice of saldo.RecurringExpense to slice of telegram.RecurringExpense
+func NewRecurringExpenses(list []saldo.RecurringExpense) []RecurringExpense {
+	result := make([]RecurringExpense, len(list))
+	for i, r := range list {
+		result[i] = *NewRecurringExpense(&r)
+	}
+	return result
+}
@@ -1,12 +1,14 @@
 package telegram
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"saldo/pkg/i18n"
 )
 
 // TimePeriod represents a time period with start and end dates
@@ -15,35 +17,38 @@ type TimePeriod struct {
 	End   time.Time
 }
 
-// GetTodayPeriod returns period for today
-func GetTodayPeriod() TimePeriod {
-	now := time.Now()
+// GetTodayPeriod returns period for day of now
+func GetTodayPeriod(now time.Time) TimePeriod {
 	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	end := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 999999999, now.Location())
 	return TimePeriod{Start: start, End: end}
 }
 
-// GetWeekPeriod returns period for last 7 days
-func GetWeekPeriod() TimePeriod {
-	now := time.Now()
+// GetWeekPeriod returns period from first day of week of now to the end of day of now
+func GetWeekPeriod(now time.Time, weekStart time.Weekday) TimePeriod {
 	end := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 999999999, now.Location())
-	start := end.AddDate(0, 0, -6)
-	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	days := (int(now.Weekday()) - int(weekStart) + 7) % 7
+	start := time.Date(now.Year(), now.Month(), now.Day()-days, 0, 0, 0, 0, now.Location())
 	return TimePeriod{Start: start, End: end}
 }
 
-// GetMonthPeriod returns period for last 30 days
-func GetMonthPeriod() TimePeriod {
-	now := time.Now()
+// GetMonthPeriod returns period for last 30 days up to now
+func GetMonthPeriod(now time.Time) TimePeriod {
 	end := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 999999999, now.Location())
 	start := end.AddDate(0, 0, -29)
 	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
 	return TimePeriod{Start: start, End: end}
 }
 
+// GetCalendarMonthPeriod returns period from the 1st day of month of now to the end of day of now
+func GetCalendarMonthPeriod(now time.Time) TimePeriod {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 999999999, now.Location())
+	return TimePeriod{Start: start, End: end}
+}
+
 // GetAllTimePeriod returns period from 2000 to now
-func GetAllTimePeriod() TimePeriod {
-	now := time.Now()
+func GetAllTimePeriod(now time.Time) TimePeriod {
 	start := time.Date(2000, 1, 1, 0, 0, 0, 0, now.Location())
 	end := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 999999999, now.Location())
 	return TimePeriod{Start: start, End: end}
@@ -52,8 +57,10 @@ func GetAllTimePeriod() TimePeriod {
 // ParseCustomPeriod parses custom period from user input
 // Supported formats:
 // - "03.04.25 07.04.25" or "03.04.25-07.04.25"
-// - "03.04 07.04" or "03.04-07.04" (uses current year)
-func ParseCustomPeriod(input string) (TimePeriod, error) {
+// - "03.04 07.04" or "03.04-07.04" (uses year of now)
+//
+// Dates are in time zone of now.
+func ParseCustomPeriod(input string, now time.Time) (TimePeriod, error) {
 	// Remove extra spaces
 	input = strings.TrimSpace(input)
 
@@ -66,17 +73,17 @@ func ParseCustomPeriod(input string) (TimePeriod, error) {
 	}
 
 	if len(parts) != 2 {
-		return TimePeriod{}, errors.New("неверный формат даты")
+		return TimePeriod{}, i18n.Errorf("date.invalid_format")
 	}
 
-	start, err := parseDate(strings.TrimSpace(parts[0]))
+	start, err := parseDate(strings.TrimSpace(parts[0]), now)
 	if err != nil {
-		return TimePeriod{}, fmt.Errorf("ошибка в начальной дате: %w", err)
+		return TimePeriod{}, i18n.Errorf("date.start_error", err)
 	}
 
-	end, err := parseDate(strings.TrimSpace(parts[1]))
+	end, err := parseDate(strings.TrimSpace(parts[1]), now)
 	if err != nil {
-		return TimePeriod{}, fmt.Errorf("ошибка в конечной дате: %w", err)
+		return TimePeriod{}, i18n.Errorf("date.end_error", err)
 	}
 
 	// Set time to start of day for start date and end of day for end date
@@ -84,16 +91,16 @@ func ParseCustomPeriod(input string) (TimePeriod, error) {
 	end = time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 999999999, end.Location())
 
 	if start.After(end) {
-		return TimePeriod{}, errors.New("начальная дата не может быть позже конечной")
+		return TimePeriod{}, i18n.Errorf("date.start_after_end")
 	}
 
 	return TimePeriod{Start: start, End: end}, nil
 }
 
-// parseYear parses year from string or returns current year if empty
-func parseYear(yearStr string) int {
+// parseYear parses year from string or returns year of now if empty
+func parseYear(yearStr string, now time.Time) int {
 	if yearStr == "" {
-		return time.Now().Year()
+		return now.Year()
 	}
 
 	year, _ := strconv.Atoi(yearStr)
@@ -107,47 +114,65 @@ func parseYear(yearStr string) int {
 	return year
 }
 
-// parseDate parses date from string
+// parseDate parses date from string in time zone of now
 // Formats: "03.04.25", "03.04"
-func parseDate(s string) (time.Time, error) {
+func parseDate(s string, now time.Time) (time.Time, error) {
 	// Match dd.mm.yy or dd.mm.yyyy or dd.mm
 	re := regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{2,4}))?$`)
 	matches := re.FindStringSubmatch(s)
 
 	if matches == nil {
-		return time.Time{}, errors.New("неверный формат даты (используйте ДД.ММ.ГГ или ДД.ММ)")
+		return time.Time{}, i18n.Errorf("date.invalid_date")
 	}
 
 	day, _ := strconv.Atoi(matches[1])
 	month, _ := strconv.Atoi(matches[2])
-	year := parseYear(matches[3])
+	year := parseYear(matches[3], now)
 
 	// Validate date
 	if month < 1 || month > 12 {
-		return time.Time{}, errors.New("месяц должен быть от 1 до 12")
+		return time.Time{}, i18n.Errorf("date.invalid_month")
 	}
 	if day < 1 || day > 31 {
-		return time.Time{}, errors.New("день должен быть от 1 до 31")
+		return time.Time{}, i18n.Errorf("date.invalid_day")
 	}
 
-	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Now().Location())
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, now.Location())
 
 	// Check if date is valid (e.g., not February 30)
 	if date.Day() != day {
-		return time.Time{}, errors.New("несуществующая дата")
+		return time.Time{}, i18n.Errorf("date.nonexistent")
 	}
 
 	return date, nil
 }
 
-// FormatDate formats date as DD.MM.YY
-func FormatDate(t time.Time) string {
-	return t.Format("02.01.06")
+// FormatDate formats date as DD.MM.YY in time zone of user from context
+func FormatDate(ctx context.Context, t time.Time) string {
+	return t.In(userLocation(ctx)).Format("02.01.06")
 }
 
 // FormatPeriod formats period as "DD.MM.YY - DD.MM.YY"
-func FormatPeriod(period TimePeriod) string {
-	return fmt.Sprintf("%s - %s", FormatDate(period.Start), FormatDate(period.End))
+func FormatPeriod(ctx context.Context, period TimePeriod) string {
+	return fmt.Sprintf("%s - %s", FormatDate(ctx, period.Start), FormatDate(ctx, period.End))
+}
+
+// MonthAgo returns period of the same days of previous month, days missing in shorter month become its last day
+func (p TimePeriod) MonthAgo() TimePeriod {
+	return TimePeriod{Start: monthAgo(p.Start), End: monthAgo(p.End)}
+}
+
+// WeekMonthAgo returns period as long as p that starts on weekStart of the week a month before start of p
+func (p TimePeriod) WeekMonthAgo(weekStart time.Weekday) TimePeriod {
+	start := GetWeekPeriod(monthAgo(p.Start), weekStart).Start
+	return TimePeriod{Start: start, End: start.Add(p.End.Sub(p.Start))}
+}
+
+// monthAgo returns the same time of the same day of previous month, clamped to the last day of it
+func monthAgo(t time.Time) time.Time {
+	first := time.Date(t.Year(), t.Month()-1, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
 }
 
 // DaysBetween returns number of days between start and end
@@ -0,0 +1,93 @@
+package telegram
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"saldo/pkg/i18n"
+)
+
+func TestParseCustomPeriod(t *testing.T) {
+	load := func(name string) *time.Location {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return loc
+	}
+	moscow, vladivostok, newYork := load("Europe/Moscow"), load("Asia/Vladivostok"), load("America/New_York")
+
+	tests := []struct {
+		name    string
+		input   string
+		now     time.Time
+		want    TimePeriod
+		wantErr string // key of i18n error
+	}{
+		{
+			name:  "full dates with space",
+			input: "03.04.25 07.04.25",
+			now:   time.Date(2025, 4, 10, 12, 0, 0, 0, moscow),
+			want: TimePeriod{
+				Start: time.Date(2025, 4, 3, 0, 0, 0, 0, moscow),
+				End:   time.Date(2025, 4, 7, 23, 59, 59, 999999999, moscow),
+			},
+		},
+		{
+			name:  "dates without year in far east",
+			input: " 28.02-01.03 ",
+			now:   time.Date(2024, 3, 1, 1, 0, 0, 0, vladivostok),
+			want: TimePeriod{
+				Start: time.Date(2024, 2, 28, 0, 0, 0, 0, vladivostok),
+				End:   time.Date(2024, 3, 1, 23, 59, 59, 999999999, vladivostok),
+			},
+		},
+		{
+			name:  "four digit years across dst change",
+			input: "09.03.2024-11.03.2024",
+			now:   time.Date(2024, 3, 12, 8, 0, 0, 0, newYork),
+			want: TimePeriod{
+				Start: time.Date(2024, 3, 9, 0, 0, 0, 0, newYork),
+				End:   time.Date(2024, 3, 11, 23, 59, 59, 999999999, newYork),
+			},
+		},
+		{
+			name:  "one day in utc",
+			input: "31.12.99 31.12.99",
+			now:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: TimePeriod{
+				Start: time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(1999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+			},
+		},
+		{name: "one date", input: "03.04.25", now: time.Date(2025, 4, 10, 0, 0, 0, 0, moscow), wantErr: "date.invalid_format"},
+		{name: "three dates", input: "01.04 02.04 03.04", now: time.Date(2025, 4, 10, 0, 0, 0, 0, moscow), wantErr: "date.invalid_format"},
+		{name: "invalid start", input: "сегодня-07.04", now: time.Date(2025, 4, 10, 0, 0, 0, 0, moscow), wantErr: "date.start_error"},
+		{name: "nonexistent end", input: "01.02-30.02", now: time.Date(2025, 4, 10, 0, 0, 0, 0, moscow), wantErr: "date.end_error"},
+		{name: "start after end", input: "07.04-03.04", now: time.Date(2025, 4, 10, 0, 0, 0, 0, moscow), wantErr: "date.start_after_end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCustomPeriod(tt.input, tt.now)
+			if tt.wantErr != "" {
+				var i18nErr *i18n.Error
+				if !errors.As(err, &i18nErr) || i18nErr.Key != tt.wantErr {
+					t.Fatalf("ParseCustomPeriod() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCustomPeriod() error = %v", err)
+			}
+
+			if !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+				t.Errorf("ParseCustomPeriod() = %v - %v, want %v - %v", got.Start, got.End, tt.want.Start, tt.want.End)
+			}
+			if got.Start.Location() != tt.now.Location() || got.End.Location() != tt.now.Location() {
+				t.Errorf("ParseCustomPeriod() location = %v, want %v", got.Start.Location(), tt.now.Location())
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package telegram
 import (
 	"context"
 	"fmt"
+	"html"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -11,13 +13,16 @@ import (
 	"strings"
 	"time"
 
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
 	"saldo/pkg/services"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 )
 
-// handleStart handles /start command - registers or welcomes user
+// handleStart handles /start command - registers or welcomes user.
+// Payload "join_<code>" of deep link adds user to shared ledger.
 func (b *Bot) handleStart(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
 	commandsProcessed.WithLabelValues("start").Inc()
 	if update.Message == nil || update.Message.From == nil {
@@ -34,27 +39,27 @@ func (b *Bot) handleStart(ctx context.Context, botAPI *bot.Bot, update *models.U
 		b.logger.Error(ctx, "failed to get or create user", "err", err, "telegram_user_id", user.ID)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "Произошла ошибка при регистрации. Попробуйте позже.",
+			Text:   tr(ctx, "start.error"),
 		})
 		return
 	}
 
 	// Clear any previous state
-	b.stateManager.ClearState(user.ID)
+	b.stateManager.ClearState(ctx, user.ID, chatID)
 
-	welcomeText := fmt.Sprintf(
-		"👋 Привет, %s!\n\n"+
-			"Я помогу вам вести учет расходов.\n\n"+
-			"Используйте кнопки ниже для управления:",
-		user.FirstName,
-	)
+	if _, payload, _ := strings.Cut(update.Message.Text, " "); strings.HasPrefix(payload, invitePrefix) {
+		b.handleJoinLedger(ctx, botAPI, update.Message.Chat, dbUser, strings.TrimPrefix(payload, invitePrefix))
+		return
+	}
+
+	welcomeText := tr(ctx, "start.welcome", user.FirstName)
 
 	b.logger.Print(ctx, "user started bot", "user_id", dbUser.ID, "telegram_user_id", user.ID, "username", user.Username)
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		Text:        welcomeText,
-		ReplyMarkup: mainMenuKeyboard(),
+		ReplyMarkup: mainMenuKeyboard(ctx),
 	})
 }
 
@@ -65,21 +70,13 @@ func (b *Bot) handleHelp(ctx context.Context, botAPI *bot.Bot, update *models.Up
 		return
 	}
 
-	helpText := `📚 <b>Справка по командам:</b>
-
-<b>➕ Добавить расход</b> - Добавить новый расход
-Нажмите кнопку и отправьте голосовое сообщение или текст с описанием расхода.
-
-<b>📊 Статистика</b> - Статистика
-Показать распределение расходов по категориям или тратам.
-
-💡 Если бот плохо угадывает категорию, вы можете сами подсказать её (например в скобках)`
+	helpText := tr(ctx, "help")
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      update.Message.Chat.ID,
 		Text:        helpText,
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: mainMenuKeyboard(),
+		ReplyMarkup: mainMenuKeyboard(ctx),
 	})
 }
 
@@ -94,12 +91,12 @@ func (b *Bot) handleMessage(ctx context.Context, botAPI *bot.Bot, update *models
 	userID := update.Message.From.ID
 
 	// Get user from DB
-	dbUser, err := b.getUserByTelegramID(ctx, userID)
+	dbUser, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
 	if err != nil || dbUser == nil {
 		errorsTotal.WithLabelValues("user_not_found").Inc()
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "Пожалуйста, используйте /start для начала работы.",
+			Text:   tr(ctx, "use_start"),
 		})
 		return
 	}
@@ -107,7 +104,7 @@ func (b *Bot) handleMessage(ctx context.Context, botAPI *bot.Bot, update *models
 	text := update.Message.Text
 
 	// Check current state
-	stateData := b.stateManager.GetState(userID)
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
 
 	// Check if this is a voice message
 	if update.Message.Voice != nil {
@@ -115,65 +112,143 @@ func (b *Bot) handleMessage(ctx context.Context, botAPI *bot.Bot, update *models
 		if stateData.State == StateAwaitingCustomPeriod {
 			_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "Пожалуйста, введите период текстом в формате: ДД.ММ.ГГ ДД.ММ.ГГ",
+				Text:   tr(ctx, "period.voice_rejected"),
 			})
 			return
 		}
-		// Clear any pending expense state and process voice as new expense
-		if stateData.ExpensesData != nil {
-			b.stateManager.ClearState(userID)
+		if !b.acceptsExpenseInput(update.Message, stateData) {
+			return
 		}
+		// Clear any pending expense state and process voice as new expense
+		b.clearExpenseInput(ctx, userID, chatID, stateData)
 		b.handleVoice(ctx, botAPI, update, dbUser)
 		return
 	}
 
+	// Photo of fiscal receipt is read by its QR code
+	if len(update.Message.Photo) > 0 {
+		if !b.acceptsExpenseInput(update.Message, stateData) {
+			return
+		}
+		b.clearExpenseInput(ctx, userID, chatID, stateData)
+		update.Message.Caption = b.stripBotMention(update.Message.Caption)
+		b.handleReceipt(ctx, botAPI, chatID, userID, dbUser, update.Message)
+		return
+	}
+
+	// Bank statement file is imported as expenses
+	if update.Message.Document != nil {
+		if !b.acceptsExpenseInput(update.Message, stateData) {
+			return
+		}
+		b.handleStatement(ctx, botAPI, chatID, userID, dbUser, update.Message.Document)
+		return
+	}
+
 	// Handle keyboard buttons
 	if b.handleKeyboardButton(ctx, botAPI, chatID, userID, dbUser, text, stateData) {
 		return
 	}
 
+	// Check if user is changing field of saved expense
+	if stateData.State == StateEditingExpense {
+		b.handleEditInput(ctx, botAPI, chatID, userID, dbUser, stateData, text)
+		return
+	}
+
+	// Check if user is fixing amount of not yet confirmed expense
+	if stateData.State == StateAwaitingItemAmount {
+		b.handleItemAmountInput(ctx, botAPI, chatID, userID, stateData, text)
+		return
+	}
+
+	// Check if user is typing time zone in settings
+	if stateData.State == StateAwaitingTimezone {
+		b.handleTimezoneInput(ctx, botAPI, chatID, userID, dbUser, text)
+		return
+	}
+
+	// Check if user is entering recurring expense
+	if stateData.State == StateAwaitingRecurring {
+		b.handleRecurringInput(ctx, botAPI, chatID, userID, dbUser, text)
+		return
+	}
+
+	// Check if user is renaming category
+	if stateData.State == StateAwaitingCategoryName {
+		b.handleCategoryNameInput(ctx, botAPI, chatID, userID, dbUser, stateData, text)
+		return
+	}
+
+	// Check if user is sending emoji of category
+	if stateData.State == StateAwaitingCategoryEmoji {
+		b.handleCategoryEmojiInput(ctx, botAPI, chatID, userID, dbUser, stateData, text)
+		return
+	}
+
+	// Check if user is entering category budget
+	if stateData.State == StateAwaitingBudget {
+		b.handleBudgetInput(ctx, botAPI, chatID, userID, dbUser, text)
+		return
+	}
+
 	// Check if user is entering custom period
 	if stateData.State == StateAwaitingCustomPeriod {
 		b.handleCustomPeriodInput(ctx, botAPI, chatID, userID, dbUser, text)
 		return
 	}
 
-	// Clear any pending expense state and treat message as new expense input
-	if stateData.ExpensesData != nil {
-		b.stateManager.ClearState(userID)
+	if !b.acceptsExpenseInput(update.Message, stateData) {
+		return
 	}
 
+	// Clear any pending expense state and treat message as new expense input
+	b.clearExpenseInput(ctx, userID, chatID, stateData)
+
 	// Any other text message is treated as expense input
-	b.handleExpenseTextInput(ctx, botAPI, chatID, userID, dbUser, text)
+	b.handleExpenseTextInput(ctx, botAPI, chatID, userID, dbUser, b.stripBotMention(text))
 }
 
 func (b *Bot) handleStatisticsButton(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, dbUser *User, text string, stateData *UserStateData) bool {
-	switch text {
-	case "📊 По категориям":
+	key, _ := i18n.Button(text)
+	switch key {
+	case "btn.by_categories":
 		buttonsPressed.WithLabelValues("by_categories").Inc()
 		b.handleStatsTypeSelection(ctx, botAPI, chatID, userID, StatsByCategories)
 		return true
-	case "💸 По тратам":
+	case "btn.by_expenses":
 		buttonsPressed.WithLabelValues("by_expenses").Inc()
 		b.handleStatsTypeSelection(ctx, botAPI, chatID, userID, StatsByExpenses)
 		return true
-	case "📅 За сегодня":
+	case "btn.export":
+		buttonsPressed.WithLabelValues("export").Inc()
+		b.handleStatsTypeSelection(ctx, botAPI, chatID, userID, StatsExport)
+		return true
+	case "btn.charts":
+		buttonsPressed.WithLabelValues("charts").Inc()
+		b.handleStatsTypeSelection(ctx, botAPI, chatID, userID, StatsCharts)
+		return true
+	case "btn.compare":
+		buttonsPressed.WithLabelValues("compare").Inc()
+		b.handleComparison(ctx, botAPI, chatID, userID, dbUser)
+		return true
+	case "btn.today":
 		buttonsPressed.WithLabelValues("period_today").Inc()
 		b.handlePeriodSelection(ctx, botAPI, chatID, userID, dbUser, stateData, "today")
 		return true
-	case "📅 За неделю":
+	case "btn.week":
 		buttonsPressed.WithLabelValues("period_week").Inc()
 		b.handlePeriodSelection(ctx, botAPI, chatID, userID, dbUser, stateData, "week")
 		return true
-	case "📅 За месяц":
+	case "btn.month":
 		buttonsPressed.WithLabelValues("period_month").Inc()
 		b.handlePeriodSelection(ctx, botAPI, chatID, userID, dbUser, stateData, "month")
 		return true
-	case "📅 За всё время":
+	case "btn.all_time":
 		buttonsPressed.WithLabelValues("period_alltime").Inc()
 		b.handlePeriodSelection(ctx, botAPI, chatID, userID, dbUser, stateData, "alltime")
 		return true
-	case "📅 Кастомный период":
+	case "btn.custom_period":
 		buttonsPressed.WithLabelValues("period_custom").Inc()
 		b.handleCustomPeriodStart(ctx, botAPI, chatID, userID, stateData)
 		return true
@@ -184,26 +259,47 @@ func (b *Bot) handleStatisticsButton(ctx context.Context, botAPI *bot.Bot, chatI
 
 // handleKeyboardButton handles keyboard button presses
 // Returns true if button was handled, false otherwise
+// Buttons are matched in all languages, so keyboard sent before language change keeps working.
 func (b *Bot) handleKeyboardButton(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, dbUser *User, text string, stateData *UserStateData) bool {
-	switch text {
-	case "➕ Добавить расход":
+	key, _ := i18n.Button(text)
+	switch key {
+	case "btn.add_expense":
 		buttonsPressed.WithLabelValues("add_expense").Inc()
 		b.handleAddExpenseStart(ctx, botAPI, chatID, userID)
 		return true
-	case "📊 Статистика":
+	case "btn.stats":
 		buttonsPressed.WithLabelValues("statistics").Inc()
 		b.handleStatistics(ctx, botAPI, chatID, userID, dbUser)
 		return true
-	case "💰 Траты за неделю":
+	case "btn.week_expenses":
 		buttonsPressed.WithLabelValues("week_expenses").Inc()
-		period := GetWeekPeriod()
+		period := GetWeekPeriod(userNow(ctx), dbUser.WeekStart)
 		b.handleStatisticsByExpenses(ctx, botAPI, chatID, userID, dbUser, period)
 		return true
-	case "🔙 Назад":
+	case "btn.trash":
+		buttonsPressed.WithLabelValues("trash").Inc()
+		b.handleTrash(ctx, botAPI, chatID, dbUser)
+		return true
+	case "btn.recurring":
+		buttonsPressed.WithLabelValues("recurring").Inc()
+		b.stateManager.ClearState(ctx, userID, chatID)
+		b.handleRecurring(ctx, botAPI, chatID, dbUser)
+		return true
+	case "btn.categories":
+		buttonsPressed.WithLabelValues("categories").Inc()
+		b.stateManager.ClearState(ctx, userID, chatID)
+		b.handleCategories(ctx, botAPI, chatID, dbUser)
+		return true
+	case "btn.budgets":
+		buttonsPressed.WithLabelValues("budgets").Inc()
+		b.stateManager.SetState(ctx, userID, chatID, StateInStatsMenu)
+		b.handleBudgets(ctx, botAPI, chatID, dbUser)
+		return true
+	case "btn.back":
 		buttonsPressed.WithLabelValues("back").Inc()
 		b.handleBack(ctx, botAPI, chatID, userID, stateData)
 		return true
-	case "📊 По категориям", "💸 По тратам", "📅 За сегодня", "📅 За неделю", "📅 За месяц", "📅 За всё время", "📅 Кастомный период":
+	case "btn.by_categories", "btn.by_expenses", "btn.export", "btn.charts", "btn.compare", "btn.today", "btn.week", "btn.month", "btn.all_time", "btn.custom_period":
 		return b.handleStatisticsButton(ctx, botAPI, chatID, userID, dbUser, text, stateData)
 	default:
 		return false
@@ -212,44 +308,59 @@ func (b *Bot) handleKeyboardButton(ctx context.Context, botAPI *bot.Bot, chatID
 
 // handleAddExpenseStart starts the add expense flow
 func (b *Bot) handleAddExpenseStart(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64) {
-	b.stateManager.SetState(userID, StateAwaitingExpense)
+	b.stateManager.SetState(ctx, userID, chatID, StateAwaitingExpense)
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: chatID,
-		Text: "💰 <b>Добавление расхода</b>\n\n" +
-			"Отправьте голосовое сообщение или напишите текстом.\n" +
-			"Например: <code>500 рублей на еду в Макдональдс</code>",
+		ChatID:      chatID,
+		Text:        tr(ctx, "expense.add"),
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: mainMenuKeyboard(),
+		ReplyMarkup: mainMenuKeyboard(ctx),
 	})
 }
 
 // handleExpenseTextInput handles text input for expense
 func (b *Bot) handleExpenseTextInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, text string) {
 	// Get user categories
-	saldoCategories, err := b.saldo.GetUserCategories(ctx, user.ID)
+	saldoCategories, err := b.saldo.GetCategories(ctx, user.LedgerID)
 	if err != nil {
 		errorsTotal.WithLabelValues("get_categories").Inc()
 		b.logger.Error(ctx, "failed to get categories", "err", err)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Ошибка получения категорий.",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        tr(ctx, "categories.get_error"),
+			ReplyMarkup: expenseReplyKeyboard(ctx, chatID),
+		})
+		return
+	}
+
+	saldoIncomeCategories, err := b.saldo.GetIncomeCategories(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get income categories", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr(ctx, "categories.get_error"),
+			ReplyMarkup: expenseReplyKeyboard(ctx, chatID),
 		})
 		return
 	}
 
 	categories := NewCategories(saldoCategories)
+	incomeCategories := NewCategories(saldoIncomeCategories)
 
-	// Extract category names
-	categoryNames := make([]string, len(categories))
-	for i, cat := range categories {
-		categoryNames[i] = cat.Title
+	// Extract category names, sub-categories are given with their parents
+	categoryNames := categoryPromptNames(categories)
+	incomeCategoryNames := make([]string, len(incomeCategories))
+	for i, cat := range incomeCategories {
+		incomeCategoryNames[i] = cat.Title
 	}
 
+	// Hashtags are kept apart from description and category, LLM gets text without them
+	text, tags := saldo.ParseTags(text)
+
 	// Parse expense using LLM with timing
 	startTime := time.Now()
-	expenses, err := b.llm.ParseExpenses(ctx, text, categoryNames)
+	expenses, err := b.llm.ParseExpenses(ctx, text, i18n.FromContext(ctx), userNow(ctx), userDefaultCurrency(user), categoryNames, incomeCategoryNames)
 	llmParseDuration.Observe(time.Since(startTime).Seconds())
 
 	if err != nil {
@@ -257,8 +368,8 @@ func (b *Bot) handleExpenseTextInput(ctx context.Context, botAPI *bot.Bot, chatI
 		b.logger.Error(ctx, "failed to parse expense", "err", err)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Ошибка обработки текста.",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        tr(ctx, "expense.parse_error"),
+			ReplyMarkup: expenseReplyKeyboard(ctx, chatID),
 		})
 		return
 	}
@@ -266,14 +377,24 @@ func (b *Bot) handleExpenseTextInput(ctx context.Context, botAPI *bot.Bot, chatI
 	if len(expenses) == 0 {
 		errorsTotal.WithLabelValues("llm_parse_failed").Inc()
 		b.logger.Print(ctx, "пользователь ввёл сообщение без расходов", "err", err)
+		// Group members talk about money too, bot stays silent there
+		if isGroupChat(chatID) {
+			return
+		}
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Не получилось получить расходы.",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        tr(ctx, "expense.not_found"),
+			ReplyMarkup: expenseReplyKeyboard(ctx, chatID),
 		})
 		return
 	}
 
+	for i := range expenses {
+		if !expenses[i].IsIncome() {
+			expenses[i].Tags = tags
+		}
+	}
+
 	// Show confirmation
 	b.showExpenseConfirmation(ctx, botAPI, chatID, userID, expenses)
 }
@@ -281,63 +402,101 @@ func (b *Bot) handleExpenseTextInput(ctx context.Context, botAPI *bot.Bot, chatI
 // showExpenseConfirmation shows expense details for confirmation
 func (b *Bot) showExpenseConfirmation(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, expenses []services.ParsedExpense) {
 	// Save to state for confirmation
-	stateData := b.stateManager.GetState(userID)
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
 	stateData.ExpensesData = make([]ExpenseData, len(expenses))
-
 	for i, exp := range expenses {
 		stateData.ExpensesData[i] = ExpenseData{
-			Amount:      int64(exp.Amount * 100),
+			Amount:      int64(math.Round(exp.Amount * 100)),
 			Currency:    exp.Currency,
 			Category:    exp.Category,
 			Description: exp.Description,
+			Income:      exp.IsIncome(),
+			Date:        exp.Date,
+			Tags:        exp.Tags,
 		}
 	}
-	b.stateManager.SetStateData(userID, stateData)
-
-	text := "✅ <b>Подтвердите расходы:</b>\n\n" + services.FormatExpenseDetails(expenses)
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        text,
+		Text:        pendingConfirmationText(ctx, stateData.ExpensesData),
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: expenseConfirmKeyboard(),
+		ReplyMarkup: expenseConfirmKeyboard(ctx, stateData.ExpensesData),
 	})
 }
 
-// createExpense creates expenses in database
-func (b *Bot) createExpenses(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, expenses []ExpenseData) {
+// createExpenses creates expenses and incomes in database and returns saved ones
+func (b *Bot) createExpenses(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, expenses []ExpenseData) ([]Expense, []Income) {
 	// Get existing categories to track new ones
-	existingCategories, _ := b.saldo.GetUserCategories(ctx, user.ID)
+	existingCategories, _ := b.saldo.GetCategories(ctx, user.LedgerID)
 	existingCategoryMap := make(map[string]bool)
 	for _, cat := range existingCategories {
 		existingCategoryMap[cat.Title] = true
 	}
+	existingIncomeCategories, _ := b.saldo.GetIncomeCategories(ctx, user.LedgerID)
+	existingIncomeCategoryMap := make(map[string]bool)
+	for _, cat := range existingIncomeCategories {
+		existingIncomeCategoryMap[cat.Title] = true
+	}
 
 	// Create expense with category
+	created := make([]Expense, 0, len(expenses))
+	var createdIncomes []Income
 	for _, exp := range expenses {
+		if exp.Income {
+			receivedAt := exp.Date
+			if receivedAt.IsZero() {
+				receivedAt = time.Now()
+			}
+
+			income, err := b.saldo.CreateIncomeWithCategory(ctx, user.LedgerID, user.ID, exp.Amount, exp.Currency, exp.Category, exp.Description, receivedAt)
+			if err != nil {
+				errorsTotal.WithLabelValues("database").Inc()
+				b.logger.Error(ctx, "failed to create income", "err", err)
+				_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+					ChatID: chatID,
+					Text:   tr(ctx, "income.save_error"),
+				})
+				return created, createdIncomes
+			}
+
+			incomesCreated.Inc()
+
+			saved := NewIncome(income)
+			if income.CategoryID != nil {
+				saved.Category = &Category{ID: *income.CategoryID, UserID: user.ID, Title: exp.Category}
+			}
+			createdIncomes = append(createdIncomes, *saved)
+
+			if exp.Category != "" && !existingIncomeCategoryMap[exp.Category] {
+				categoriesCreated.Inc()
+				existingIncomeCategoryMap[exp.Category] = true
+			}
+			continue
+		}
+
 		// Track if category is new
 		categoryIsNew := exp.Category != "" && !existingCategoryMap[exp.Category]
 
-		_, err := b.saldo.CreateExpenseWithCategory(
-			ctx,
-			user.ID,
-			exp.Amount,
-			exp.Currency,
-			exp.Category,
-			exp.Description,
-		)
+		expense, err := b.createExpense(ctx, user, exp)
 		if err != nil {
 			errorsTotal.WithLabelValues("database").Inc()
 			b.logger.Error(ctx, "failed to create expense", "err", err)
 			_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "Ошибка сохранения расхода.",
+				Text:   tr(ctx, "edit.save_error"),
 			})
-			return
+			return created, createdIncomes
 		}
 
 		expensesCreated.Inc()
 
+		saved := NewExpense(expense)
+		if expense.CategoryID != nil {
+			saved.Category = &Category{ID: *expense.CategoryID, UserID: user.ID, Title: exp.Category}
+		}
+		created = append(created, *saved)
+
 		// Increment category counter if new category was created
 		if categoryIsNew {
 			categoriesCreated.Inc()
@@ -346,13 +505,29 @@ func (b *Bot) createExpenses(ctx context.Context, botAPI *bot.Bot, chatID int64,
 	}
 
 	// Clear state
-	b.stateManager.ClearState(userID)
+	b.stateManager.ClearState(ctx, userID, chatID)
 
+	text := tr(ctx, "expense.added")
+	if len(createdIncomes) > 0 {
+		text = tr(ctx, "expense.operations_added")
+	}
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        "✅ Расходы добавлены!\n\n💰",
-		ReplyMarkup: mainMenuKeyboard(),
+		Text:        text,
+		ReplyMarkup: mainMenuKeyboard(ctx),
 	})
+
+	return created, createdIncomes
+}
+
+// createExpense creates expense with category, expenses with known date like receipts or mentioned in text keep their date
+func (b *Bot) createExpense(ctx context.Context, user *User, exp ExpenseData) (*saldo.Expense, error) {
+	spentAt := exp.Date
+	if spentAt.IsZero() {
+		spentAt = time.Now()
+	}
+
+	return b.saldo.CreateExpenseWithCategory(ctx, user.LedgerID, user.ID, exp.Amount, exp.Currency, exp.Category, exp.Description, exp.Tags, spentAt)
 }
 
 // Download Telegram file by file ID
@@ -414,8 +589,8 @@ func (b *Bot) handleVoice(ctx context.Context, botAPI *bot.Bot, update *models.U
 		b.logger.Error(ctx, "failed to download voice file", "err", err)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Ошибка получения голосового сообщения.",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        tr(ctx, "voice.download_error"),
+			ReplyMarkup: expenseReplyKeyboard(ctx, chatID),
 		})
 		return
 	}
@@ -423,7 +598,7 @@ func (b *Bot) handleVoice(ctx context.Context, botAPI *bot.Bot, update *models.U
 
 	// Transcribe voice message with timing
 	startTime := time.Now()
-	transcription, err := b.transcriber.Transcribe(ctx, tmpOgg)
+	transcription, err := b.transcriber.Transcribe(ctx, tmpOgg, i18n.FromContext(ctx))
 	transcriptionDuration.Observe(time.Since(startTime).Seconds())
 
 	b.logger.Print(ctx, "transcription result", "text", transcription)
@@ -432,8 +607,8 @@ func (b *Bot) handleVoice(ctx context.Context, botAPI *bot.Bot, update *models.U
 		b.logger.Error(ctx, "failed to transcribe voice", "err", err)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Ошибка распознавания голоса.",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        tr(ctx, "voice.transcribe_error"),
+			ReplyMarkup: expenseReplyKeyboard(ctx, chatID),
 		})
 		return
 	}
@@ -442,41 +617,49 @@ func (b *Bot) handleVoice(ctx context.Context, botAPI *bot.Bot, update *models.U
 	b.handleExpenseTextInput(ctx, botAPI, chatID, userID, user, transcription)
 }
 
-// handleStatistics shows statistics menu
-func (b *Bot) handleStatistics(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, _ *User) {
-	stateData := b.stateManager.GetState(userID)
+// handleStatistics shows statistics menu, statistics of shared ledger may be filtered by member
+func (b *Bot) handleStatistics(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User) {
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
 	stateData.State = StateInStatsMenu
-	b.stateManager.SetStateData(userID, stateData)
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        "📊 <b>Выберите тип статистики:</b>",
+		Text:        tr(ctx, "stats.choose_type"),
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: statisticsMenuKeyboard(),
+		ReplyMarkup: statisticsMenuKeyboard(ctx),
 	})
+
+	if user != nil && user.SharedLedger {
+		b.sendMemberFilter(ctx, botAPI, chatID, user, stateData.MemberID)
+	}
 }
 
 // handleStatsTypeSelection handles statistics type selection from reply keyboard
 func (b *Bot) handleStatsTypeSelection(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, statsType StatsType) {
-	stateData := b.stateManager.GetState(userID)
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
 	stateData.State = StateInPeriodSelection
 	stateData.StatsType = statsType
-	b.stateManager.SetStateData(userID, stateData)
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
 
 	var text string
-	includeAllTime := false
-	if statsType == StatsByCategories {
-		text = "📊 <b>Статистика по категориям</b>\n\nВыберите период:"
-		includeAllTime = true
-	} else {
-		text = "💸 <b>Статистика по тратам</b>\n\nВыберите период:"
+	includeAllTime := statsType != StatsByExpenses
+	switch statsType {
+	case StatsByCategories:
+		text = tr(ctx, "stats.categories_period")
+	case StatsExport:
+		text = tr(ctx, "stats.export_period")
+	case StatsCharts:
+		text = tr(ctx, "stats.charts_period")
+	default:
+		text = tr(ctx, "stats.expenses_period")
 	}
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		Text:        text,
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: periodSelectionKeyboard(includeAllTime),
+		ReplyMarkup: periodSelectionKeyboard(ctx, includeAllTime),
 	})
 }
 
@@ -485,20 +668,21 @@ func (b *Bot) handlePeriodSelection(ctx context.Context, botAPI *bot.Bot, chatID
 	// If user was in custom period input state, return to period selection
 	if stateData.State == StateAwaitingCustomPeriod {
 		stateData.State = StateInPeriodSelection
-		b.stateManager.SetStateData(userID, stateData)
+		b.stateManager.SetStateData(ctx, userID, chatID, stateData)
 	}
 
 	// Get period
 	var period TimePeriod
+	now := userNow(ctx)
 	switch periodType {
 	case "today":
-		period = GetTodayPeriod()
+		period = GetTodayPeriod(now)
 	case "week":
-		period = GetWeekPeriod()
+		period = GetWeekPeriod(now, user.WeekStart)
 	case "month":
-		period = GetMonthPeriod()
+		period = GetMonthPeriod(now)
 	case "alltime":
-		period = GetAllTimePeriod()
+		period = GetAllTimePeriod(now)
 	default:
 		return
 	}
@@ -511,6 +695,11 @@ func (b *Bot) handlePeriodSelection(ctx context.Context, botAPI *bot.Bot, chatID
 		b.handleStatisticsByCategories(ctx, botAPI, chatID, userID, user, period)
 	case StatsByExpenses:
 		b.handleStatisticsByExpenses(ctx, botAPI, chatID, userID, user, period)
+	case StatsExport:
+		b.handleExportPeriod(ctx, botAPI, chatID, period)
+	case StatsCharts:
+		b.handleStatisticsByCategories(ctx, botAPI, chatID, userID, user, period)
+		b.handleStatisticsCharts(ctx, botAPI, chatID, userID, user, period)
 	default:
 		return
 	}
@@ -519,16 +708,11 @@ func (b *Bot) handlePeriodSelection(ctx context.Context, botAPI *bot.Bot, chatID
 // handleCustomPeriodStart starts custom period input
 func (b *Bot) handleCustomPeriodStart(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, stateData *UserStateData) {
 	stateData.State = StateAwaitingCustomPeriod
-	b.stateManager.SetStateData(userID, stateData)
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: chatID,
-		Text: "📅 <b>Введите кастомный период</b>\n\n" +
-			"Форматы:\n" +
-			"• <code>03.04.25 07.04.25</code>\n" +
-			"• <code>03.04.25 - 07.04.25</code>\n" +
-			"• <code>03.04 07.04</code> (текущий год)\n" +
-			"• <code>03.04 - 07.04</code> (текущий год)",
+		ChatID:    chatID,
+		Text:      tr(ctx, "period.enter"),
 		ParseMode: models.ParseModeHTML,
 	})
 }
@@ -536,111 +720,101 @@ func (b *Bot) handleCustomPeriodStart(ctx context.Context, botAPI *bot.Bot, chat
 // handleBack handles back button navigation
 func (b *Bot) handleBack(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, stateData *UserStateData) {
 	switch stateData.State {
-	case StateInPeriodSelection, StateAwaitingCustomPeriod: // Go back to stats menu
+	case StateInPeriodSelection, StateAwaitingCustomPeriod, StateAwaitingBudget: // Go back to stats menu
 		b.handleStatistics(ctx, botAPI, chatID, userID, nil)
 	default:
-		b.stateManager.ClearState(userID)
+		b.stateManager.ClearState(ctx, userID, chatID)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Главное меню:",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        tr(ctx, "main_menu"),
+			ReplyMarkup: mainMenuKeyboard(ctx),
 		})
 	}
 }
 
 // handleStatisticsByCategories handles statistics by categories request with period
 func (b *Bot) handleStatisticsByCategories(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, period TimePeriod) {
-	// Get all expenses for user
-	expenses, err := b.saldo.GetUserExpenses(ctx, user.ID)
+	// Statistics of shared ledger may be shown for one member
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+
+	// Expenses are summed by category, currency and day in database
+	saldoTotals, err := b.saldo.GetExpenseTotals(ctx, user.LedgerID, stateData.MemberID, period.Start, period.End, userLocation(ctx))
 	if err != nil {
 		errorsTotal.WithLabelValues("database").Inc()
-		b.logger.Error(ctx, "failed to get expenses", "err", err)
+		b.logger.Error(ctx, "failed to get expense totals", "err", err)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "Ошибка получения расходов.",
+			Text:   tr(ctx, "expenses.load_error"),
 		})
 		return
 	}
+	totals := NewExpenseTotals(saldoTotals)
 
-	// Convert to telegram expenses
-	allExpenses := NewExpenses(expenses)
+	incomes, err := b.getIncomesForPeriod(ctx, user.LedgerID, stateData.MemberID, period)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get incomes", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "income.load_error"),
+		})
+		return
+	}
 
-	// Filter expenses by period
-	var tgExpenses []Expense
-	for _, exp := range allExpenses {
-		if exp.CreatedAt.After(period.Start) && exp.CreatedAt.Before(period.End) {
-			tgExpenses = append(tgExpenses, exp)
-		}
+	// Sub-categories are shown inside their parent categories
+	categories, err := b.saldo.GetCategories(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "categories.load_error"),
+		})
+		return
 	}
 
 	// Group expenses by category and currency
-	categoryMap, currencyFrequency := groupExpensesByCategory(tgExpenses)
+	entries := totalEntries(totals)
+	categoryMap, _ := groupExpensesByCategory(ctx, entries, NewCategories(categories))
+	currencyFrequency := currencyFrequencyOfTotals(totals)
 
 	// Sort currencies by frequency (most frequent first)
 	currencyOrder := sortCurrenciesByFrequency(currencyFrequency)
 
 	// Get current keyboard based on state - don't change the state
-	replyMarkup := b.stateManager.GetCurrentKeyboard(userID)
+	replyMarkup := b.stateManager.GetCurrentKeyboard(ctx, userID, chatID)
 
 	// Format statistics message
-	if len(categoryMap) == 0 {
+	if len(categoryMap) == 0 && len(incomes) == 0 {
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "📊 <b>Статистика</b>\n\n<i>Пока нет расходов.</i>",
+			Text:        tr(ctx, "stats.empty"),
 			ParseMode:   models.ParseModeHTML,
 			ReplyMarkup: replyMarkup,
 		})
 		return
 	}
 
-	// Sort categories by total amount (with currency rate approximation)
-	type categoryWithTotal struct {
-		stats *CategoryStats
-		total int64
-	}
-	categoriesWithTotal := make([]categoryWithTotal, 0, len(categoryMap))
-	for _, stats := range categoryMap {
-		total := calculateCategoryTotal(stats.Amounts)
-		categoriesWithTotal = append(categoriesWithTotal, categoryWithTotal{stats, total})
-	}
-	sort.Slice(categoriesWithTotal, func(i, j int) bool {
-		return categoriesWithTotal[i].total > categoriesWithTotal[j].total
-	})
-
-	// Calculate and format total expenses
-	totalExpenses := calculateTotalExpenses(tgExpenses)
-	totalFormatted := formatTotalExpenses(totalExpenses)
-
-	text := "📊 <b>Статистика по категориям:</b>\n"
-	text += fmt.Sprintf("<i>%s</i>\n\n", FormatPeriod(period))
-	text += fmt.Sprintf("💰 <b>Всего:</b> %s\n\n", totalFormatted)
+	text := tr(ctx, "stats.by_categories") + "\n"
+	text += formatStatsPeriod(ctx, period, stateData) + "\n"
+	text += b.formatStatsSummary(ctx, user, entries, incomes)
+	text += "\n"
 
 	// Format each category (sorted by total)
-	for _, cat := range categoriesWithTotal {
-		stats := cat.stats
-		text += fmt.Sprintf("%s <b>%s:</b> ", stats.Emoji, stats.Title)
-
-		// Format amounts by currency in order of frequency
-		first := true
-		for _, currency := range currencyOrder {
-			// Only show currencies that exist in this category
-			amountCents, exists := stats.Amounts[currency]
-			if !exists {
-				continue
-			}
+	rates := b.currentRates(ctx)
+	for _, stats := range sortCategoriesByTotal(categoryMap, rates) {
+		text += formatCategoryTree(stats, currencyOrder, rates)
+	}
 
-			if !first {
-				text += "/"
-			}
-			first = false
+	// Format income categories after expense ones
+	if len(incomes) > 0 {
+		incomeCategoryMap := groupIncomesByCategory(ctx, incomes)
+		incomeCurrencyOrder := sortCurrenciesByFrequency(currencyFrequencyOf(incomes))
 
-			// Format amount and get currency symbol
-			amountStr := formatAmount(amountCents)
-			currencySymbol := getCurrencySymbol(currency)
-			text += fmt.Sprintf("%s %s", amountStr, currencySymbol)
+		text += "\n" + tr(ctx, "stats.income_by_categories") + "\n"
+		for _, stats := range sortCategoriesByTotal(incomeCategoryMap, rates) {
+			text += formatCategoryStats(stats, incomeCurrencyOrder)
 		}
-
-		text += "\n"
 	}
 
 	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
@@ -687,40 +861,39 @@ func getCurrencyWithFlag(currency string) string {
 	return curr
 }
 
-// getCurrencyRate returns approximate rate for currency sorting (higher = more valuable)
-// This is only used for sorting order, not for converting values
-func getCurrencyRate(currency string) float64 {
-	rates := map[string]float64{
-		"USD": 100.0,
-		"EUR": 100.0,
-		"GBP": 100.0,
-		"CHF": 100.0,
-		"GEL": 30.0,
-		"CNY": 10.0,
-		"RUB": 1.0,
-		"JPY": 0.5,
-		"KZT": 0.14,
-	}
-
-	curr := strings.ToUpper(currency)
-	if rate, exists := rates[curr]; exists {
-		return rate
-	}
-	return 1.0
-}
-
 // CategoryStats represents statistics for a category
 type CategoryStats struct {
-	Title   string
-	Emoji   string
-	Amounts map[string]int64 // currency -> amount in cents
+	Title    string
+	Emoji    string
+	Amounts  map[string]int64          // currency -> amount in cents, parent category includes its sub-categories
+	Children map[string]*CategoryStats // sub-categories of parent category
 }
 
-// groupExpensesByCategory groups expenses by category and currency
-func groupExpensesByCategory(expenses []Expense) (map[string]*CategoryStats, map[string]int) {
+// otherCategoryKey is key of expenses of parent category that are not in any of its sub-categories
+const otherCategoryKey = "__other__"
+
+// groupExpensesByCategory groups expenses by category and currency.
+// Expenses of sub-categories are summed into their parent found in categories and kept as its children.
+func groupExpensesByCategory(ctx context.Context, expenses []Expense, categories []Category) (map[string]*CategoryStats, map[string]int) {
 	categoryMap := make(map[string]*CategoryStats)
 	currencyFrequency := make(map[string]int)
 
+	parents := make(map[int]Category, len(categories))
+	for _, cat := range categories {
+		parents[cat.ID] = cat
+	}
+
+	addStats := func(m map[string]*CategoryStats, key, title, emoji string) *CategoryStats {
+		if _, exists := m[key]; !exists {
+			m[key] = &CategoryStats{
+				Title:   title,
+				Emoji:   emoji,
+				Amounts: make(map[string]int64),
+			}
+		}
+		return m[key]
+	}
+
 	for _, exp := range expenses {
 		var categoryKey, categoryTitle, emoji string
 
@@ -730,35 +903,145 @@ func groupExpensesByCategory(expenses []Expense) (map[string]*CategoryStats, map
 			emoji = exp.Category.Emoji
 		} else {
 			categoryKey = "__no_category__"
-			categoryTitle = "Без категории"
+			categoryTitle = tr(ctx, "no_category")
 			emoji = "❓"
 		}
 
-		// Initialize category if not exists
-		if _, exists := categoryMap[categoryKey]; !exists {
-			categoryMap[categoryKey] = &CategoryStats{
-				Title:   categoryTitle,
-				Emoji:   emoji,
-				Amounts: make(map[string]int64),
-			}
-		}
-
 		// Track currency frequency
 		currencyFrequency[exp.Currency]++
 
-		// Add amount to category
-		categoryMap[categoryKey].Amounts[exp.Currency] += exp.Amount
+		var parent Category
+		var hasParent bool
+		if exp.Category != nil && exp.Category.ParentID != nil {
+			parent, hasParent = parents[*exp.Category.ParentID]
+		}
+
+		if !hasParent {
+			addStats(categoryMap, categoryKey, categoryTitle, emoji).Amounts[exp.Currency] += exp.Amount
+			continue
+		}
+
+		// Add amount to parent category and to its sub-category
+		parentStats := addStats(categoryMap, parent.Title, parent.Title, parent.Emoji)
+		parentStats.Amounts[exp.Currency] += exp.Amount
+		if parentStats.Children == nil {
+			parentStats.Children = make(map[string]*CategoryStats)
+		}
+		addStats(parentStats.Children, categoryKey, categoryTitle, emoji).Amounts[exp.Currency] += exp.Amount
+	}
+
+	// Expenses of parent category itself are shown next to its sub-categories
+	for _, stats := range categoryMap {
+		if len(stats.Children) == 0 {
+			continue
+		}
+
+		rest := make(map[string]int64)
+		for currency, amount := range stats.Amounts {
+			rest[currency] = amount
+		}
+		for _, child := range stats.Children {
+			for currency, amount := range child.Amounts {
+				rest[currency] -= amount
+			}
+		}
+		for currency, amount := range rest {
+			if amount != 0 {
+				addStats(stats.Children, otherCategoryKey, tr(ctx, "stats.category_other"), "▫️").Amounts[currency] = amount
+			}
+		}
 	}
 
 	return categoryMap, currencyFrequency
 }
 
-// calculateCategoryTotal calculates total for a category using currency rates
-func calculateCategoryTotal(amounts map[string]int64) int64 {
+// totalEntries converts expense totals to expense entries for grouping and currency conversion, entry is dated by its day
+func totalEntries(totals []ExpenseTotal) []Expense {
+	entries := make([]Expense, len(totals))
+	for i, total := range totals {
+		entries[i] = Expense{Amount: total.Amount, Currency: total.Currency, SpentAt: total.Day, Category: total.Category}
+	}
+	return entries
+}
+
+// currencyFrequencyOfTotals counts expenses of each currency
+func currencyFrequencyOfTotals(totals []ExpenseTotal) map[string]int {
+	frequency := make(map[string]int)
+	for _, total := range totals {
+		frequency[total.Currency] += total.Count
+	}
+	return frequency
+}
+
+// sortCategoriesByTotal sorts categories by total amount, amounts in different currencies are compared by rates
+func sortCategoriesByTotal(categoryMap map[string]*CategoryStats, rates saldo.Rates) []*CategoryStats {
+	type categoryWithTotal struct {
+		stats *CategoryStats
+		total int64
+	}
+	categoriesWithTotal := make([]categoryWithTotal, 0, len(categoryMap))
+	for _, stats := range categoryMap {
+		total := calculateCategoryTotal(stats.Amounts, rates)
+		categoriesWithTotal = append(categoriesWithTotal, categoryWithTotal{stats, total})
+	}
+	sort.Slice(categoriesWithTotal, func(i, j int) bool {
+		return categoriesWithTotal[i].total > categoriesWithTotal[j].total
+	})
+
+	result := make([]*CategoryStats, len(categoriesWithTotal))
+	for i, cat := range categoriesWithTotal {
+		result[i] = cat.stats
+	}
+	return result
+}
+
+// formatCategoryStats formats category line with amounts by currency in given order
+func formatCategoryStats(stats *CategoryStats, currencyOrder []string) string {
+	text := fmt.Sprintf("%s <b>%s:</b> ", stats.Emoji, html.EscapeString(stats.Title))
+
+	// Format amounts by currency in order of frequency
+	first := true
+	for _, currency := range currencyOrder {
+		// Only show currencies that exist in this category
+		amountCents, exists := stats.Amounts[currency]
+		if !exists {
+			continue
+		}
+
+		if !first {
+			text += "/"
+		}
+		first = false
+
+		// Format amount and get currency symbol
+		amountStr := formatAmount(amountCents)
+		currencySymbol := getCurrencySymbol(currency)
+		text += fmt.Sprintf("%s %s", amountStr, currencySymbol)
+	}
+
+	return text + "\n"
+}
+
+// formatCategoryTree formats category line followed by collapsible lines of its sub-categories
+func formatCategoryTree(stats *CategoryStats, currencyOrder []string, rates saldo.Rates) string {
+	text := formatCategoryStats(stats, currencyOrder)
+	if len(stats.Children) == 0 {
+		return text
+	}
+
+	var children string
+	for _, child := range sortCategoriesByTotal(stats.Children, rates) {
+		children += formatCategoryStats(child, currencyOrder)
+	}
+
+	return text + "<blockquote expandable>" + strings.TrimSuffix(children, "\n") + "</blockquote>\n"
+}
+
+// calculateCategoryTotal calculates total for a category in rubles using currency rates
+func calculateCategoryTotal(amounts map[string]int64, rates saldo.Rates) int64 {
 	total := int64(0)
 	for currency, amountCents := range amounts {
-		rate := getCurrencyRate(currency)
-		total += int64(float64(amountCents) * rate)
+		total += valueInRubles(rates, amountCents, currency)
 	}
 	return total
 }
@@ -797,7 +1080,7 @@ func calculateTotalExpenses(expenses []Expense) map[string]int64 {
 }
 
 // formatTotalExpenses formats total expenses with currencies sorted by rate (highest first)
-func formatTotalExpenses(totals map[string]int64) string {
+func formatTotalExpenses(totals map[string]int64, rates saldo.Rates) string {
 	if len(totals) == 0 {
 		return ""
 	}
@@ -805,14 +1088,14 @@ func formatTotalExpenses(totals map[string]int64) string {
 	// Sort currencies by rate (highest first) - only for display order
 	type currencyWithRate struct {
 		currency string
-		rate     float64
+		rate     int64 // value of one unit in kopecks
 		amount   int64
 	}
 
 	currencies := make([]currencyWithRate, 0, len(totals))
 	for currency, amount := range totals {
 		if amount > 0 { // Skip zero amounts
-			currencies = append(currencies, currencyWithRate{currency, getCurrencyRate(currency), amount})
+			currencies = append(currencies, currencyWithRate{currency, valueInRubles(rates, 100, currency), amount})
 		}
 	}
 
@@ -836,59 +1119,59 @@ func formatTotalExpenses(totals map[string]int64) string {
 
 // handleStatisticsByExpenses handles statistics by individual expenses with period
 func (b *Bot) handleStatisticsByExpenses(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, period TimePeriod) {
-	// Get all expenses for user
-	expenses, err := b.saldo.GetUserExpenses(ctx, user.ID)
+	// Statistics of shared ledger may be shown for one member
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+
+	expenses, err := b.saldo.GetExpensesForPeriod(ctx, user.LedgerID, stateData.MemberID, period.Start, period.End)
 	if err != nil {
 		errorsTotal.WithLabelValues("database").Inc()
 		b.logger.Error(ctx, "failed to get expenses", "err", err)
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "Ошибка получения расходов.",
+			Text:   tr(ctx, "expenses.load_error"),
 		})
 		return
 	}
+	tgExpenses := NewExpenses(expenses)
 
-	// Convert to telegram expenses
-	allExpenses := NewExpenses(expenses)
-
-	// Filter expenses by period
-	var tgExpenses []Expense
-	for _, exp := range allExpenses {
-		if exp.CreatedAt.After(period.Start) && exp.CreatedAt.Before(period.End) {
-			tgExpenses = append(tgExpenses, exp)
-		}
+	incomes, err := b.getIncomesForPeriod(ctx, user.LedgerID, stateData.MemberID, period)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get incomes", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "income.load_error"),
+		})
+		return
 	}
 
 	// Get current keyboard based on state - don't change the state
-	replyMarkup := b.stateManager.GetCurrentKeyboard(userID)
+	replyMarkup := b.stateManager.GetCurrentKeyboard(ctx, userID, chatID)
 
-	if len(tgExpenses) == 0 {
+	if len(tgExpenses) == 0 && len(incomes) == 0 {
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        fmt.Sprintf("📊 <b>Статистика по тратам:</b>\n<i>%s</i>\n\n<i>Нет расходов за этот период.</i>", FormatPeriod(period)),
+			Text:        tr(ctx, "stats.by_expenses") + "\n" + formatStatsPeriod(ctx, period, stateData) + "\n" + tr(ctx, "stats.no_expenses"),
 			ParseMode:   models.ParseModeHTML,
 			ReplyMarkup: replyMarkup,
 		})
 		return
 	}
 
-	// Calculate and format total expenses
-	totalExpenses := calculateTotalExpenses(tgExpenses)
-	totalFormatted := formatTotalExpenses(totalExpenses)
-
-	text := "📊 <b>Статистика по тратам:</b>\n"
-	text += fmt.Sprintf("<i>%s</i>\n\n", FormatPeriod(period))
-	text += fmt.Sprintf("💰 <b>Всего:</b> %s\n\n", totalFormatted)
+	text := tr(ctx, "stats.by_expenses") + "\n"
+	text += formatStatsPeriod(ctx, period, stateData) + "\n"
+	text += b.formatStatsSummary(ctx, user, tgExpenses, incomes)
+	text += "\n"
 
 	// Sort by date (newest first)
 	sort.Slice(tgExpenses, func(i, j int) bool {
-		return tgExpenses[i].CreatedAt.After(tgExpenses[j].CreatedAt)
+		return tgExpenses[i].SpentAt.After(tgExpenses[j].SpentAt)
 	})
 
 	// Format each expense
 	for _, exp := range tgExpenses {
 		// Format: Description(Category): Amount (Date) or Category: Amount (Date) if no description
-		categoryName := "Без категории"
+		categoryName := tr(ctx, "no_category")
 		emoji := "❓"
 		if exp.Category != nil {
 			categoryName = exp.Category.Title
@@ -897,7 +1180,12 @@ func (b *Bot) handleStatisticsByExpenses(ctx context.Context, botAPI *bot.Bot, c
 
 		amountStr := formatAmount(exp.Amount)
 		currencySymbol := getCurrencySymbol(exp.Currency)
-		dateStr := FormatDate(exp.CreatedAt)
+		dateStr := FormatDate(ctx, exp.SpentAt)
+
+		// members of shared ledger see who spent money
+		if user.SharedLedger && exp.Author != "" {
+			dateStr += ", " + html.EscapeString(exp.Author)
+		}
 
 		if exp.Description != "" {
 			// Capitalize first letter of description
@@ -908,10 +1196,27 @@ func (b *Bot) handleStatisticsByExpenses(ctx context.Context, botAPI *bot.Bot, c
 				description = string(runes)
 			}
 			text += fmt.Sprintf("<b>%s</b> (%s%s): %s %s (%s)\n",
-				description, emoji, categoryName, amountStr, currencySymbol, dateStr)
+				html.EscapeString(description), emoji, html.EscapeString(categoryName), amountStr, currencySymbol, dateStr)
 		} else {
 			text += fmt.Sprintf("<b>%s%s</b>: %s %s (%s)\n",
-				emoji, categoryName, amountStr, currencySymbol, dateStr)
+				emoji, html.EscapeString(categoryName), amountStr, currencySymbol, dateStr)
+		}
+	}
+
+	// Format incomes after expenses
+	if len(incomes) > 0 {
+		sort.Slice(incomes, func(i, j int) bool {
+			return incomes[i].ReceivedAt.After(incomes[j].ReceivedAt)
+		})
+
+		text += "\n" + tr(ctx, "stats.incomes") + "\n"
+		for _, inc := range incomes {
+			categoryName := tr(ctx, "no_category")
+			if inc.Category != nil {
+				categoryName = strings.TrimSpace(inc.Category.Emoji + inc.Category.Title)
+			}
+			text += fmt.Sprintf("<b>%s</b>: +%s %s (%s)\n",
+				html.EscapeString(categoryName), formatAmount(inc.Amount), getCurrencySymbol(inc.Currency), FormatDate(ctx, inc.ReceivedAt))
 		}
 	}
 
@@ -921,47 +1226,67 @@ func (b *Bot) handleStatisticsByExpenses(ctx context.Context, botAPI *bot.Bot, c
 		ParseMode:   models.ParseModeHTML,
 		ReplyMarkup: replyMarkup,
 	})
+
+	if len(tgExpenses) == 0 {
+		return
+	}
+
+	// Offer editing of listed expenses
+	if len(tgExpenses) > maxEditButtons {
+		tgExpenses = tgExpenses[:maxEditButtons]
+	}
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "stats.choose_to_edit"),
+		ReplyMarkup: savedExpensesKeyboard(ctx, tgExpenses),
+	})
 }
 
 // handleCustomPeriodInput handles custom period input from user
 func (b *Bot) handleCustomPeriodInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, text string) {
 	// Parse custom period
-	period, err := ParseCustomPeriod(text)
+	period, err := ParseCustomPeriod(text, userNow(ctx))
 	if err != nil {
 		// Keep period selection menu on error
-		stateData := b.stateManager.GetState(userID)
-		includeAllTime := stateData.StatsType == StatsByCategories
+		stateData := b.stateManager.GetState(ctx, userID, chatID)
+		includeAllTime := stateData.StatsType != StatsByExpenses
 
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        fmt.Sprintf("❌ Ошибка: %v\n\nПожалуйста, введите период в формате:\n• ДД.ММ.ГГ ДД.ММ.ГГ\n• ДД.ММ - ДД.ММ (текущий год)", err),
-			ReplyMarkup: periodSelectionKeyboard(includeAllTime),
+			Text:        tr(ctx, "period.retry", trErr(ctx, err)),
+			ReplyMarkup: periodSelectionKeyboard(ctx, includeAllTime),
 		})
 		return
 	}
 
 	// Get state to know which stats type was requested
-	stateData := b.stateManager.GetState(userID)
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
 	statsType := stateData.StatsType
 
 	// For expenses, check max period is 1 month
 	if statsType == StatsByExpenses && period.DaysBetween() > 31 {
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "❌ Для статистики по тратам период не может быть больше месяца (31 день).",
-			ReplyMarkup: periodSelectionKeyboard(false), // expenses don't have all-time
+			Text:        tr(ctx, "period.too_long"),
+			ReplyMarkup: periodSelectionKeyboard(ctx, false), // expenses don't have all-time
 		})
 		return
 	}
 
 	// Return to period selection state after showing results
 	stateData.State = StateInPeriodSelection
-	b.stateManager.SetStateData(userID, stateData)
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
 
 	// Show statistics
-	if statsType == StatsByCategories {
+	switch statsType {
+	case StatsByCategories:
 		b.handleStatisticsByCategories(ctx, botAPI, chatID, userID, user, period)
-	} else {
+	case StatsExport:
+		b.handleExportPeriod(ctx, botAPI, chatID, period)
+	case StatsCharts:
+		b.handleStatisticsByCategories(ctx, botAPI, chatID, userID, user, period)
+		b.handleStatisticsCharts(ctx, botAPI, chatID, userID, user, period)
+	default:
 		b.handleStatisticsByExpenses(ctx, botAPI, chatID, userID, user, period)
 	}
 }
@@ -988,12 +1313,12 @@ func (b *Bot) handleCallback(ctx context.Context, botAPI *bot.Bot, update *model
 	b.logger.Print(ctx, "callback received", "data", data, "from", callback.From.Username)
 
 	// Get user from DB
-	user, err := b.getUserByTelegramID(ctx, userID)
+	user, err := b.getChatUser(ctx, callback.Message.Message.Chat, &callback.From)
 	if err != nil || user == nil {
 		errorsTotal.WithLabelValues("user_not_found").Inc()
 		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            "Ошибка: пользователь не найден",
+			Text:            tr(ctx, "user_not_found"),
 			ShowAlert:       true,
 		})
 		return
@@ -1011,48 +1336,103 @@ func (b *Bot) handleCallback(ctx context.Context, botAPI *bot.Bot, update *model
 	switch action {
 	case "expense":
 		b.handleExpenseAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "edit":
+		b.handleEditAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "trash":
+		b.handleTrashAction(ctx, botAPI, callback, chatID, user, value)
+	case "income":
+		b.handleIncomeAction(ctx, botAPI, callback, chatID, user, value)
+	case "budget":
+		b.handleBudgetAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "recurring":
+		b.handleRecurringAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "cat":
+		b.handleCategoryAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "currency":
+		b.handleCurrencyAction(ctx, botAPI, callback, chatID, user, value)
+	case "language":
+		b.handleLanguageAction(ctx, botAPI, callback, chatID, user, value)
+	case "settings":
+		b.handleSettingsAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "ledger":
+		b.handleLedgerAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "member":
+		b.handleMemberAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "export":
+		b.handleExportAction(ctx, botAPI, callback, chatID, user, value)
+	case "import":
+		b.handleImportAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "tag":
+		b.handleTagAction(ctx, botAPI, callback, chatID, user, value)
+	case "find":
+		b.handleFindAction(ctx, botAPI, callback, chatID, userID, user, value)
+	case "compare":
+		b.handleCompareAction(ctx, botAPI, callback, chatID, userID, user, value)
 	default:
 		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            "Неизвестное действие",
+			Text:            tr(ctx, "unknown_action"),
 		})
 	}
 }
 
-// handleExpenseAction handles expense confirmation/cancellation
+// handleExpenseAction handles expense confirmation/cancellation and changes of pending items
 func (b *Bot) handleExpenseAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, action string) {
 	if action == "cancel" {
 		callbacksProcessed.WithLabelValues("cancel").Inc()
-		b.stateManager.ClearState(userID)
+		b.stateManager.ClearState(ctx, userID, chatID)
 		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
 		})
 
 		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Отменено.",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        tr(ctx, "cancelled"),
+			ReplyMarkup: mainMenuKeyboard(ctx),
 		})
 		return
 	}
 
 	if action == "confirm" {
 		callbacksProcessed.WithLabelValues("confirm").Inc()
-		stateData := b.stateManager.GetState(userID)
+		stateData := b.stateManager.GetState(ctx, userID, chatID)
 		if stateData.ExpensesData == nil {
-			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: callback.ID,
-				Text:            "Ошибка: нет данных расхода",
-				ShowAlert:       true,
-			})
+			b.expirePendingConfirmation(ctx, botAPI, callback, chatID)
 			return
 		}
 
-		b.createExpenses(ctx, botAPI, chatID, userID, user, stateData.ExpensesData)
+		created, createdIncomes := b.createExpenses(ctx, botAPI, chatID, userID, user, stateData.ExpensesData)
 
 		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            "Расход сохранен!",
+			Text:            tr(ctx, "expense.saved"),
 		})
+
+		// Replace confirmation with saved expenses that can be edited
+		if len(created) > 0 || len(createdIncomes) > 0 {
+			lines := make([]string, 0, 2)
+			if len(created) > 0 {
+				lines = append(lines, formatSavedExpenses(ctx, created))
+			}
+			if len(createdIncomes) > 0 {
+				lines = append(lines, formatSavedIncomes(ctx, createdIncomes))
+			}
+			if warnings := b.unusualExpenseLines(ctx, user, created); len(warnings) > 0 {
+				lines = append(lines, "\n"+strings.Join(warnings, "\n"))
+			}
+			_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+				ChatID:      chatID,
+				MessageID:   callback.Message.Message.ID,
+				Text:        tr(ctx, "expense.saved_list") + "\n\n" + strings.Join(lines, "\n"),
+				ParseMode:   models.ParseModeHTML,
+				ReplyMarkup: savedEntriesKeyboard(ctx, created, createdIncomes),
+			})
+		}
+
+		b.notifyBudgets(ctx, botAPI, chatID, user, created)
+		b.notifyDailySpike(ctx, botAPI, chatID, user, created)
+		return
 	}
+
+	b.handlePendingItemAction(ctx, botAPI, callback, chatID, userID, user, action)
 }
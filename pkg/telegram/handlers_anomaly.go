@@ -0,0 +1,156 @@
+package telegram
+
+import (
+	"context"
+	"html"
+	"slices"
+	"time"
+
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// anomalyFactor is how many times expense or daily total must exceed its median to be unusual
+	anomalyFactor = 3
+
+	// anomalyMinDays is min number of days with expenses in history to tell typical daily total
+	anomalyMinDays = 14
+)
+
+// unusualExpenseLines returns warnings about just saved expenses that are much larger than usual in their category.
+// Typical amount is median of category's expenses in the same currency for last saldo.AnomalyHistoryDays.
+func (b *Bot) unusualExpenseLines(ctx context.Context, user *User, expenses []Expense) []string {
+	var categoryIDs []int
+	var createdBefore time.Time
+	for _, exp := range expenses {
+		if exp.CategoryID != nil && !slices.Contains(categoryIDs, *exp.CategoryID) {
+			categoryIDs = append(categoryIDs, *exp.CategoryID)
+		}
+		if createdBefore.IsZero() || exp.CreatedAt.Before(createdBefore) {
+			createdBefore = exp.CreatedAt
+		}
+	}
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	medians, err := b.saldo.GetExpenseMedians(ctx, user.LedgerID, categoryIDs, userNow(ctx), createdBefore)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get expense medians", "err", err, "ledger_id", user.LedgerID)
+		return nil
+	}
+
+	type medianKey struct {
+		categoryID int
+		currency   string
+	}
+	typical := make(map[medianKey]int64, len(medians))
+	for _, m := range medians {
+		typical[medianKey{m.CategoryID, m.Currency}] = m.Median
+	}
+
+	var lines []string
+	for _, exp := range expenses {
+		if exp.CategoryID == nil || exp.Category == nil {
+			continue
+		}
+		median, ok := typical[medianKey{*exp.CategoryID, exp.Currency}]
+		if !ok || exp.Amount <= median*anomalyFactor {
+			continue
+		}
+
+		anomalyAlerts.WithLabelValues("expense").Inc()
+		symbol := getCurrencySymbol(exp.Currency)
+		lines = append(lines, tr(ctx, "anomaly.expense",
+			formatAmount(exp.Amount)+" "+symbol, html.EscapeString(categoryTitle(*exp.Category)),
+			formatAmount(median)+" "+symbol, exp.Amount/median))
+	}
+
+	return lines
+}
+
+// notifyDailySpike warns when just saved expenses make today's total of ledger much larger than on usual day.
+// Typical daily total is median of days with expenses for last saldo.AnomalyHistoryDays in base currency.
+// Alert is sent once a day: only when today's total crosses the threshold.
+func (b *Bot) notifyDailySpike(ctx context.Context, botAPI *bot.Bot, chatID int64, user *User, expenses []Expense) {
+	today := GetTodayPeriod(userNow(ctx))
+
+	var added []ExpenseTotal
+	for _, exp := range expenses {
+		if !exp.SpentAt.Before(today.Start) && !exp.SpentAt.After(today.End) {
+			added = append(added, ExpenseTotal{Currency: exp.Currency, Day: exp.SpentAt, Amount: exp.Amount})
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	historyStart := today.Start.AddDate(0, 0, -saldo.AnomalyHistoryDays)
+	saldoHistory, err := b.saldo.GetExpenseTotals(ctx, user.LedgerID, 0, historyStart, today.Start.Add(-time.Nanosecond), userLocation(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get expense totals", "err", err, "ledger_id", user.LedgerID)
+		return
+	}
+
+	history, currency := b.singleCurrencyTotals(ctx, user, NewExpenseTotals(saldoHistory))
+	median, days := dailyMedian(history)
+	if days < anomalyMinDays || median <= 0 {
+		return
+	}
+
+	saldoToday, err := b.saldo.GetExpenseTotals(ctx, user.LedgerID, 0, today.Start, today.End, userLocation(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get expense totals", "err", err, "ledger_id", user.LedgerID)
+		return
+	}
+
+	// Totals that cannot be summed in currency of history are not compared
+	total := b.sumTotalsIn(ctx, user, NewExpenseTotals(saldoToday), currency)
+	addedTotal := b.sumTotalsIn(ctx, user, added, currency)
+	if total < 0 || addedTotal < 0 {
+		return
+	}
+
+	threshold := median * anomalyFactor
+	if before := total - addedTotal; before > threshold || total <= threshold {
+		return
+	}
+
+	anomalyAlerts.WithLabelValues("daily").Inc()
+	symbol := getCurrencySymbol(currency)
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text: tr(ctx, "anomaly.daily",
+			formatAmount(total)+" "+symbol, total/median, formatAmount(median)+" "+symbol, saldo.AnomalyHistoryDays),
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+// dailyMedian returns median of totals summed by day and number of days with expenses
+func dailyMedian(totals []ExpenseTotal) (int64, int) {
+	sums := make(map[time.Time]int64)
+	for _, t := range totals {
+		sums[t.Day] += t.Amount
+	}
+	if len(sums) == 0 {
+		return 0, 0
+	}
+
+	values := make([]int64, 0, len(sums))
+	for _, sum := range sums {
+		values = append(values, sum)
+	}
+	slices.Sort(values)
+
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[middle-1] + values[middle]) / 2, len(values)
+	}
+	return values[middle], len(values)
+}
@@ -0,0 +1,293 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"saldo/pkg/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	budgetWarnPercent = 80
+	progressBarCells  = 10
+)
+
+// categoryAmountRegex matches "<category> <amount> [currency]", e.g. "Еда — 30 000 RUB"
+var categoryAmountRegex = regexp.MustCompile(`^(.+?)\s*[—–:-]?\s+(\d[\d\s\x{00a0}.,]*?)\s*([A-Za-zА-Яа-я₽]*)$`)
+
+// handleBudgets shows monthly budgets with progress bars
+func (b *Bot) handleBudgets(ctx context.Context, botAPI *bot.Bot, chatID int64, user *User) {
+	text, markup, err := b.budgetsScreen(ctx, user)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get budgets", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "budget.load_error"),
+		})
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// budgetsScreen builds text and keyboard of budgets screen
+func (b *Bot) budgetsScreen(ctx context.Context, user *User) (string, models.ReplyMarkup, error) {
+	statuses, err := b.saldo.GetBudgetStatuses(ctx, user.LedgerID, userNow(ctx))
+	if err != nil {
+		return "", nil, err
+	}
+
+	budgets := NewBudgets(statuses)
+	text := tr(ctx, "budget.title") + "\n\n"
+	if len(budgets) == 0 {
+		text += tr(ctx, "budget.empty")
+	}
+
+	for _, budget := range budgets {
+		text += formatBudgetStatus(ctx, budget) + "\n\n"
+	}
+
+	return strings.TrimSpace(text), budgetsKeyboard(ctx, budgets), nil
+}
+
+// handleBudgetAction handles callbacks of budgets screen
+// Callback data format: budget:add or budget:delete:<budgetID>
+func (b *Bot) handleBudgetAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	parts := strings.Split(value, ":")
+
+	switch parts[0] {
+	case "add":
+		callbacksProcessed.WithLabelValues("budget_add").Inc()
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+
+		b.stateManager.SetState(ctx, userID, chatID, StateAwaitingBudget)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "budget.enter"),
+			ParseMode: models.ParseModeHTML,
+		})
+	case "delete":
+		callbacksProcessed.WithLabelValues("budget_delete").Inc()
+		if len(parts) != 2 {
+			return
+		}
+		budgetID, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return
+		}
+
+		budget, err := b.saldo.DeleteBudget(ctx, user.LedgerID, budgetID)
+		if err != nil || budget == nil {
+			if err != nil {
+				errorsTotal.WithLabelValues("database").Inc()
+				b.logger.Error(ctx, "failed to delete budget", "err", err, "budget_id", budgetID)
+			}
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "budget.already_deleted"),
+			})
+			return
+		}
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "budget.deleted"),
+		})
+
+		text, markup, err := b.budgetsScreen(ctx, user)
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to get budgets", "err", err)
+			return
+		}
+		_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      chatID,
+			MessageID:   callback.Message.Message.ID,
+			Text:        text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: markup,
+		})
+	}
+}
+
+// handleBudgetInput handles text with category and monthly limit
+func (b *Bot) handleBudgetInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, text string) {
+	title, amount, currency, err := parseCategoryAmount(text, userDefaultCurrency(user))
+	if err != nil {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "budget.retry", trErr(ctx, err)),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	saldoBudget, err := b.saldo.SetBudget(ctx, user.LedgerID, user.ID, title, amount, currency)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to set budget", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "budget.save_error"),
+		})
+		return
+	}
+
+	// Return user to statistics menu where budgets button lives
+	b.stateManager.SetState(ctx, userID, chatID, StateInStatsMenu)
+
+	status, err := b.saldo.GetCategoryBudgetStatus(ctx, user.LedgerID, saldoBudget.CategoryID, userNow(ctx))
+	if err != nil || status == nil {
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to get budget status", "err", err)
+		}
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "budget.saved") + "\n\n" + formatBudgetStatus(ctx, *NewBudget(status)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: statisticsMenuKeyboard(ctx),
+	})
+}
+
+// notifyBudgets replies with remaining budgets of categories of just saved expenses
+// and warns when spending crosses 80% or 100% of the limit
+func (b *Bot) notifyBudgets(ctx context.Context, botAPI *bot.Bot, chatID int64, user *User, expenses []Expense) {
+	// Back-dated expenses of previous months do not change budgets of current month
+	monthStart := GetCalendarMonthPeriod(userNow(ctx)).Start
+	expenses = slices.DeleteFunc(slices.Clone(expenses), func(exp Expense) bool {
+		return exp.SpentAt.Before(monthStart)
+	})
+
+	if !slices.ContainsFunc(expenses, func(exp Expense) bool { return exp.CategoryID != nil }) {
+		return
+	}
+
+	statuses, err := b.saldo.GetBudgetStatuses(ctx, user.LedgerID, userNow(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get budget statuses", "err", err)
+		return
+	}
+
+	var lines []string
+	for _, budget := range NewBudgets(statuses) {
+		var budgetExpenses []Expense
+		for _, exp := range expenses {
+			if exp.CategoryID != nil && slices.Contains(budget.CategoryIDs, *exp.CategoryID) {
+				budgetExpenses = append(budgetExpenses, exp)
+			}
+		}
+		if len(budgetExpenses) == 0 {
+			continue
+		}
+
+		// Amount spent before these expenses tells whether threshold was crossed just now
+		added, err := b.sumInBaseCurrency(ctx, budget.Currency, budgetExpenses)
+		if err != nil {
+			b.logger.Error(ctx, "failed to convert expenses to budget currency", "err", err, "budget_id", budget.ID)
+			continue
+		}
+		before := budget.Spent - added
+
+		lines = append(lines, formatBudgetStatus(ctx, budget))
+		switch {
+		case before < budget.Amount && budget.Spent >= budget.Amount:
+			lines = append(lines, tr(ctx, "budget.exceeded",
+				budgetTitle(ctx, budget), formatAmount(budget.Spent-budget.Amount), getCurrencySymbol(budget.Currency)))
+		case before*100 < budget.Amount*budgetWarnPercent && budget.Spent*100 >= budget.Amount*budgetWarnPercent:
+			lines = append(lines, tr(ctx, "budget.warning", budgetWarnPercent, budgetTitle(ctx, budget)))
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      strings.Join(lines, "\n\n"),
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+// parseCategoryAmount parses category title, amount in cents and currency from user input, defaultCurrency is used if currency is omitted
+func parseCategoryAmount(text, defaultCurrency string) (string, int64, string, error) {
+	matches := categoryAmountRegex.FindStringSubmatch(strings.TrimSpace(text))
+	if matches == nil {
+		return "", 0, "", i18n.Errorf("format.invalid")
+	}
+
+	amount, err := parseAmount(matches[2])
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	currency := strings.ToUpper(matches[3])
+	switch currency {
+	case "":
+		currency = defaultCurrency
+	case "₽", "Р", "РУБ":
+		currency = "RUB"
+	}
+	if !slices.Contains(supportedCurrencies, currency) {
+		return "", 0, "", i18n.Errorf("format.unsupported_currency", matches[3])
+	}
+
+	return strings.TrimSpace(matches[1]), amount, currency, nil
+}
+
+// formatBudgetStatus formats budget with progress bar and remaining amount
+func formatBudgetStatus(ctx context.Context, budget Budget) string {
+	currency := getCurrencySymbol(budget.Currency)
+	text := fmt.Sprintf("<b>%s</b>\n%s %d%%\n%s / %s %s",
+		budgetTitle(ctx, budget), formatProgressBar(budget.Percent), budget.Percent,
+		formatAmount(budget.Spent), formatAmount(budget.Amount), currency)
+
+	if remaining := budget.Amount - budget.Spent; remaining >= 0 {
+		text += tr(ctx, "budget.remaining", formatAmount(remaining), currency)
+	} else {
+		text += tr(ctx, "budget.overspent", formatAmount(-remaining), currency)
+	}
+
+	return text
+}
+
+// budgetTitle returns title of budget category with emoji escaped for HTML messages
+func budgetTitle(ctx context.Context, budget Budget) string {
+	return html.EscapeString(budgetLabel(ctx, budget))
+}
+
+// budgetLabel returns title of budget category with emoji as plain text, e.g. for keyboard buttons
+func budgetLabel(ctx context.Context, budget Budget) string {
+	if budget.Category == nil {
+		return tr(ctx, "no_category")
+	}
+
+	return strings.TrimSpace(budget.Category.Emoji + " " + budget.Category.Title)
+}
+
+// formatProgressBar returns bar of filled and empty cells for percent
+func formatProgressBar(percent int64) string {
+	filled := int(min(max(percent, 0), 100)) * progressBarCells / 100
+	return strings.Repeat("▓", filled) + strings.Repeat("░", progressBarCells-filled)
+}
@@ -0,0 +1,553 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"saldo/pkg/saldo"
+	"saldo/pkg/services"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// categoryTitleMaxLength is max length of category title entered by user
+const categoryTitleMaxLength = 64
+
+// handleCategoriesCommand handles /categories command
+func (b *Bot) handleCategoriesCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("categories").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	b.handleCategories(ctx, botAPI, chatID, user)
+}
+
+// handleCategories shows ledger's categories with numbers of their expenses
+func (b *Bot) handleCategories(ctx context.Context, botAPI *bot.Bot, chatID int64, user *User) {
+	text, markup, err := b.categoriesScreen(ctx, user)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "categories.load_error"),
+		})
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// categoriesScreen builds text and keyboard of categories menu
+func (b *Bot) categoriesScreen(ctx context.Context, user *User) (string, models.ReplyMarkup, error) {
+	categories, err := b.getCategoriesUsage(ctx, user)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(categories) == 0 {
+		return tr(ctx, "categories.empty"), nil, nil
+	}
+
+	categories = categoryTree(categories)
+	lines := []string{tr(ctx, "categories.title"), ""}
+	for _, cat := range categories {
+		lines = append(lines, tr(ctx, "categories.item", html.EscapeString(categoryTreeTitle(cat.Category)), cat.ExpensesCount))
+	}
+
+	return strings.Join(lines, "\n"), categoriesKeyboard(categories), nil
+}
+
+// categoryScreen builds text and keyboard of one category, nil markup means category is not found
+func (b *Bot) categoryScreen(ctx context.Context, user *User, categoryID int) (string, models.ReplyMarkup, error) {
+	categories, err := b.getCategoriesUsage(ctx, user)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cat := findCategoryUsage(categories, categoryID)
+	if cat == nil {
+		return tr(ctx, "categories.not_found"), nil, nil
+	}
+
+	text := tr(ctx, "categories.detail", html.EscapeString(categoryTitle(cat.Category)), cat.ExpensesCount)
+	if cat.ParentID != nil {
+		if parent := findCategoryUsage(categories, *cat.ParentID); parent != nil {
+			text += "\n" + tr(ctx, "categories.parent", html.EscapeString(categoryTitle(parent.Category)))
+		}
+	}
+
+	return text, categoryKeyboard(ctx, cat.ID), nil
+}
+
+// handleCategoryAction handles callbacks of categories menu
+// Callback data format: cat:list, cat:<open|rename|emoji|merge|delete|parent>:<categoryID>,
+// cat:<mergeto|delto|parentto>:<categoryID>:<targetID>, zero target leaves expenses without category or makes category top level
+func (b *Bot) handleCategoryAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	parts := strings.Split(value, ":")
+
+	if parts[0] == "list" {
+		callbacksProcessed.WithLabelValues("category_list").Inc()
+		b.showCategoriesScreen(ctx, botAPI, callback, chatID, user, "")
+		return
+	} else if len(parts) < 2 {
+		return
+	}
+
+	categoryID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+
+	switch parts[0] {
+	case "open":
+		callbacksProcessed.WithLabelValues("category_open").Inc()
+		b.showCategoryScreen(ctx, botAPI, callback, chatID, user, categoryID)
+	case "rename", "emoji":
+		callbacksProcessed.WithLabelValues("category_" + parts[0]).Inc()
+		cat, ok := b.answerCategory(ctx, botAPI, callback, user, categoryID)
+		if !ok {
+			return
+		}
+
+		state, prompt := StateAwaitingCategoryName, "categories.enter_name"
+		if parts[0] == "emoji" {
+			state, prompt = StateAwaitingCategoryEmoji, "categories.enter_emoji"
+		}
+		b.stateManager.SetStateData(ctx, userID, chatID, &UserStateData{State: state, EditCategory: cat.ID})
+
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, prompt, html.EscapeString(categoryTitle(cat.Category))),
+			ParseMode: models.ParseModeHTML,
+		})
+	case "merge", "delete", "parent":
+		callbacksProcessed.WithLabelValues("category_" + parts[0]).Inc()
+		b.showCategoryTargets(ctx, botAPI, callback, chatID, user, parts[0], categoryID)
+	case "parentto":
+		callbacksProcessed.WithLabelValues("category_parent_confirm").Inc()
+		if len(parts) != 3 {
+			return
+		}
+		parentID, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return
+		}
+
+		var parent *int
+		if parentID != 0 {
+			parent = &parentID
+		}
+		_, err = b.saldo.SetCategoryParent(ctx, user.LedgerID, categoryID, parent)
+		if errors.Is(err, saldo.ErrCategoryDepth) {
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "categories.has_children"),
+				ShowAlert:       true,
+			})
+			return
+		} else if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to set parent category", "err", err, "category_id", categoryID, "parent_id", parentID)
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "categories.save_error"),
+				ShowAlert:       true,
+			})
+			return
+		}
+
+		b.showCategoriesScreen(ctx, botAPI, callback, chatID, user, tr(ctx, "categories.parent_set"))
+	case "mergeto", "delto":
+		if len(parts) != 3 {
+			return
+		}
+		targetID, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return
+		}
+
+		var moved int
+		if parts[0] == "mergeto" {
+			callbacksProcessed.WithLabelValues("category_merge_confirm").Inc()
+			moved, err = b.saldo.MergeCategories(ctx, user.LedgerID, categoryID, targetID)
+		} else {
+			callbacksProcessed.WithLabelValues("category_delete_confirm").Inc()
+			var target *int
+			if targetID != 0 {
+				target = &targetID
+			}
+			moved, err = b.saldo.DeleteCategory(ctx, user.LedgerID, categoryID, target)
+		}
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to delete category", "err", err, "category_id", categoryID, "target_id", targetID)
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "categories.save_error"),
+				ShowAlert:       true,
+			})
+			return
+		}
+
+		result := tr(ctx, "categories.deleted", moved)
+		if parts[0] == "mergeto" {
+			result = tr(ctx, "categories.merged", moved)
+		}
+		b.showCategoriesScreen(ctx, botAPI, callback, chatID, user, result)
+	}
+}
+
+// answerCategory answers callback and returns category of ledger, user is alerted if category is not found
+func (b *Bot) answerCategory(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, user *User, categoryID int) (*CategoryUsage, bool) {
+	categories, err := b.getCategoriesUsage(ctx, user)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "categories.load_error"),
+		})
+		return nil, false
+	}
+
+	cat := findCategoryUsage(categories, categoryID)
+	if cat == nil {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "categories.not_found"),
+		})
+		return nil, false
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	return cat, true
+}
+
+// showCategoriesScreen replaces message of categories menu with list of categories, notice is shown in callback answer
+func (b *Bot) showCategoriesScreen(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, notice string) {
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            notice,
+	})
+
+	text, markup, err := b.categoriesScreen(ctx, user)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		return
+	}
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// showCategoryScreen replaces message of categories menu with actions on category
+func (b *Bot) showCategoryScreen(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, categoryID int) {
+	text, markup, err := b.categoryScreen(ctx, user, categoryID)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "categories.load_error"),
+		})
+		return
+	} else if markup == nil {
+		b.showCategoriesScreen(ctx, botAPI, callback, chatID, user, text)
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// showCategoryTargets asks which category expenses of merged or deleted category move to
+// or which category becomes parent of category
+func (b *Bot) showCategoryTargets(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, action string, categoryID int) {
+	categories, err := b.getCategoriesUsage(ctx, user)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "categories.load_error"),
+		})
+		return
+	}
+
+	cat := findCategoryUsage(categories, categoryID)
+	if cat == nil {
+		b.showCategoriesScreen(ctx, botAPI, callback, chatID, user, tr(ctx, "categories.not_found"))
+		return
+	}
+
+	title := html.EscapeString(categoryTitle(cat.Category))
+	var text string
+	var markup models.ReplyMarkup
+	switch action {
+	case "merge":
+		targets := otherCategories(categoryTree(categories), cat.ID)
+		if len(targets) == 0 {
+			b.answerCategoryNotice(ctx, botAPI, callback, tr(ctx, "categories.no_targets"))
+			return
+		}
+		text, markup = tr(ctx, "categories.merge_choose", title), categoryTargetKeyboard(ctx, "mergeto", cat.ID, targets, "")
+	case "delete":
+		targets := otherCategories(categoryTree(categories), cat.ID)
+		text, markup = tr(ctx, "categories.delete_choose", title, cat.ExpensesCount), categoryTargetKeyboard(ctx, "delto", cat.ID, targets, tr(ctx, "kb.category_none"))
+	case "parent":
+		if hasSubCategories(categories, cat.ID) {
+			b.answerCategoryNotice(ctx, botAPI, callback, tr(ctx, "categories.has_children"))
+			return
+		}
+
+		var parents []CategoryUsage
+		for _, c := range categories {
+			if c.ParentID == nil && c.ID != cat.ID && (cat.ParentID == nil || *cat.ParentID != c.ID) {
+				parents = append(parents, c)
+			}
+		}
+
+		var topLabel string
+		if cat.ParentID != nil {
+			topLabel = tr(ctx, "kb.category_top")
+		} else if len(parents) == 0 {
+			b.answerCategoryNotice(ctx, botAPI, callback, tr(ctx, "categories.no_targets"))
+			return
+		}
+		text, markup = tr(ctx, "categories.parent_choose", title), categoryTargetKeyboard(ctx, "parentto", cat.ID, parents, topLabel)
+	default:
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// answerCategoryNotice answers callback of categories menu with notice shown as alert
+func (b *Bot) answerCategoryNotice(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, notice string) {
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            notice,
+		ShowAlert:       true,
+	})
+}
+
+// handleCategoryNameInput handles new title of category
+func (b *Bot) handleCategoryNameInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, stateData *UserStateData, text string) {
+	title := strings.TrimSpace(text)
+	if title == "" || utf8.RuneCountInString(title) > categoryTitleMaxLength {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "categories.name_invalid", categoryTitleMaxLength),
+		})
+		return
+	}
+
+	category, err := b.saldo.RenameCategory(ctx, user.LedgerID, stateData.EditCategory, title)
+	if errors.Is(err, saldo.ErrCategoryExists) {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "categories.exists", html.EscapeString(title)),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	b.finishCategoryInput(ctx, botAPI, chatID, userID, user, category, err, "categories.renamed")
+}
+
+// handleCategoryEmojiInput handles new emoji of category, "-" removes emoji
+func (b *Bot) handleCategoryEmojiInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, stateData *UserStateData, text string) {
+	emoji, ok := parseCategoryEmoji(text)
+	if !ok {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "categories.emoji_invalid"),
+		})
+		return
+	}
+
+	category, err := b.saldo.SetCategoryEmoji(ctx, user.LedgerID, stateData.EditCategory, emoji)
+	b.finishCategoryInput(ctx, botAPI, chatID, userID, user, category, err, "categories.emoji_set")
+}
+
+// finishCategoryInput clears state after category is changed and shows categories menu again
+func (b *Bot) finishCategoryInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, category *saldo.Category, err error, doneKey string) {
+	b.stateManager.ClearState(ctx, userID, chatID)
+
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to update category", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "categories.save_error"),
+		})
+		return
+	}
+
+	doneText := tr(ctx, doneKey)
+	if category == nil {
+		doneText = tr(ctx, "categories.not_found")
+	}
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   doneText,
+	})
+
+	b.handleCategories(ctx, botAPI, chatID, user)
+}
+
+// getCategoriesUsage returns ledger's expense categories with numbers of their expenses
+func (b *Bot) getCategoriesUsage(ctx context.Context, user *User) ([]CategoryUsage, error) {
+	usage, err := b.saldo.GetCategoriesUsage(ctx, user.LedgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCategoriesUsage(usage), nil
+}
+
+// categoryTree orders categories so that each top level category is followed by its sub-categories
+func categoryTree(categories []CategoryUsage) []CategoryUsage {
+	children := make(map[int][]CategoryUsage)
+	var roots []CategoryUsage
+	for _, cat := range categories {
+		if cat.ParentID != nil && findCategoryUsage(categories, *cat.ParentID) != nil {
+			children[*cat.ParentID] = append(children[*cat.ParentID], cat)
+		} else {
+			roots = append(roots, cat)
+		}
+	}
+
+	tree := make([]CategoryUsage, 0, len(categories))
+	for _, root := range roots {
+		tree = append(tree, root)
+		tree = append(tree, children[root.ID]...)
+	}
+	return tree
+}
+
+// otherCategories returns categories except one with given ID
+func otherCategories(categories []CategoryUsage, categoryID int) []CategoryUsage {
+	result := make([]CategoryUsage, 0, len(categories))
+	for _, cat := range categories {
+		if cat.ID != categoryID {
+			result = append(result, cat)
+		}
+	}
+	return result
+}
+
+// hasSubCategories reports whether category is parent of any of categories
+func hasSubCategories(categories []CategoryUsage, categoryID int) bool {
+	for _, cat := range categories {
+		if cat.ParentID != nil && *cat.ParentID == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// findCategoryUsage returns category with given ID or nil
+func findCategoryUsage(categories []CategoryUsage, categoryID int) *CategoryUsage {
+	for i := range categories {
+		if categories[i].ID == categoryID {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// categoryPromptNames returns category titles for LLM prompt, sub-categories are listed in brackets after their parent
+func categoryPromptNames(categories []Category) []string {
+	nodes := make([]services.CategoryNode, 0, len(categories))
+	index := make(map[int]int, len(categories))
+	for _, cat := range categories {
+		if cat.ParentID == nil {
+			index[cat.ID] = len(nodes)
+			nodes = append(nodes, services.CategoryNode{Title: cat.Title})
+		}
+	}
+
+	for _, cat := range categories {
+		if cat.ParentID == nil {
+			continue
+		}
+		if i, ok := index[*cat.ParentID]; ok {
+			nodes[i].Children = append(nodes[i].Children, cat.Title)
+		} else {
+			nodes = append(nodes, services.CategoryNode{Title: cat.Title})
+		}
+	}
+
+	return services.CategoryTreeNames(nodes)
+}
+
+// categoryTreeTitle returns title of category with its emoji, sub-category is marked as nested
+func categoryTreeTitle(cat Category) string {
+	if cat.ParentID != nil {
+		return "↳ " + categoryTitle(cat)
+	}
+	return categoryTitle(cat)
+}
+
+// categoryTitle returns title of category with its emoji
+func categoryTitle(cat Category) string {
+	return strings.TrimSpace(cat.Emoji + " " + cat.Title)
+}
+
+// parseCategoryEmoji validates emoji sent by user, "-" means no emoji
+func parseCategoryEmoji(text string) (string, bool) {
+	emoji := strings.TrimSpace(text)
+	if emoji == "-" {
+		return "", true
+	}
+
+	return emoji, saldo.IsEmoji(emoji)
+}
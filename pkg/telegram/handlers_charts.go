@@ -0,0 +1,242 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"time"
+
+	"saldo/pkg/services"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// chartMaxDays is max number of days of bar chart by day, longer periods are charted by month
+const chartMaxDays = 62
+
+// handleStatisticsCharts sends pie chart of expenses by category and bar chart of expenses by day for period.
+// Amounts are converted to base currency, if rates are unknown only expenses in the most frequent currency are charted.
+func (b *Bot) handleStatisticsCharts(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, period TimePeriod) {
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+
+	saldoTotals, err := b.saldo.GetExpenseTotals(ctx, user.LedgerID, stateData.MemberID, period.Start, period.End, userLocation(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get expense totals", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "expenses.load_error"),
+		})
+		return
+	}
+
+	// No expenses are already reported by statistics text
+	totals, currency := b.singleCurrencyTotals(ctx, user, NewExpenseTotals(saldoTotals))
+	if len(totals) == 0 {
+		return
+	}
+
+	categories, err := b.saldo.GetCategories(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "categories.load_error"),
+		})
+		return
+	}
+
+	title := formatStatsPeriod(ctx, period, stateData)
+	categoryMap, _ := groupExpensesByCategory(ctx, totalEntries(totals), NewCategories(categories))
+	values, legend := pieChartData(ctx, categoryMap, currency)
+
+	var pie bytes.Buffer
+	if err := services.WritePieChart(&pie, values); err != nil {
+		b.sendChartError(ctx, botAPI, chatID, err)
+		return
+	}
+	b.sendChart(ctx, botAPI, chatID, "categories.png", &pie, tr(ctx, "charts.by_categories")+"\n"+title+"\n"+legend)
+
+	bars, byMonth := barChartData(ctx, totals, period)
+	key := "charts.by_days"
+	if byMonth {
+		key = "charts.by_months"
+	}
+
+	var total int64
+	for _, t := range totals {
+		total += t.Amount
+	}
+	caption := tr(ctx, key) + "\n" + title + "\n"
+	caption += tr(ctx, "stats.total", formatAmount(total)+" "+getCurrencySymbol(currency))
+	caption += tr(ctx, "charts.average", formatAmount(total/int64(len(bars)))+" "+getCurrencySymbol(currency))
+
+	var bar bytes.Buffer
+	if err := services.WriteBarChart(&bar, bars); err != nil {
+		b.sendChartError(ctx, botAPI, chatID, err)
+		return
+	}
+	b.sendChart(ctx, botAPI, chatID, "days.png", &bar, caption)
+}
+
+// singleCurrencyTotals returns totals in one currency to be charted or summed: all totals converted to base currency
+// or, if some rate is unknown, totals in the most frequent currency only
+func (b *Bot) singleCurrencyTotals(ctx context.Context, user *User, totals []ExpenseTotal) ([]ExpenseTotal, string) {
+	base := userBaseCurrency(user)
+
+	if converted, ok := b.convertTotals(ctx, totals, base); ok {
+		return converted, base
+	}
+
+	currencyOrder := sortCurrenciesByFrequency(currencyFrequencyOfTotals(totals))
+	if len(currencyOrder) == 0 {
+		return nil, base
+	}
+
+	var filtered []ExpenseTotal
+	for _, t := range totals {
+		if t.Currency == currencyOrder[0] {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, currencyOrder[0]
+}
+
+// convertTotals converts totals to currency by exchange rates of their days, false is returned if some rate is unknown
+func (b *Bot) convertTotals(ctx context.Context, totals []ExpenseTotal, currency string) ([]ExpenseTotal, bool) {
+	days := make([]time.Time, len(totals))
+	for i, t := range totals {
+		days[i] = t.Day
+	}
+	rates, err := b.saldo.GetDailyRates(ctx, days)
+	if err != nil {
+		errorsTotal.WithLabelValues("exchange_rates").Inc()
+		b.logger.Error(ctx, "failed to get exchange rates", "err", err)
+		return nil, false
+	}
+
+	converted := make([]ExpenseTotal, 0, len(totals))
+	for _, t := range totals {
+		amount, ok := rates.Convert(t.Amount, t.Currency, currency, t.Day)
+		if !ok {
+			errorsTotal.WithLabelValues("exchange_rates").Inc()
+			b.logger.Error(ctx, "failed to convert totals", "currency", t.Currency, "to", currency)
+			return nil, false
+		}
+		t.Amount, t.Currency = amount, currency
+		converted = append(converted, t)
+	}
+
+	return converted, true
+}
+
+// pieChartData returns values of pie slices, largest categories first, and caption legend of them.
+// Categories that do not fit on chart are joined into the last slice.
+func pieChartData(ctx context.Context, categoryMap map[string]*CategoryStats, currency string) ([]int64, string) {
+	// amounts are in one currency, they are compared without rates
+	sorted := sortCategoriesByTotal(categoryMap, nil)
+	maxSlices := len(services.ChartColorMarks)
+
+	var total int64
+	for _, stats := range sorted {
+		total += stats.Amounts[currency]
+	}
+
+	var (
+		values []int64
+		legend string
+	)
+	addSlice := func(title string, amount int64) {
+		if amount <= 0 {
+			return
+		}
+		mark := services.ChartColorMarks[len(values)]
+		values = append(values, amount)
+		legend += fmt.Sprintf("%s %s — %s %s (%d%%)\n", mark, html.EscapeString(title),
+			formatAmount(amount), getCurrencySymbol(currency), amount*100/total)
+	}
+
+	for i, stats := range sorted {
+		if i == maxSlices-1 && len(sorted) > maxSlices {
+			var rest int64
+			for _, other := range sorted[i:] {
+				rest += other.Amounts[currency]
+			}
+			addSlice(tr(ctx, "stats.category_other"), rest)
+			break
+		}
+		addSlice(categoryTitle(Category{Title: stats.Title, Emoji: stats.Emoji}), stats.Amounts[currency])
+	}
+
+	return values, legend
+}
+
+// barChartData returns bars of expenses by day of period, long periods start from the first day with expenses.
+// Periods longer than chartMaxDays are charted by month, then true is returned.
+func barChartData(ctx context.Context, totals []ExpenseTotal, period TimePeriod) ([]services.ChartBar, bool) {
+	// Totals are summed by day in user's time zone, days of period are compared as dates
+	date := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	sums := make(map[time.Time]int64)
+	earliest := date(period.End.In(userLocation(ctx)))
+	for _, t := range totals {
+		day := date(t.Day)
+		sums[day] += t.Amount
+		if day.Before(earliest) {
+			earliest = day
+		}
+	}
+
+	first, last := date(period.Start.In(userLocation(ctx))), date(period.End.In(userLocation(ctx)))
+	if last.Sub(first).Hours()/24 >= chartMaxDays && earliest.After(first) {
+		first = earliest
+	}
+
+	byMonth := last.Sub(first).Hours()/24 >= chartMaxDays
+	var bars []services.ChartBar
+	if !byMonth {
+		for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+			bars = append(bars, services.ChartBar{Label: strconv.Itoa(day.Day()), Value: sums[day]})
+		}
+		return bars, false
+	}
+
+	months := make(map[time.Time]int64)
+	for day, amount := range sums {
+		months[time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)] += amount
+	}
+	for month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(last); month = month.AddDate(0, 1, 0) {
+		bars = append(bars, services.ChartBar{Label: month.Format("01.06"), Value: months[month]})
+	}
+	return bars, true
+}
+
+// sendChart sends PNG chart as photo with HTML caption
+func (b *Bot) sendChart(ctx context.Context, botAPI *bot.Bot, chatID int64, filename string, chart *bytes.Buffer, caption string) {
+	_, err := botAPI.SendPhoto(ctx, &bot.SendPhotoParams{
+		ChatID:    chatID,
+		Photo:     &models.InputFileUpload{Filename: filename, Data: chart},
+		Caption:   caption,
+		ParseMode: models.ParseModeHTML,
+	})
+	if err != nil {
+		errorsTotal.WithLabelValues("send_photo").Inc()
+		b.logger.Error(ctx, "failed to send chart", "err", err, "file", filename)
+	}
+}
+
+// sendChartError reports failed chart rendering
+func (b *Bot) sendChartError(ctx context.Context, botAPI *bot.Bot, chatID int64, err error) {
+	errorsTotal.WithLabelValues("chart").Inc()
+	b.logger.Error(ctx, "failed to render chart", "err", err)
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   tr(ctx, "charts.error"),
+	})
+}
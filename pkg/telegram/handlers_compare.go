@@ -0,0 +1,231 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// compareTrendMonths is number of months in trend line of category, the current month is the last one
+const compareTrendMonths = 6
+
+// Comparison modes, callback data format: compare:<mode>
+const (
+	compareMonth = "month" // this month versus the same days of last month
+	compareWeek  = "week"  // this week versus the same week of last month
+)
+
+// sparkBars are bars of trend line from the lowest to the highest
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// handleComparison shows expenses of this month by category compared with last month
+func (b *Bot) handleComparison(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User) {
+	text, err := b.comparisonText(ctx, chatID, userID, user, compareMonth)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to compare expenses", "err", err, "ledger_id", user.LedgerID)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "expenses.load_error"),
+		})
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: compareKeyboard(ctx, compareMonth),
+	})
+}
+
+// handleCompareAction switches comparison between month and week
+// Callback data format: compare:<month|week>
+func (b *Bot) handleCompareAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, mode string) {
+	if mode != compareMonth && mode != compareWeek {
+		return
+	}
+	callbacksProcessed.WithLabelValues("compare_" + mode).Inc()
+
+	text, err := b.comparisonText(ctx, chatID, userID, user, mode)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to compare expenses", "err", err, "ledger_id", user.LedgerID, "mode", mode)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "expenses.load_error"),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: compareKeyboard(ctx, mode),
+	})
+}
+
+// comparisonText builds totals of categories in current and previous period with deltas and trend lines of last months.
+// Amounts are converted to base currency, statistics of shared ledger may be shown for one member.
+func (b *Bot) comparisonText(ctx context.Context, chatID int64, userID int64, user *User, mode string) (string, error) {
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+	now := userNow(ctx)
+
+	current := GetCalendarMonthPeriod(now)
+	previous := current.MonthAgo()
+	title := tr(ctx, "compare.month_title")
+	if mode == compareWeek {
+		current = GetWeekPeriod(now, user.WeekStart)
+		previous = current.WeekMonthAgo(user.WeekStart)
+		title = tr(ctx, "compare.week_title")
+	}
+
+	// One query covers trend months and both compared periods
+	trendStart := GetCalendarMonthPeriod(now).Start.AddDate(0, 1-compareTrendMonths, 0)
+	saldoTotals, err := b.saldo.GetExpenseTotals(ctx, user.LedgerID, stateData.MemberID, trendStart, current.End, userLocation(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	categories, err := b.saldo.GetCategories(ctx, user.LedgerID)
+	if err != nil {
+		return "", err
+	}
+
+	text := title + "\n" + fmt.Sprintf("<i>%s</i> ↔ <i>%s</i>\n", FormatPeriod(ctx, current), FormatPeriod(ctx, previous))
+	if stateData.MemberID != 0 {
+		text += tr(ctx, "stats.member", html.EscapeString(stateData.MemberName)) + "\n"
+	}
+	text += "\n"
+
+	totals, currency := b.singleCurrencyTotals(ctx, user, NewExpenseTotals(saldoTotals))
+	if len(totals) == 0 {
+		return text + tr(ctx, "stats.no_expenses"), nil
+	}
+
+	group := func(period TimePeriod) map[string]*CategoryStats {
+		categoryMap, _ := groupExpensesByCategory(ctx, totalEntries(totalsInPeriod(ctx, totals, period)), NewCategories(categories))
+		return categoryMap
+	}
+	currentMap, previousMap := group(current), group(previous)
+	trend := make([]map[string]*CategoryStats, compareTrendMonths)
+	for i := range trend {
+		start := trendStart.AddDate(0, i, 0)
+		trend[i] = group(TimePeriod{Start: start, End: start.AddDate(0, 1, 0).Add(-time.Nanosecond)})
+	}
+
+	amount := func(categoryMap map[string]*CategoryStats, key string) int64 {
+		if stats, ok := categoryMap[key]; ok {
+			return stats.Amounts[currency]
+		}
+		return 0
+	}
+
+	// Categories of both periods, the largest ones of current period first
+	var keys []string
+	var currentTotal, previousTotal int64
+	for key, stats := range currentMap {
+		keys = append(keys, key)
+		currentTotal += stats.Amounts[currency]
+	}
+	for key, stats := range previousMap {
+		if _, ok := currentMap[key]; !ok {
+			keys = append(keys, key)
+		}
+		previousTotal += stats.Amounts[currency]
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if x, y := amount(currentMap, keys[i]), amount(currentMap, keys[j]); x != y {
+			return x > y
+		}
+		if x, y := amount(previousMap, keys[i]), amount(previousMap, keys[j]); x != y {
+			return x > y
+		}
+		return keys[i] < keys[j]
+	})
+
+	text += tr(ctx, "stats.total", formatAmount(currentTotal)+" "+getCurrencySymbol(currency)+" "+formatDelta(ctx, currentTotal, previousTotal))
+
+	for _, key := range keys {
+		stats, ok := currentMap[key]
+		if !ok {
+			stats = previousMap[key]
+		}
+
+		values := make([]int64, compareTrendMonths)
+		for i, month := range trend {
+			values[i] = amount(month, key)
+		}
+
+		cur := amount(currentMap, key)
+		text += fmt.Sprintf("\n%s: <b>%s %s</b> %s\n<code>%s</code>\n",
+			html.EscapeString(categoryTitle(Category{Title: stats.Title, Emoji: stats.Emoji})),
+			formatAmount(cur), getCurrencySymbol(currency), formatDelta(ctx, cur, amount(previousMap, key)), sparkline(values))
+	}
+
+	return text + "\n" + tr(ctx, "compare.trend_hint", compareTrendMonths), nil
+}
+
+// totalsInPeriod returns totals of days within period, days are compared as dates in user's time zone
+func totalsInPeriod(ctx context.Context, totals []ExpenseTotal, period TimePeriod) []ExpenseTotal {
+	first := period.Start.In(userLocation(ctx)).Format(time.DateOnly)
+	last := period.End.In(userLocation(ctx)).Format(time.DateOnly)
+
+	var result []ExpenseTotal
+	for _, t := range totals {
+		// Totals are summed by day in user's time zone
+		if day := t.Day.Format(time.DateOnly); day >= first && day <= last {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// formatDelta formats absolute and percentage change of amount versus previous one
+func formatDelta(ctx context.Context, current, previous int64) string {
+	switch {
+	case previous == 0 && current == 0:
+		return ""
+	case previous == 0:
+		return tr(ctx, "compare.new")
+	}
+
+	diff := current - previous
+	mark, sign := "➖", "+"
+	if diff > 0 {
+		mark = "📈"
+	} else if diff < 0 {
+		mark, sign, diff = "📉", "-", -diff
+	}
+
+	return fmt.Sprintf("(%s %s%s, %+d%%)", mark, sign, formatAmount(diff), (current-previous)*100/previous)
+}
+
+// sparkline draws values as line of bars scaled to the largest value, zero is the lowest bar
+func sparkline(values []int64) string {
+	var maxValue int64
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	line := make([]rune, len(values))
+	for i, v := range values {
+		level := 0
+		if maxValue > 0 && v > 0 {
+			level = 1 + int(v*int64(len(sparkBars)-2)/maxValue)
+		}
+		line[i] = sparkBars[level]
+	}
+	return string(line)
+}
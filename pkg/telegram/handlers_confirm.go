@@ -0,0 +1,262 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+
+	"saldo/pkg/saldo"
+	"saldo/pkg/services"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handlePendingItemAction handles callbacks changing one of parsed expenses before confirmation
+// Callback data format: expense:<drop|category|amount>:<index>, expense:setcat:<index>:<categoryID> or expense:back
+func (b *Bot) handlePendingItemAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	parts := strings.Split(value, ":")
+	messageID := callback.Message.Message.ID
+
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+	if len(stateData.ExpensesData) == 0 {
+		b.expirePendingConfirmation(ctx, botAPI, callback, chatID)
+		return
+	}
+
+	if parts[0] == "back" {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		b.updatePendingConfirmation(ctx, botAPI, chatID, messageID, stateData.ExpensesData)
+		return
+	}
+
+	if len(parts) < 2 {
+		return
+	}
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 || index >= len(stateData.ExpensesData) {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "confirm.item_not_found"),
+			ShowAlert:       true,
+		})
+		return
+	}
+	item := &stateData.ExpensesData[index]
+
+	switch parts[0] {
+	case "drop":
+		callbacksProcessed.WithLabelValues("item_drop").Inc()
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "confirm.item_dropped"),
+		})
+
+		stateData.ExpensesData = slices.Delete(stateData.ExpensesData, index, index+1)
+		if len(stateData.ExpensesData) == 0 {
+			b.stateManager.ClearState(ctx, userID, chatID)
+			_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+				ChatID:    chatID,
+				MessageID: messageID,
+				Text:      tr(ctx, "cancelled"),
+			})
+			return
+		}
+
+		b.stateManager.SetStateData(ctx, userID, chatID, stateData)
+		b.updatePendingConfirmation(ctx, botAPI, chatID, messageID, stateData.ExpensesData)
+	case "category":
+		callbacksProcessed.WithLabelValues("item_category").Inc()
+		categories, err := b.pendingItemCategories(ctx, user, item)
+		if err != nil {
+			errorsTotal.WithLabelValues("get_categories").Inc()
+			b.logger.Error(ctx, "failed to get categories", "err", err)
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "categories.load_error"),
+				ShowAlert:       true,
+			})
+			return
+		} else if len(categories) == 0 {
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "categories.empty"),
+				ShowAlert:       true,
+			})
+			return
+		}
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		_, _ = botAPI.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			ReplyMarkup: pendingCategoryKeyboard(ctx, index, NewCategories(categories)),
+		})
+	case "setcat":
+		if len(parts) < 3 {
+			return
+		}
+		categoryID, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return
+		}
+
+		kind := saldo.CategoryKindExpense
+		if item.Income {
+			kind = saldo.CategoryKindIncome
+		}
+		category, err := b.saldo.GetLedgerCategory(ctx, user.LedgerID, categoryID)
+		if err != nil || category == nil || category.Kind != kind {
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "confirm.category_not_found"),
+				ShowAlert:       true,
+			})
+			return
+		}
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+
+		item.Category = category.Title
+		b.stateManager.SetStateData(ctx, userID, chatID, stateData)
+		b.updatePendingConfirmation(ctx, botAPI, chatID, messageID, stateData.ExpensesData)
+	case "amount":
+		callbacksProcessed.WithLabelValues("item_amount").Inc()
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+
+		stateData.State = StateAwaitingItemAmount
+		stateData.EditItem = index
+		b.stateManager.SetStateData(ctx, userID, chatID, stateData)
+
+		// confirmation is sent again with new amount, buttons of this one are removed
+		_, _ = botAPI.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:    chatID,
+			MessageID: messageID,
+		})
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "confirm.enter_amount", html.EscapeString(formatPendingShort(*item))),
+			ParseMode: models.ParseModeHTML,
+		})
+	}
+}
+
+// handleItemAmountInput sets amount of pending expense and shows confirmation again
+func (b *Bot) handleItemAmountInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, stateData *UserStateData, text string) {
+	if stateData.EditItem >= len(stateData.ExpensesData) {
+		b.stateManager.ClearState(ctx, userID, chatID)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr(ctx, "confirm.expired_message"),
+			ReplyMarkup: mainMenuKeyboard(ctx),
+		})
+		return
+	}
+
+	amount, err := parseAmount(text)
+	if err != nil {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "amount.retry", trErr(ctx, err)),
+		})
+		return
+	}
+
+	stateData.ExpensesData[stateData.EditItem].Amount = amount
+	stateData.State = StateIdle
+	stateData.EditItem = 0
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        pendingConfirmationText(ctx, stateData.ExpensesData),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: expenseConfirmKeyboard(ctx, stateData.ExpensesData),
+	})
+}
+
+// pendingItemCategories returns user's categories of the same kind as pending expense
+func (b *Bot) pendingItemCategories(ctx context.Context, user *User, item *ExpenseData) ([]saldo.Category, error) {
+	if item.Income {
+		return b.saldo.GetIncomeCategories(ctx, user.LedgerID)
+	}
+	return b.saldo.GetCategories(ctx, user.LedgerID)
+}
+
+// updatePendingConfirmation replaces confirmation message with actual pending expenses
+func (b *Bot) updatePendingConfirmation(ctx context.Context, botAPI *bot.Bot, chatID int64, messageID int, expenses []ExpenseData) {
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        pendingConfirmationText(ctx, expenses),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: expenseConfirmKeyboard(ctx, expenses),
+	})
+}
+
+// expirePendingConfirmation tells that pending expenses are lost and removes buttons of confirmation
+func (b *Bot) expirePendingConfirmation(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64) {
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            tr(ctx, "confirm.expired"),
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
+		Text:      tr(ctx, "confirm.expired_message"),
+	})
+}
+
+// pendingConfirmationText formats expenses and incomes waiting for confirmation
+func pendingConfirmationText(ctx context.Context, expenses []ExpenseData) string {
+	title := tr(ctx, "confirm.expenses")
+	parsed := make([]services.ParsedExpense, len(expenses))
+	for i, exp := range expenses {
+		parsed[i] = services.ParsedExpense{
+			Type:        services.OperationExpense,
+			Amount:      float64(exp.Amount) / 100,
+			Currency:    exp.Currency,
+			Category:    exp.Category,
+			Description: exp.Description,
+			Date:        exp.Date,
+			Tags:        exp.Tags,
+		}
+		if exp.Income {
+			parsed[i].Type = services.OperationIncome
+			title = tr(ctx, "confirm.operations")
+		}
+	}
+
+	return "✅ <b>" + title + "</b>\n\n" + services.FormatExpenseDetails(parsed)
+}
+
+// formatPendingShort formats pending expense in one short line for button labels
+func formatPendingShort(exp ExpenseData) string {
+	title := exp.Description
+	if title == "" {
+		title = exp.Category
+	}
+
+	runes := []rune(title)
+	if len(runes) > 20 {
+		title = string(runes[:20]) + "…"
+	}
+
+	amount := formatAmount(exp.Amount)
+	if exp.Income {
+		amount = "+" + amount
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", title, amount, getCurrencySymbol(exp.Currency)))
+}
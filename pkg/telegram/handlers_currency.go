@@ -0,0 +1,185 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleCurrencyCommand handles /currency command - shows base currency selection
+func (b *Bot) handleCurrencyCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("currency").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	base := userBaseCurrency(user)
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "currency.base", getCurrencyWithFlag(base)) + "\n\n" + tr(ctx, "currency.base_hint"),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: baseCurrencyKeyboard(base),
+	})
+}
+
+// handleCurrencyAction handles base currency selection
+// Callback data format: currency:<code>
+func (b *Bot) handleCurrencyAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, currency string) {
+	callbacksProcessed.WithLabelValues("currency").Inc()
+	if !slices.Contains(supportedCurrencies, currency) {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "currency.unsupported"),
+		})
+		return
+	}
+
+	if err := b.saldo.SetUserBaseCurrency(ctx, user.ID, currency); err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to set base currency", "err", err)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "currency.save_error"),
+		})
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            tr(ctx, "currency.saved"),
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        tr(ctx, "currency.base", getCurrencyWithFlag(currency)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: baseCurrencyKeyboard(currency),
+	})
+}
+
+// formatStatsSummary formats totals of expenses and incomes converted to user's base currency.
+// If some exchange rate is unavailable, totals are shown by currency.
+func (b *Bot) formatStatsSummary(ctx context.Context, user *User, expenses []Expense, incomes []Income) string {
+	expenseTotals := calculateTotalExpenses(expenses)
+	incomeTotals := calculateTotalIncomes(incomes)
+	base := userBaseCurrency(user)
+
+	expenseBase, err := b.sumInBaseCurrency(ctx, base, expenses)
+	var incomeBase int64
+	if err == nil {
+		incomeBase, err = b.sumInBaseCurrency(ctx, base, incomeEntries(incomes))
+	}
+	if err != nil {
+		errorsTotal.WithLabelValues("exchange_rates").Inc()
+		b.logger.Error(ctx, "failed to convert totals to base currency", "err", err, "currency", base)
+
+		var text string
+		if len(expenses) > 0 {
+			text += tr(ctx, "stats.total", formatTotalExpenses(expenseTotals, nil))
+		}
+		if len(incomes) > 0 {
+			text += formatIncomeSummary(ctx, incomes, expenseTotals, nil)
+		}
+		return text
+	}
+
+	var text string
+	rates := b.currentRates(ctx)
+	if len(expenses) > 0 {
+		text += tr(ctx, "stats.total", formatBaseTotal(expenseBase, base, expenseTotals, rates))
+	}
+	if len(incomes) > 0 {
+		text += tr(ctx, "stats.income_balance",
+			formatBaseTotal(incomeBase, base, incomeTotals, rates),
+			formatBalance(map[string]int64{base: incomeBase}, map[string]int64{base: expenseBase}, rates))
+	}
+
+	return text
+}
+
+// sumInBaseCurrency sums entries converting each one by exchange rate on its date
+func (b *Bot) sumInBaseCurrency(ctx context.Context, base string, entries []Expense) (int64, error) {
+	dates := make([]time.Time, len(entries))
+	for i, e := range entries {
+		dates[i] = e.SpentAt
+	}
+	rates, err := b.saldo.GetDailyRates(ctx, dates)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, e := range entries {
+		amount, ok := rates.Convert(e.Amount, e.Currency, base, e.SpentAt)
+		if !ok {
+			return 0, fmt.Errorf("unknown exchange rate of %s or %s", e.Currency, base)
+		}
+		total += amount
+	}
+
+	return total, nil
+}
+
+// formatBaseTotal formats total in base currency followed by amounts in original currencies if they differ
+func formatBaseTotal(total int64, base string, totals map[string]int64, rates saldo.Rates) string {
+	text := fmt.Sprintf("%s %s", formatAmount(total), getCurrencyWithFlag(base))
+	if _, onlyBase := totals[base]; len(totals) > 1 || !onlyBase {
+		text += fmt.Sprintf(" (%s)", formatTotalExpenses(totals, rates))
+	}
+
+	return text
+}
+
+// incomeEntries converts incomes to expense entries for currency conversion
+func incomeEntries(incomes []Income) []Expense {
+	entries := make([]Expense, len(incomes))
+	for i, inc := range incomes {
+		entries[i] = Expense{Amount: inc.Amount, Currency: inc.Currency, SpentAt: inc.ReceivedAt}
+	}
+	return entries
+}
+
+// userBaseCurrency returns currency that user's statistics are converted to
+func userBaseCurrency(user *User) string {
+	if user == nil || user.BaseCurrency == "" {
+		return saldo.CurrencyRUB
+	}
+	return user.BaseCurrency
+}
+
+// currentRates returns today's exchange rates that order categories and currencies of amounts in several currencies,
+// nil is returned if rates are unavailable and then amounts are compared as is
+func (b *Bot) currentRates(ctx context.Context) saldo.Rates {
+	rates, err := b.saldo.GetRates(ctx, userNow(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("exchange_rates").Inc()
+		b.logger.Error(ctx, "failed to get exchange rates", "err", err)
+		return nil
+	}
+	return rates
+}
+
+// valueInRubles converts amount in cents to kopecks by rates, amount of currency with unknown rate is returned as is
+func valueInRubles(rates saldo.Rates, amount int64, currency string) int64 {
+	if converted, ok := rates.Convert(amount, currency, saldo.CurrencyRUB); ok {
+		return converted
+	}
+	return amount
+}
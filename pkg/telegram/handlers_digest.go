@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// digestCheckInterval is how often due digests are sent
+	digestCheckInterval = 5 * time.Minute
+
+	// digestTopCategories is number of the largest categories shown in digest
+	digestTopCategories = 5
+)
+
+// runDigestScheduler sends due weekly and monthly digests until ctx is done
+func (b *Bot) runDigestScheduler(ctx context.Context) {
+	ticker := time.NewTicker(digestCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		b.sendDigests(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			b.logger.Print(ctx, "digest scheduler stopped")
+			return
+		}
+	}
+}
+
+// sendDigests sends due digests to private chats of subscribed users
+func (b *Bot) sendDigests(ctx context.Context) {
+	digests, err := b.saldo.TakeDueDigests(ctx, time.Now())
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get due digests", "err", err)
+		return
+	}
+
+	for _, digest := range digests {
+		// digest is sent in language and time zone of user, there is no update to take them from
+		ctx := withLocation(i18n.WithLang(ctx, i18n.Parse(digest.User.Language)), saldo.LoadLocation(digest.User.Timezone))
+
+		text, err := b.digestText(ctx, digest)
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to build digest", "err", err, "user_id", digest.User.ID, "kind", digest.Kind)
+			continue
+		}
+
+		_, err = b.api.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    digest.ChatID,
+			Text:      text,
+			ParseMode: models.ParseModeHTML,
+		})
+		if err != nil {
+			errorsTotal.WithLabelValues("send_digest").Inc()
+			b.logger.Error(ctx, "failed to send digest", "err", err, "user_id", digest.User.ID, "kind", digest.Kind)
+			continue
+		}
+		digestsSent.WithLabelValues(string(digest.Kind)).Inc()
+	}
+}
+
+// digestText builds summary of period: total in base currency, its change versus previous period and top categories
+func (b *Bot) digestText(ctx context.Context, digest saldo.Digest) (string, error) {
+	user := NewUser(&digest.User)
+	weekly := digest.Kind == saldo.DigestWeekly
+
+	saldoTotals, err := b.saldo.GetExpenseTotals(ctx, digest.LedgerID, 0, digest.Start, digest.End, userLocation(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	previousStart, previousEnd := digest.PreviousPeriod()
+	saldoPrevious, err := b.saldo.GetExpenseTotals(ctx, digest.LedgerID, 0, previousStart, previousEnd, userLocation(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	title := tr(ctx, "digest.monthly_title")
+	if weekly {
+		title = tr(ctx, "digest.weekly_title")
+	}
+	text := title + "\n" + fmt.Sprintf("<i>%s</i>\n\n", FormatPeriod(ctx, TimePeriod{Start: digest.Start, End: digest.End}))
+
+	totals, currency := b.singleCurrencyTotals(ctx, user, NewExpenseTotals(saldoTotals))
+	if len(totals) == 0 {
+		return text + tr(ctx, "digest.no_expenses"), nil
+	}
+
+	var total int64
+	for _, t := range totals {
+		total += t.Amount
+	}
+	text += tr(ctx, "stats.total", formatAmount(total)+" "+getCurrencySymbol(currency))
+	text += digestChange(ctx, weekly, total, currency, b.sumTotalsIn(ctx, user, NewExpenseTotals(saldoPrevious), currency))
+
+	categories, err := b.saldo.GetCategories(ctx, digest.LedgerID)
+	if err != nil {
+		return "", err
+	}
+
+	categoryMap, _ := groupExpensesByCategory(ctx, totalEntries(totals), NewCategories(categories))
+	text += "\n" + tr(ctx, "digest.top_categories") + "\n"
+	// totals are in one currency, categories are compared without rates
+	for i, stats := range sortCategoriesByTotal(categoryMap, nil) {
+		if i == digestTopCategories {
+			break
+		}
+		amount := stats.Amounts[currency]
+		text += fmt.Sprintf("%d. %s — %s %s (%d%%)\n", i+1, html.EscapeString(categoryTitle(Category{Title: stats.Title, Emoji: stats.Emoji})),
+			formatAmount(amount), getCurrencySymbol(currency), amount*100/total)
+	}
+
+	return text, nil
+}
+
+// sumTotalsIn sums totals in currency, -1 is returned if some rate is unknown and they cannot be summed in it
+func (b *Bot) sumTotalsIn(ctx context.Context, user *User, totals []ExpenseTotal, currency string) int64 {
+	// when some rate is unknown totals in other currencies are dropped, their sum would be too small
+	converted, convertedCurrency := b.singleCurrencyTotals(ctx, user, totals)
+	if len(converted) != len(totals) || (len(converted) > 0 && convertedCurrency != currency) {
+		return -1
+	}
+
+	var sum int64
+	for _, t := range converted {
+		sum += t.Amount
+	}
+	return sum
+}
+
+// digestChange formats change of total versus previous period, nothing is shown if previous total is unknown
+func digestChange(ctx context.Context, weekly bool, total int64, currency string, previous int64) string {
+	suffix := "month"
+	if weekly {
+		suffix = "week"
+	}
+
+	switch {
+	case previous < 0:
+		return ""
+	case previous == 0:
+		return tr(ctx, "digest.no_previous_"+suffix) + "\n"
+	}
+
+	percent := (total - previous) * 100 / previous
+	mark := "📈"
+	if percent < 0 {
+		mark = "📉"
+	}
+	return tr(ctx, "digest.change_"+suffix, mark, fmt.Sprintf("%+d%%", percent), formatAmount(previous)+" "+getCurrencySymbol(currency)) + "\n"
+}
@@ -0,0 +1,322 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// maxEditButtons limits number of edit buttons under statistics by expenses
+const maxEditButtons = 50
+
+// handleEditAction handles callbacks of saved expense editing flow
+// Callback data format: edit:<expenseID>[:<field>|:set:<field>:<value>|:back|:done]
+func (b *Bot) handleEditAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	parts := strings.Split(value, ":")
+
+	expenseID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return
+	}
+
+	expense, err := b.saldo.GetExpense(ctx, user.LedgerID, expenseID)
+	if err != nil || expense == nil {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "edit.not_found"),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	messageID := callback.Message.Message.ID
+
+	// edit:<id> - open edit menu in a new message
+	if len(parts) == 1 {
+		callbacksProcessed.WithLabelValues("edit").Inc()
+		b.stateManager.ClearState(ctx, userID, chatID)
+		b.showExpenseEditMenu(ctx, botAPI, chatID, NewExpense(expense))
+		return
+	}
+
+	switch parts[1] {
+	case string(EditFieldAmount):
+		b.startEditInput(ctx, botAPI, chatID, userID, expenseID, EditFieldAmount,
+			tr(ctx, "edit.enter_amount"))
+	case string(EditFieldDescription):
+		b.startEditInput(ctx, botAPI, chatID, userID, expenseID, EditFieldDescription,
+			tr(ctx, "edit.enter_description"))
+	case string(EditFieldCurrency):
+		_, _ = botAPI.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			ReplyMarkup: editCurrencyKeyboard(ctx, expenseID),
+		})
+	case string(EditFieldCategory):
+		saldoCategories, err := b.saldo.GetCategories(ctx, user.LedgerID)
+		if err != nil {
+			errorsTotal.WithLabelValues("get_categories").Inc()
+			b.logger.Error(ctx, "failed to get categories", "err", err)
+			return
+		}
+
+		// user can also type a title of new category
+		b.stateManager.SetStateData(ctx, userID, chatID, &UserStateData{
+			State:         StateEditingExpense,
+			EditExpenseID: expenseID,
+			EditField:     EditFieldCategory,
+		})
+
+		_, _ = botAPI.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			ReplyMarkup: editCategoryKeyboard(ctx, expenseID, NewCategories(saldoCategories)),
+		})
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "edit.choose_category"),
+		})
+	case "set":
+		if len(parts) < 4 {
+			return
+		}
+		b.applyExpenseEditChoice(ctx, botAPI, chatID, userID, user, expense, EditField(parts[2]), parts[3])
+	case "back":
+		b.stateManager.ClearState(ctx, userID, chatID)
+		_, _ = botAPI.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			ReplyMarkup: editExpenseKeyboard(ctx, expenseID),
+		})
+	case "done":
+		callbacksProcessed.WithLabelValues("edit_done").Inc()
+		b.stateManager.ClearState(ctx, userID, chatID)
+		_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: messageID,
+			Text:      tr(ctx, "edit.saved") + "\n\n" + formatExpenseDetails(ctx, *NewExpense(expense)),
+			ParseMode: models.ParseModeHTML,
+		})
+	}
+}
+
+// startEditInput switches user to waiting for text value of expense field
+func (b *Bot) startEditInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, expenseID int, field EditField, prompt string) {
+	b.stateManager.SetStateData(ctx, userID, chatID, &UserStateData{
+		State:         StateEditingExpense,
+		EditExpenseID: expenseID,
+		EditField:     field,
+	})
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      prompt,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+// applyExpenseEditChoice applies value chosen with inline keyboard
+func (b *Bot) applyExpenseEditChoice(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, expense *saldo.Expense, field EditField, value string) {
+	switch field {
+	case EditFieldCurrency:
+		if !slices.Contains(supportedCurrencies, value) {
+			return
+		}
+		expense.Currency = value
+	case EditFieldCategory:
+		categoryID, err := strconv.Atoi(value)
+		if err != nil {
+			return
+		}
+		category, err := b.saldo.GetLedgerCategory(ctx, user.LedgerID, categoryID)
+		if err != nil || category == nil || category.Kind != saldo.CategoryKindExpense {
+			return
+		}
+		expense.CategoryID = &category.ID
+	default:
+		return
+	}
+
+	b.saveEditedExpense(ctx, botAPI, chatID, userID, expense)
+}
+
+// handleEditInput handles text value for field of edited expense
+func (b *Bot) handleEditInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, stateData *UserStateData, text string) {
+	expense, err := b.saldo.GetExpense(ctx, user.LedgerID, stateData.EditExpenseID)
+	if err != nil || expense == nil {
+		b.stateManager.ClearState(ctx, userID, chatID)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr(ctx, "edit.not_found"),
+			ReplyMarkup: mainMenuKeyboard(ctx),
+		})
+		return
+	}
+
+	text = strings.TrimSpace(text)
+
+	switch stateData.EditField {
+	case EditFieldAmount:
+		amount, err := parseAmount(text)
+		if err != nil {
+			_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   tr(ctx, "amount.retry", trErr(ctx, err)),
+			})
+			return
+		}
+		expense.Amount = amount
+	case EditFieldDescription:
+		if text == "-" {
+			text = ""
+		}
+		expense.Description = text
+	case EditFieldCategory:
+		if text == "" {
+			return
+		}
+		category, err := b.saldo.FindOrCreateCategoryByTitle(ctx, user.LedgerID, user.ID, text)
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to find or create category", "err", err)
+			_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   tr(ctx, "edit.category_error"),
+			})
+			return
+		}
+		expense.CategoryID = &category.ID
+	default:
+		b.stateManager.ClearState(ctx, userID, chatID)
+		return
+	}
+
+	b.saveEditedExpense(ctx, botAPI, chatID, userID, expense)
+}
+
+// saveEditedExpense persists edited expense and shows edit menu again
+func (b *Bot) saveEditedExpense(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, expense *saldo.Expense) {
+	b.stateManager.ClearState(ctx, userID, chatID)
+
+	if err := b.saldo.UpdateExpense(ctx, expense); err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to update expense", "err", err, "expense_id", expense.ID)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "edit.save_error"),
+		})
+		return
+	}
+
+	expensesEdited.Inc()
+
+	// reload expense to get actual category
+	updated, err := b.getExpense(ctx, expense.LedgerID, expense.ID)
+	if err != nil || updated == nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get expense", "err", err, "expense_id", expense.ID)
+		return
+	}
+
+	b.showExpenseEditMenu(ctx, botAPI, chatID, updated)
+}
+
+// showExpenseEditMenu sends expense details with fields to change
+func (b *Bot) showExpenseEditMenu(ctx context.Context, botAPI *bot.Bot, chatID int64, expense *Expense) {
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "edit.title") + "\n\n" + formatExpenseDetails(ctx, *expense) + "\n\n" + tr(ctx, "edit.what"),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: editExpenseKeyboard(ctx, expense.ID),
+	})
+}
+
+// getExpense returns ledger's expense converted to telegram model
+func (b *Bot) getExpense(ctx context.Context, ledgerID, expenseID int) (*Expense, error) {
+	saldoExpense, err := b.saldo.GetExpense(ctx, ledgerID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewExpense(saldoExpense), nil
+}
+
+// formatExpenseDetails formats all fields of saved expense
+func formatExpenseDetails(ctx context.Context, exp Expense) string {
+	categoryName := "❓ " + tr(ctx, "no_category")
+	if exp.Category != nil {
+		categoryName = html.EscapeString(categoryTitle(*exp.Category))
+	}
+
+	description := html.EscapeString(exp.Description)
+	if description == "" {
+		description = "—"
+	}
+
+	return tr(ctx, "edit.details",
+		formatAmount(exp.Amount), getCurrencySymbol(exp.Currency), categoryName, description, FormatDate(ctx, exp.SpentAt))
+}
+
+// formatExpenseShort formats expense in one short line for button labels
+func formatExpenseShort(exp Expense) string {
+	title := exp.Description
+	if title == "" && exp.Category != nil {
+		title = exp.Category.Title
+	}
+
+	runes := []rune(title)
+	if len(runes) > 20 {
+		title = string(runes[:20]) + "…"
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", title, formatAmount(exp.Amount), getCurrencySymbol(exp.Currency)))
+}
+
+// parseAmount parses positive amount from user input and returns it in cents
+func parseAmount(s string) (int64, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", ",", ".").Replace(strings.TrimSpace(s))
+
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, i18n.Errorf("amount.invalid")
+	}
+	if amount <= 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return 0, i18n.Errorf("amount.not_positive")
+	}
+
+	return int64(math.Round(amount * 100)), nil
+}
+
+// formatSavedExpenses formats saved expenses one per line
+func formatSavedExpenses(ctx context.Context, expenses []Expense) string {
+	lines := make([]string, len(expenses))
+	for i, exp := range expenses {
+		categoryName := tr(ctx, "no_category")
+		if exp.Category != nil {
+			categoryName = exp.Category.Title
+		}
+		lines[i] = fmt.Sprintf("💰 %s %s — %s", formatAmount(exp.Amount), getCurrencySymbol(exp.Currency), html.EscapeString(categoryName))
+		if exp.Description != "" {
+			lines[i] += fmt.Sprintf(" (%s)", html.EscapeString(exp.Description))
+		}
+		for _, tag := range exp.Tags {
+			lines[i] += " #" + html.EscapeString(tag)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
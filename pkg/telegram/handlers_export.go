@@ -0,0 +1,130 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"saldo/pkg/services"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleExportPeriod asks user for file format of expenses export for period
+func (b *Bot) handleExportPeriod(ctx context.Context, botAPI *bot.Bot, chatID int64, period TimePeriod) {
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "export.choose_format", FormatPeriod(ctx, period)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: exportFormatKeyboard(period),
+	})
+}
+
+// handleExportAction sends document with user's expenses for period
+// Callback data format: export:<format>:<startUnix>:<endUnix>
+func (b *Bot) handleExportAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, value string) {
+	format, period, err := parseExportCallback(value)
+	if err != nil {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "export.invalid_format"),
+		})
+		return
+	}
+	callbacksProcessed.WithLabelValues("export_" + string(format)).Inc()
+
+	saldoExpenses, err := b.saldo.GetExpensesForPeriod(ctx, user.LedgerID, 0, period.Start, period.End)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get expenses for export", "err", err)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "export.load_error"),
+		})
+		return
+	}
+
+	expenses := NewExpenses(saldoExpenses)
+	if len(expenses) == 0 {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "export.empty"),
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := services.WriteExport(&buf, format, exportRows(ctx, expenses)); err != nil {
+		errorsTotal.WithLabelValues("export").Inc()
+		b.logger.Error(ctx, "failed to write export", "err", err, "format", format)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "export.file_error"),
+		})
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	filename := fmt.Sprintf("expenses_%s_%s.%s", period.Start.Format("20060102"), period.End.Format("20060102"), format)
+	_, err = botAPI.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID:   chatID,
+		Document: &models.InputFileUpload{Filename: filename, Data: &buf},
+		Caption:  tr(ctx, "export.caption", FormatPeriod(ctx, period), len(expenses)),
+	})
+	if err != nil {
+		errorsTotal.WithLabelValues("send_document").Inc()
+		b.logger.Error(ctx, "failed to send export", "err", err)
+	}
+}
+
+// parseExportCallback parses "<format>:<startUnix>:<endUnix>" of export callback
+func parseExportCallback(value string) (services.ExportFormat, TimePeriod, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return "", TimePeriod{}, fmt.Errorf("invalid export callback %q", value)
+	}
+
+	format := services.ExportFormat(parts[0])
+	if format != services.ExportCSV && format != services.ExportXLSX {
+		return "", TimePeriod{}, fmt.Errorf("unsupported export format %q", parts[0])
+	}
+
+	start, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", TimePeriod{}, err
+	}
+	end, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", TimePeriod{}, err
+	}
+
+	// callback keeps seconds only, so the end is extended to cover its last second
+	return format, TimePeriod{Start: time.Unix(start, 0), End: time.Unix(end, 0).Add(time.Second - 1)}, nil
+}
+
+// exportRows converts expenses to rows of exported table
+func exportRows(ctx context.Context, expenses []Expense) []services.ExportRow {
+	rows := make([]services.ExportRow, len(expenses))
+	for i, e := range expenses {
+		category := tr(ctx, "no_category")
+		if e.Category != nil {
+			category = e.Category.Title
+		}
+
+		rows[i] = services.ExportRow{
+			Date:        e.SpentAt.In(userLocation(ctx)),
+			Amount:      e.Amount,
+			Currency:    e.Currency,
+			Category:    category,
+			Description: e.Description,
+		}
+	}
+	return rows
+}
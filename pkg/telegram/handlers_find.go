@@ -0,0 +1,247 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// findPageSize is number of expenses on one page of search results
+const findPageSize = 10
+
+var (
+	// findTokenRegex splits search query into words, value of key:"..." may contain spaces
+	findTokenRegex = regexp.MustCompile(`[^\s:]+:"[^"]*"|\S+`)
+
+	// findAmountRegex matches amount condition, e.g. amount>1000 or сумма<=500
+	findAmountRegex = regexp.MustCompile(`(?i)^(amount|сумма)(>=|<=|>|<|=)(.+)$`)
+)
+
+// handleFindCommand handles /find command - searches ledger's expenses by words of description and filters
+func (b *Bot) handleFindCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("find").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	_, query, _ := strings.Cut(update.Message.Text, " ")
+	query = strings.TrimSpace(query)
+	if query == "" {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "find.usage"),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	text, markup, err := b.findScreen(ctx, user, query, 1)
+	if err != nil {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      text,
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	// query is kept to turn pages of results
+	stateData := b.stateManager.GetState(ctx, update.Message.From.ID, chatID)
+	stateData.FindQuery = query
+	b.stateManager.SetStateData(ctx, update.Message.From.ID, chatID, stateData)
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// handleFindAction turns page of search results
+// Callback data format: find:<page>
+func (b *Bot) handleFindAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	callbacksProcessed.WithLabelValues("find_page").Inc()
+
+	page, err := strconv.Atoi(value)
+	if err != nil || page < 1 {
+		return
+	}
+
+	query := b.stateManager.GetState(ctx, userID, chatID).FindQuery
+	if query == "" {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "find.expired"),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	text, markup, err := b.findScreen(ctx, user, query, page)
+	if err != nil {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "find.error"),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// findScreen builds page of search results. On error text is message for user.
+func (b *Bot) findScreen(ctx context.Context, user *User, query string, page int) (string, models.ReplyMarkup, error) {
+	filter, err := parseFindQuery(query, userNow(ctx))
+	if err != nil {
+		return trErr(ctx, err) + "\n\n" + tr(ctx, "find.usage"), nil, err
+	}
+
+	saldoExpenses, total, err := b.saldo.FindExpenses(ctx, user.LedgerID, filter, page, findPageSize)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to find expenses", "err", err, "ledger_id", user.LedgerID, "query", query)
+		return tr(ctx, "find.error"), nil, err
+	}
+
+	if total == 0 {
+		return tr(ctx, "find.empty", html.EscapeString(query)), nil, nil
+	}
+
+	pages := (total + findPageSize - 1) / findPageSize
+	lines := []string{tr(ctx, "find.results", html.EscapeString(query), total), ""}
+	for _, exp := range NewExpenses(saldoExpenses) {
+		lines = append(lines, formatFoundExpense(ctx, exp))
+	}
+	if pages > 1 {
+		lines = append(lines, "", tr(ctx, "find.page", page, pages))
+	}
+
+	return strings.Join(lines, "\n"), findPagerKeyboard(page, pages), nil
+}
+
+// parseFindQuery parses search query: words are searched in descriptions, #tag and key:value words filter expenses.
+// Supported filters: amount>1000, amount<500, amount=300, category:Еда, currency:USD, after:01.09, before:30.09.2025, tag:отпуск.
+// Dates are in time zone of now, year of now is used if it is omitted.
+func parseFindQuery(query string, now time.Time) (saldo.ExpenseFilter, error) {
+	var (
+		filter saldo.ExpenseFilter
+		words  []string
+	)
+
+	for _, token := range findTokenRegex.FindAllString(query, -1) {
+		if strings.HasPrefix(token, "#") {
+			if tags := saldo.NormalizeTags([]string{token}); len(tags) > 0 {
+				filter.Tag = tags[0]
+			}
+			continue
+		}
+
+		if matches := findAmountRegex.FindStringSubmatch(token); matches != nil {
+			amount, err := parseAmount(matches[3])
+			if err != nil {
+				return filter, err
+			}
+			switch matches[2] {
+			case ">", ">=":
+				filter.AmountFrom = &amount
+			case "<", "<=":
+				filter.AmountTo = &amount
+			default:
+				filter.AmountFrom, filter.AmountTo = &amount, &amount
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(token, ":")
+		value = strings.Trim(value, `"`)
+		if !ok || value == "" {
+			words = append(words, token)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "category", "категория":
+			filter.Category = value
+		case "currency", "валюта":
+			currency := strings.ToUpper(value)
+			if currency == "₽" || currency == "РУБ" {
+				currency = "RUB"
+			}
+			if !slices.Contains(supportedCurrencies, currency) {
+				return filter, i18n.Errorf("format.unsupported_currency", value)
+			}
+			filter.Currency = currency
+		case "tag", "тег":
+			if tags := saldo.NormalizeTags([]string{value}); len(tags) > 0 {
+				filter.Tag = tags[0]
+			}
+		case "after", "после":
+			date, err := parseDate(value, now)
+			if err != nil {
+				return filter, err
+			}
+			filter.From = &date
+		case "before", "до":
+			date, err := parseDate(value, now)
+			if err != nil {
+				return filter, err
+			}
+			end := date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			filter.To = &end
+		default:
+			words = append(words, token)
+		}
+	}
+
+	filter.Text = strings.Join(words, " ")
+
+	return filter, nil
+}
+
+// formatFoundExpense formats expense of search results in one line with its date
+func formatFoundExpense(ctx context.Context, exp Expense) string {
+	line := fmt.Sprintf("<code>%s</code> %s %s", FormatDate(ctx, exp.SpentAt), formatAmount(exp.Amount), getCurrencySymbol(exp.Currency))
+	if exp.Category != nil {
+		line += " — " + html.EscapeString(categoryTitle(*exp.Category))
+	}
+	if exp.Description != "" {
+		line += " (" + html.EscapeString(exp.Description) + ")"
+	}
+	for _, tag := range exp.Tags {
+		line += " #" + html.EscapeString(tag)
+	}
+	return line
+}
@@ -0,0 +1,101 @@
+package telegram
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+)
+
+func TestParseFindQuery(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 10, 5, 12, 0, 0, 0, moscow)
+	cents := func(v int64) *int64 { return &v }
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, moscow)
+		return &d
+	}
+	dayEnd := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 23, 59, 59, 999999999, moscow)
+		return &d
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    saldo.ExpenseFilter
+		wantErr string // key of i18n error
+	}{
+		{
+			name:  "words only",
+			query: "такси  домой",
+			want:  saldo.ExpenseFilter{Text: "такси домой"},
+		},
+		{
+			name:  "amount range",
+			query: "amount>1000 сумма<=2500,50 кофе",
+			want:  saldo.ExpenseFilter{Text: "кофе", AmountFrom: cents(100000), AmountTo: cents(250050)},
+		},
+		{
+			name:  "exact amount",
+			query: "amount=300",
+			want:  saldo.ExpenseFilter{AmountFrom: cents(30000), AmountTo: cents(30000)},
+		},
+		{
+			name:  "quoted category and currency",
+			query: `category:"Кафе и рестораны" валюта:usd`,
+			want:  saldo.ExpenseFilter{Category: "Кафе и рестораны", Currency: "USD"},
+		},
+		{
+			name:  "ruble sign",
+			query: "currency:₽",
+			want:  saldo.ExpenseFilter{Currency: "RUB"},
+		},
+		{
+			name:  "tags",
+			query: "#Отпуск tag:море",
+			want:  saldo.ExpenseFilter{Tag: "море"},
+		},
+		{
+			name:  "dates in time zone of now",
+			query: "after:01.09 before:30.09.2025",
+			want:  saldo.ExpenseFilter{From: date(2025, 9, 1), To: dayEnd(2025, 9, 30)},
+		},
+		{
+			name:  "unknown key and empty value are words",
+			query: "note:x after: 12:30",
+			want:  saldo.ExpenseFilter{Text: "note:x after: 12:30"},
+		},
+		{name: "invalid amount", query: "amount>много", wantErr: "amount.invalid"},
+		{name: "zero amount", query: "amount<0", wantErr: "amount.not_positive"},
+		{name: "unsupported currency", query: "currency:XYZ", wantErr: "format.unsupported_currency"},
+		{name: "invalid date", query: "after:вчера", wantErr: "date.invalid_date"},
+		{name: "nonexistent date", query: "before:31.02", wantErr: "date.nonexistent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFindQuery(tt.query, now)
+			if tt.wantErr != "" {
+				var i18nErr *i18n.Error
+				if !errors.As(err, &i18nErr) || i18nErr.Key != tt.wantErr {
+					t.Fatalf("parseFindQuery() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFindQuery() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFindQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
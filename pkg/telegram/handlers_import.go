@@ -0,0 +1,453 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+	"saldo/pkg/services"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// maxStatementSize is the largest statement file accepted for import
+	maxStatementSize = 5 << 20
+
+	// maxStatementLines is the largest number of payments imported at once, every line is categorized by LLM
+	maxStatementLines = 300
+
+	// statementProgressInterval is how often message about categorization progress is updated
+	statementProgressInterval = 3 * time.Second
+)
+
+// handleStatement parses uploaded bank statement, categorizes its payments and asks for confirmation
+func (b *Bot) handleStatement(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, document *models.Document) {
+	messagesProcessed.WithLabelValues("document").Inc()
+
+	if document.FileSize > maxStatementSize {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "import.too_large"),
+		})
+		return
+	}
+
+	data, err := b.downloadTgFileData(ctx, botAPI, document.FileID, maxStatementSize)
+	if err != nil {
+		errorsTotal.WithLabelValues("download_file").Inc()
+		b.logger.Error(ctx, "failed to download statement", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "import.download_error"),
+		})
+		return
+	}
+
+	lines, source, err := services.ParseStatement(document.FileName, data, b.csvMappings, userLocation(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("statement_parse").Inc()
+		b.logger.Print(ctx, "failed to parse statement", "err", err, "file", document.FileName)
+		text := tr(ctx, "import.parse_error", err)
+		if errors.Is(err, services.ErrUnknownStatement) {
+			text = tr(ctx, "import.unknown_format")
+		}
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		return
+	}
+
+	lines, duplicates, err := b.skipImportedLines(ctx, user, lines)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to check imported expenses", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "import.check_error"),
+		})
+		return
+	}
+
+	if len(lines) == 0 {
+		text := tr(ctx, "import.no_expenses")
+		if duplicates > 0 {
+			text = tr(ctx, "import.all_duplicates", duplicates)
+		}
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		return
+	}
+
+	if len(lines) > maxStatementLines {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "import.too_many", len(lines), maxStatementLines),
+		})
+		return
+	}
+
+	// every payment is categorized by LLM, that takes minutes for long statements,
+	// so user can not upload another statement until this one is categorized
+	if _, busy := b.importing.LoadOrStore(userID, struct{}{}); busy {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "import.in_progress"),
+		})
+		return
+	}
+	defer b.importing.Delete(userID)
+
+	msg, err := botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   tr(ctx, "import.categorizing", source, len(lines)),
+	})
+	if err != nil {
+		b.logger.Error(ctx, "failed to send message", "err", err)
+		return
+	}
+
+	b.importStatement(ctx, botAPI, chatID, userID, user, lines, duplicates, source, msg.ID)
+}
+
+// importStatement categorizes statement payments, reports progress by editing message with messageID
+// and replaces it with confirmation when all payments are categorized
+func (b *Bot) importStatement(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, lines []services.StatementLine, duplicates int, source string, messageID int) {
+	lastProgress := time.Now()
+	progress := func(done int) {
+		if time.Since(lastProgress) < statementProgressInterval {
+			return
+		}
+		lastProgress = time.Now()
+		_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: messageID,
+			Text:      tr(ctx, "import.progress", source, done, len(lines)),
+		})
+	}
+
+	expenses, err := b.categorizeStatement(ctx, user, lines, progress)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "categories.get_error"),
+		})
+		return
+	}
+
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+	stateData.ExpensesData = expenses
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
+
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        formatImportSummary(ctx, expenses, duplicates, b.currentRates(ctx)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: importConfirmKeyboard(ctx),
+	})
+}
+
+// skipImportedLines removes statement lines that were already imported and returns number of removed ones
+func (b *Bot) skipImportedLines(ctx context.Context, user *User, lines []services.StatementLine) ([]services.StatementLine, int, error) {
+	if len(lines) == 0 {
+		return lines, 0, nil
+	}
+
+	from, to := lines[0].Date, lines[0].Date
+	for _, line := range lines {
+		if line.Date.Before(from) {
+			from = line.Date
+		}
+		if line.Date.After(to) {
+			to = line.Date
+		}
+	}
+
+	saldoExpenses, err := b.saldo.GetExpensesForPeriod(ctx, user.LedgerID, 0, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	type key struct {
+		date        int64
+		amount      int64
+		currency    string
+		description string
+	}
+	existing := make(map[key]int)
+	for _, e := range saldoExpenses {
+		existing[key{e.SpentAt.Unix(), e.Amount, e.Currency, e.Description}]++
+	}
+
+	result := make([]services.StatementLine, 0, len(lines))
+	for _, line := range lines {
+		k := key{line.Date.Unix(), line.Amount, line.Currency, line.Description}
+		if existing[k] > 0 {
+			existing[k]--
+			continue
+		}
+		result = append(result, line)
+	}
+
+	return result, len(lines) - len(result), nil
+}
+
+// categorizeStatement chooses category of every payment by its merchant text with LLM.
+// Payments that LLM failed to categorize are kept without category.
+// Progress is called with number of categorized payments after every LLM call.
+func (b *Bot) categorizeStatement(ctx context.Context, user *User, lines []services.StatementLine, progress func(done int)) ([]ExpenseData, error) {
+	saldoCategories, err := b.saldo.GetCategories(ctx, user.LedgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := NewCategories(saldoCategories)
+	categoryNames := make([]string, 0, len(categories))
+	for _, cat := range categories {
+		categoryNames = append(categoryNames, cat.Title)
+	}
+	promptNames := categoryPromptNames(categories)
+
+	// the same merchant is usually repeated in statement, so it is categorized once
+	merchants := make(map[string]string)
+	expenses := make([]ExpenseData, len(lines))
+	for i, line := range lines {
+		category, ok := merchants[line.Description]
+		if !ok && line.Description != "" {
+			category = b.categorizeMerchant(ctx, line, promptNames)
+			merchants[line.Description] = category
+
+			if category != "" && !containsFold(categoryNames, category) {
+				categoryNames = append(categoryNames, category)
+				promptNames = append(promptNames, category)
+			}
+			progress(i + 1)
+		}
+
+		expenses[i] = ExpenseData{
+			Amount:      line.Amount,
+			Currency:    line.Currency,
+			Category:    category,
+			Description: line.Description,
+			Date:        line.Date,
+		}
+	}
+
+	return expenses, nil
+}
+
+// categorizeMerchant returns category of payment chosen by LLM or empty string
+func (b *Bot) categorizeMerchant(ctx context.Context, line services.StatementLine, categoryNames []string) string {
+	startTime := time.Now()
+	parsed, err := b.llm.ParseExpenses(ctx, fmt.Sprintf("%s %s %s", line.Description, formatAmount(line.Amount), line.Currency), i18n.FromContext(ctx), line.Date, line.Currency, categoryNames, nil)
+	llmParseDuration.Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		errorsTotal.WithLabelValues("llm_parse").Inc()
+		b.logger.Error(ctx, "failed to categorize statement line", "err", err, "description", line.Description)
+		return ""
+	}
+
+	for _, p := range parsed {
+		if !p.IsIncome() && p.Category != "" {
+			return p.Category
+		}
+	}
+
+	return ""
+}
+
+// handleImportAction handles confirmation of statement import
+// Callback data format: import:confirm or import:cancel
+func (b *Bot) handleImportAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, action string) {
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+
+	switch action {
+	case "cancel":
+		callbacksProcessed.WithLabelValues("import_cancel").Inc()
+		b.stateManager.ClearState(ctx, userID, chatID)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: callback.Message.Message.ID,
+			Text:      tr(ctx, "import.cancelled"),
+		})
+	case "confirm":
+		callbacksProcessed.WithLabelValues("import_confirm").Inc()
+		if len(stateData.ExpensesData) == 0 {
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "confirm.expired"),
+			})
+			_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+				ChatID:    chatID,
+				MessageID: callback.Message.Message.ID,
+				Text:      tr(ctx, "import.expired"),
+			})
+			return
+		}
+
+		list := make([]saldo.ImportedExpense, len(stateData.ExpensesData))
+		for i, e := range stateData.ExpensesData {
+			list[i] = saldo.ImportedExpense{
+				Date:        e.Date,
+				Amount:      e.Amount,
+				Currency:    e.Currency,
+				Category:    e.Category,
+				Description: e.Description,
+			}
+		}
+
+		saldoExpenses, err := b.saldo.ImportExpenses(ctx, user.LedgerID, user.ID, list)
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to import expenses", "err", err)
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "expenses.save_error"),
+				ShowAlert:       true,
+			})
+			return
+		}
+
+		b.stateManager.ClearState(ctx, userID, chatID)
+		expensesCreated.Add(float64(len(saldoExpenses)))
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "import.done"),
+		})
+
+		created := NewExpenses(saldoExpenses)
+		_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: callback.Message.Message.ID,
+			Text: tr(ctx, "import.saved",
+				len(created), formatTotalExpenses(calculateTotalExpenses(created), b.currentRates(ctx))),
+			ParseMode: models.ParseModeHTML,
+		})
+
+		b.notifyBudgets(ctx, botAPI, chatID, user, created)
+	}
+}
+
+// formatImportSummary formats statement payments grouped by category for confirmation
+func formatImportSummary(ctx context.Context, expenses []ExpenseData, duplicates int, rates saldo.Rates) string {
+	from, to := expenses[0].Date, expenses[0].Date
+	totals := make(map[string]int64)
+	type categoryTotal struct {
+		title  string
+		count  int
+		totals map[string]int64
+	}
+	byCategory := make(map[string]*categoryTotal)
+
+	for _, e := range expenses {
+		if e.Date.Before(from) {
+			from = e.Date
+		}
+		if e.Date.After(to) {
+			to = e.Date
+		}
+		totals[e.Currency] += e.Amount
+
+		title := e.Category
+		if title == "" {
+			title = tr(ctx, "no_category")
+		}
+		ct, ok := byCategory[title]
+		if !ok {
+			ct = &categoryTotal{title: title, totals: make(map[string]int64)}
+			byCategory[title] = ct
+		}
+		ct.count++
+		ct.totals[e.Currency] += e.Amount
+	}
+
+	categories := make([]*categoryTotal, 0, len(byCategory))
+	for _, ct := range byCategory {
+		categories = append(categories, ct)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].count != categories[j].count {
+			return categories[i].count > categories[j].count
+		}
+		return categories[i].title < categories[j].title
+	})
+
+	var sb strings.Builder
+	sb.WriteString(tr(ctx, "import.confirm") + "\n\n")
+	fmt.Fprintf(&sb, "📅 %s\n", FormatPeriod(ctx, TimePeriod{Start: from, End: to}))
+	sb.WriteString(tr(ctx, "import.count", len(expenses)) + "\n")
+	if duplicates > 0 {
+		sb.WriteString(tr(ctx, "import.skipped", duplicates) + "\n")
+	}
+	sb.WriteString(tr(ctx, "stats.total", formatTotalExpenses(totals, rates)) + "\n")
+
+	sb.WriteString(tr(ctx, "import.by_category") + "\n")
+	for _, ct := range categories {
+		sb.WriteString(tr(ctx, "import.category_line", html.EscapeString(ct.title), formatTotalExpenses(ct.totals, rates), ct.count) + "\n")
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// downloadTgFileData downloads Telegram file by file ID into memory, files larger than maxSize are rejected
+func (b *Bot) downloadTgFileData(ctx context.Context, botAPI *bot.Bot, fileID string, maxSize int64) ([]byte, error) {
+	file, err := botAPI.GetFile(ctx, &bot.GetFileParams{
+		FileID: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, botAPI.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxSize)
+	}
+
+	return data, nil
+}
+
+// containsFold reports whether list contains s ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
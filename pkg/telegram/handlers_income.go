@@ -0,0 +1,189 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleIncomeAction handles deletion and restoring of incomes
+// Callback data format: income:delete:<incomeID> or income:restore:<incomeID>
+func (b *Bot) handleIncomeAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, value string) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return
+	}
+
+	incomeID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+
+	switch parts[0] {
+	case "delete":
+		callbacksProcessed.WithLabelValues("income_delete").Inc()
+		saldoIncome, err := b.saldo.DeleteIncome(ctx, user.LedgerID, incomeID)
+		if err != nil || saldoIncome == nil {
+			if err != nil {
+				errorsTotal.WithLabelValues("database").Inc()
+				b.logger.Error(ctx, "failed to delete income", "err", err, "income_id", incomeID)
+			}
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "income.already_deleted"),
+			})
+			return
+		}
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "income.deleted"),
+		})
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr(ctx, "income.deleted_details") + "\n\n" + formatSavedIncomes(ctx, []Income{*NewIncome(saldoIncome)}),
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: restoreIncomeKeyboard(ctx, saldoIncome.ID),
+		})
+	case "restore":
+		callbacksProcessed.WithLabelValues("income_restore").Inc()
+		saldoIncome, err := b.saldo.RestoreIncome(ctx, user.LedgerID, incomeID)
+		if err != nil || saldoIncome == nil {
+			if err != nil {
+				errorsTotal.WithLabelValues("database").Inc()
+				b.logger.Error(ctx, "failed to restore income", "err", err, "income_id", incomeID)
+			}
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "income.already_restored"),
+			})
+			return
+		}
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "income.restored"),
+		})
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "income.restored_details") + "\n\n" + formatSavedIncomes(ctx, []Income{*NewIncome(saldoIncome)}),
+			ParseMode: models.ParseModeHTML,
+		})
+	}
+}
+
+// getIncomesForPeriod returns ledger's incomes created within period, zero memberID returns incomes of all members
+func (b *Bot) getIncomesForPeriod(ctx context.Context, ledgerID, memberID int, period TimePeriod) ([]Income, error) {
+	incomes, err := b.saldo.GetIncomesForPeriod(ctx, ledgerID, memberID, period.Start, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIncomes(incomes), nil
+}
+
+// formatSavedIncomes formats saved incomes one per line
+func formatSavedIncomes(ctx context.Context, incomes []Income) string {
+	lines := make([]string, len(incomes))
+	for i, inc := range incomes {
+		categoryName := tr(ctx, "no_category")
+		if inc.Category != nil {
+			categoryName = inc.Category.Title
+		}
+		lines[i] = fmt.Sprintf("📈 +%s %s — %s", formatAmount(inc.Amount), getCurrencySymbol(inc.Currency), html.EscapeString(categoryName))
+		if inc.Description != "" {
+			lines[i] += fmt.Sprintf(" (%s)", html.EscapeString(inc.Description))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatIncomeShort formats income in one short line for button labels
+func formatIncomeShort(inc Income) string {
+	return formatExpenseShort(Expense{
+		Amount:      inc.Amount,
+		Currency:    inc.Currency,
+		Description: inc.Description,
+		Category:    inc.Category,
+	})
+}
+
+// groupIncomesByCategory groups incomes by category and currency the same way as expenses
+func groupIncomesByCategory(ctx context.Context, incomes []Income) map[string]*CategoryStats {
+	entries := make([]Expense, len(incomes))
+	for i, inc := range incomes {
+		entries[i] = Expense{Amount: inc.Amount, Currency: inc.Currency, Category: inc.Category}
+	}
+
+	categoryMap, _ := groupExpensesByCategory(ctx, entries, nil)
+	return categoryMap
+}
+
+// calculateTotalIncomes calculates total incomes grouped by currency
+func calculateTotalIncomes(incomes []Income) map[string]int64 {
+	totals := make(map[string]int64)
+	for _, inc := range incomes {
+		totals[inc.Currency] += inc.Amount
+	}
+	return totals
+}
+
+// formatIncomeSummary formats total incomes and net balance lines for statistics
+func formatIncomeSummary(ctx context.Context, incomes []Income, expenseTotals map[string]int64, rates saldo.Rates) string {
+	incomeTotals := calculateTotalIncomes(incomes)
+
+	return tr(ctx, "stats.income_balance",
+		formatTotalExpenses(incomeTotals, rates), formatBalance(incomeTotals, expenseTotals, rates))
+}
+
+// formatBalance formats incomes minus expenses by currency with explicit sign, currencies are sorted by rate
+func formatBalance(incomes, expenses map[string]int64, rates saldo.Rates) string {
+	balance := make(map[string]int64, len(incomes)+len(expenses))
+	for currency, amount := range incomes {
+		balance[currency] += amount
+	}
+	for currency, amount := range expenses {
+		balance[currency] -= amount
+	}
+
+	currencies := make([]string, 0, len(balance))
+	for currency := range balance {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		ri, rj := valueInRubles(rates, 100, currencies[i]), valueInRubles(rates, 100, currencies[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return currencies[i] < currencies[j]
+	})
+
+	parts := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		amount, sign := balance[currency], "+"
+		if amount < 0 {
+			amount, sign = -amount, "−"
+		}
+		parts = append(parts, fmt.Sprintf("%s%s %s", sign, formatAmount(amount), getCurrencyWithFlag(currency)))
+	}
+
+	return strings.Join(parts, " / ")
+}
+
+// currencyFrequencyOf counts incomes by currency
+func currencyFrequencyOf(incomes []Income) map[string]int {
+	frequency := make(map[string]int)
+	for _, inc := range incomes {
+		frequency[inc.Currency]++
+	}
+	return frequency
+}
@@ -0,0 +1,81 @@
+package telegram
+
+import (
+	"context"
+
+	"saldo/pkg/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleLanguageCommand handles /language command - shows interface language selection
+func (b *Bot) handleLanguageCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("language").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "language.current", i18n.Name(user.Language)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: languageKeyboard(user.Language),
+	})
+}
+
+// handleLanguageAction handles interface language selection
+// Callback data format: language:<code>
+func (b *Bot) handleLanguageAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, code string) {
+	callbacksProcessed.WithLabelValues("language").Inc()
+	lang := i18n.Lang(code)
+	if i18n.Parse(code) != lang {
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "language.unsupported"),
+		})
+		return
+	}
+
+	if err := b.saldo.SetUserLanguage(ctx, user.ID, code); err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to set language", "err", err)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "language.save_error"),
+		})
+		return
+	}
+
+	// reply in just selected language
+	ctx = i18n.WithLang(ctx, lang)
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        tr(ctx, "language.current", i18n.Name(lang)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: languageKeyboard(lang),
+	})
+
+	// reply keyboard is sent again to translate its buttons
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "language.saved"),
+		ReplyMarkup: b.stateManager.GetCurrentKeyboard(ctx, callback.From.ID, chatID),
+	})
+}
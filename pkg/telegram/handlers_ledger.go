@@ -0,0 +1,311 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// invitePrefix is prefix of /start payload that adds user to shared ledger
+const invitePrefix = "join_"
+
+// handleJoinLedger adds user to ledger of invite code from /start payload.
+// In private chat the ledger becomes the one that user's expenses are written to.
+func (b *Bot) handleJoinLedger(ctx context.Context, botAPI *bot.Bot, chat models.Chat, user *User, code string) {
+	ledger, err := b.saldo.GetLedgerByInviteCode(ctx, code)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get ledger by invite code", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chat.ID,
+			Text:   tr(ctx, "ledger.load_error"),
+		})
+		return
+	} else if ledger == nil {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chat.ID,
+			Text:   tr(ctx, "ledger.invite_invalid"),
+		})
+		return
+	}
+
+	if _, err := b.saldo.AddLedgerMember(ctx, ledger.ID, user.ID); err != nil {
+		b.ledgerSaveError(ctx, botAPI, chat.ID, err)
+		return
+	}
+	if chat.Type == models.ChatTypePrivate {
+		if err := b.saldo.SetUserLedger(ctx, user.ID, &ledger.ID); err != nil {
+			b.ledgerSaveError(ctx, botAPI, chat.ID, err)
+			return
+		}
+	}
+
+	b.logger.Print(ctx, "user joined ledger", "user_id", user.ID, "ledger_id", ledger.ID)
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chat.ID,
+		Text:        tr(ctx, "ledger.joined", html.EscapeString(ledger.Title)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: mainMenuKeyboard(ctx),
+	})
+}
+
+// handleLedgerCommand handles /ledger command - shows ledger of chat, its members and invite link
+func (b *Bot) handleLedgerCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("ledger").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	if !user.SharedLedger {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "ledger.personal"),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	ledger, err := b.saldo.GetLedgerByID(ctx, user.LedgerID)
+	if err != nil || ledger == nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get ledger", "err", err, "ledger_id", user.LedgerID)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "ledger.load_error"),
+		})
+		return
+	}
+
+	members, err := b.getLedgerMembers(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get ledger members", "err", err, "ledger_id", user.LedgerID)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "ledger.load_error"),
+		})
+		return
+	}
+
+	lines := []string{tr(ctx, "ledger.shared", html.EscapeString(ledger.Title)), "", tr(ctx, "ledger.members")}
+	for _, member := range members {
+		lines = append(lines, "• "+html.EscapeString(member.DisplayName()))
+	}
+	if b.username != "" {
+		link := fmt.Sprintf("https://t.me/%s?start=%s%s", b.username, invitePrefix, ledger.InviteCode)
+		lines = append(lines, "", tr(ctx, "ledger.invite", link))
+	}
+
+	// private chat may leave shared ledger, group chat always writes to ledger of group
+	var markup models.ReplyMarkup
+	if update.Message.Chat.Type == models.ChatTypePrivate {
+		markup = ledgerKeyboard(ctx)
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        strings.Join(lines, "\n"),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// handleLedgerAction handles ledger menu of private chat
+// Callback data format: ledger:personal
+func (b *Bot) handleLedgerAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	if value != "personal" {
+		return
+	}
+	callbacksProcessed.WithLabelValues("ledger_personal").Inc()
+
+	if err := b.saldo.SetUserLedger(ctx, user.ID, nil); err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to switch ledger", "err", err)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "ledger.save_error"),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	// statistics filter and pending expenses belong to previous ledger
+	b.stateManager.ClearState(ctx, userID, chatID)
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
+		Text:      tr(ctx, "ledger.switched_personal"),
+	})
+}
+
+// handleMemberAction selects ledger member whose statistics are shown
+// Callback data format: member:<userId>, zero is all members
+func (b *Bot) handleMemberAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	callbacksProcessed.WithLabelValues("stats_member").Inc()
+
+	memberID, err := strconv.Atoi(value)
+	if err != nil {
+		return
+	}
+
+	members, err := b.getLedgerMembers(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get ledger members", "err", err, "ledger_id", user.LedgerID)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "ledger.load_error"),
+		})
+		return
+	}
+
+	var memberName string
+	for _, member := range members {
+		if member.ID == memberID {
+			memberName = member.DisplayName()
+		}
+	}
+	if memberID != 0 && memberName == "" {
+		return
+	}
+
+	stateData := b.stateManager.GetState(ctx, userID, chatID)
+	stateData.MemberID, stateData.MemberName = memberID, memberName
+	b.stateManager.SetStateData(ctx, userID, chatID, stateData)
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		ReplyMarkup: memberFilterKeyboard(ctx, members, memberID),
+	})
+}
+
+// sendMemberFilter offers to show statistics of shared ledger for one member
+func (b *Bot) sendMemberFilter(ctx context.Context, botAPI *bot.Bot, chatID int64, user *User, selected int) {
+	members, err := b.getLedgerMembers(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get ledger members", "err", err, "ledger_id", user.LedgerID)
+		return
+	} else if len(members) < 2 {
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "stats.member_choose"),
+		ReplyMarkup: memberFilterKeyboard(ctx, members, selected),
+	})
+}
+
+// getLedgerMembers returns members of ledger converted to telegram model
+func (b *Bot) getLedgerMembers(ctx context.Context, ledgerID int) ([]User, error) {
+	saldoMembers, err := b.saldo.GetLedgerMembers(ctx, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]User, len(saldoMembers))
+	for i := range saldoMembers {
+		members[i] = *NewUser(&saldoMembers[i])
+	}
+
+	return members, nil
+}
+
+// ledgerSaveError tells user that joining ledger failed
+func (b *Bot) ledgerSaveError(ctx context.Context, botAPI *bot.Bot, chatID int64, err error) {
+	errorsTotal.WithLabelValues("database").Inc()
+	b.logger.Error(ctx, "failed to join ledger", "err", err)
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   tr(ctx, "ledger.save_error"),
+	})
+}
+
+// formatStatsPeriod formats period of statistics and member it is shown for
+func formatStatsPeriod(ctx context.Context, period TimePeriod, stateData *UserStateData) string {
+	text := fmt.Sprintf("<i>%s</i>\n", FormatPeriod(ctx, period))
+	if stateData.MemberID != 0 {
+		text += tr(ctx, "stats.member", html.EscapeString(stateData.MemberName)) + "\n"
+	}
+	return text
+}
+
+// isGroupChat reports whether chat is a group, Telegram IDs of group chats are negative
+func isGroupChat(chatID int64) bool {
+	return chatID < 0
+}
+
+// acceptsExpenseInput reports whether message is parsed as expense.
+// In group chat only messages that mention bot, reply to it or follow ➕ button are parsed,
+// so ordinary conversation of members gets no replies and does not spend LLM requests.
+func (b *Bot) acceptsExpenseInput(message *models.Message, stateData *UserStateData) bool {
+	if !isGroupChat(message.Chat.ID) || stateData.State == StateAwaitingExpense {
+		return true
+	}
+
+	if reply := message.ReplyToMessage; reply != nil && reply.From != nil && reply.From.IsBot && reply.From.Username == b.username {
+		return true
+	}
+
+	mention := b.botMention()
+	return mention != nil && mention.MatchString(message.Text+" "+message.Caption)
+}
+
+// botMention returns pattern of bot mention in message text, nil until username of bot is known
+func (b *Bot) botMention() *regexp.Regexp {
+	if b.username == "" {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)@` + regexp.QuoteMeta(b.username) + `\b`)
+}
+
+// stripBotMention removes mention of bot from text of group message
+func (b *Bot) stripBotMention(text string) string {
+	mention := b.botMention()
+	if mention == nil {
+		return text
+	}
+	return strings.TrimSpace(mention.ReplaceAllString(text, ""))
+}
+
+// clearExpenseInput drops not confirmed expenses before new input, in group chat ➕ button is good for one message
+func (b *Bot) clearExpenseInput(ctx context.Context, userID, chatID int64, stateData *UserStateData) {
+	if stateData.ExpensesData != nil || (isGroupChat(chatID) && stateData.State == StateAwaitingExpense) {
+		b.stateManager.ClearState(ctx, userID, chatID)
+	}
+}
+
+// expenseReplyKeyboard returns main menu keyboard for replies to expense input, group chats get no reply keyboard
+func expenseReplyKeyboard(ctx context.Context, chatID int64) models.ReplyMarkup {
+	if isGroupChat(chatID) {
+		return nil
+	}
+	return mainMenuKeyboard(ctx)
+}
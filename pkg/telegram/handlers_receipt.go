@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // photos are sent as JPEG
+	_ "image/png"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+	"saldo/pkg/services"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// maxReceiptPhotoSize is the largest photo downloaded for QR code recognition
+const maxReceiptPhotoSize = 10 << 20
+
+// handleReceipt reads fiscal receipt QR code from photo and asks to confirm expense of receipt.
+// Caption of photo is parsed by LLM to choose category and description.
+func (b *Bot) handleReceipt(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, message *models.Message) {
+	messagesProcessed.WithLabelValues("photo").Inc()
+
+	// sizes are sorted ascending, the largest one is the most readable
+	photo := message.Photo[len(message.Photo)-1]
+	if photo.FileSize > maxReceiptPhotoSize {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "receipt.too_large"),
+		})
+		return
+	}
+
+	data, err := b.downloadTgFileData(ctx, botAPI, photo.FileID, maxReceiptPhotoSize)
+	if err != nil {
+		errorsTotal.WithLabelValues("download_file").Inc()
+		b.logger.Error(ctx, "failed to download photo", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "receipt.download_error"),
+		})
+		return
+	}
+
+	receipt, err := readReceipt(data, userLocation(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("receipt_decode").Inc()
+		b.logger.Print(ctx, "failed to read receipt", "err", err)
+
+		text := tr(ctx, "receipt.read_error", err)
+		switch {
+		case errors.Is(err, services.ErrQRNotFound):
+			text = tr(ctx, "receipt.qr_not_found")
+		case errors.Is(err, services.ErrNotReceipt):
+			text = tr(ctx, "receipt.not_receipt")
+		case errors.Is(err, services.ErrReceiptNotPurchase):
+			text = tr(ctx, "receipt.not_purchase")
+		}
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		return
+	}
+
+	expense := services.ParsedExpense{
+		Type:        services.OperationExpense,
+		Amount:      float64(receipt.Amount) / 100,
+		Currency:    "RUB",
+		Description: tr(ctx, "receipt.description", receipt.Date.Format("02.01.06 15:04")),
+		Date:        receipt.Date,
+	}
+	if message.Caption != "" {
+		b.describeReceipt(ctx, user, message.Caption, receipt.Amount, &expense)
+	}
+
+	b.showExpenseConfirmation(ctx, botAPI, chatID, userID, []services.ParsedExpense{expense})
+}
+
+// readReceipt decodes image and reads fiscal receipt of its QR code, receipt time is in loc
+func readReceipt(data []byte, loc *time.Location) (*services.Receipt, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	text, err := services.ReadQR(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.ParseReceiptQR(text, loc)
+}
+
+// describeReceipt sets category and description of receipt expense from photo caption parsed by LLM.
+// Amount of receipt is kept, caption is only a hint what was bought. Hashtags of caption become tags of expense.
+func (b *Bot) describeReceipt(ctx context.Context, user *User, caption string, amount int64, expense *services.ParsedExpense) {
+	caption, expense.Tags = saldo.ParseTags(caption)
+
+	saldoCategories, err := b.saldo.GetCategories(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("get_categories").Inc()
+		b.logger.Error(ctx, "failed to get categories", "err", err)
+		return
+	}
+
+	categoryNames := categoryPromptNames(NewCategories(saldoCategories))
+
+	startTime := time.Now()
+	parsed, err := b.llm.ParseExpenses(ctx, fmt.Sprintf("%s %s RUB", caption, formatAmount(amount)), i18n.FromContext(ctx), userNow(ctx), saldo.CurrencyRUB, categoryNames, nil)
+	llmParseDuration.Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		errorsTotal.WithLabelValues("llm_parse").Inc()
+		b.logger.Error(ctx, "failed to parse receipt caption", "err", err)
+		return
+	}
+
+	for _, p := range parsed {
+		if p.IsIncome() {
+			continue
+		}
+		expense.Category = p.Category
+		if p.Description != "" {
+			expense.Description = p.Description
+		}
+		return
+	}
+}
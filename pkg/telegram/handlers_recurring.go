@@ -0,0 +1,267 @@
+package telegram
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// recurringCheckInterval is how often due recurring expenses are charged
+const recurringCheckInterval = 5 * time.Minute
+
+// recurringDayRegex matches day of month, e.g. "5", "5 числа", "5-го числа" or "5th"
+var recurringDayRegex = regexp.MustCompile(`^(\d{1,2})(?:\s*-?го|st|nd|rd|th)?(?:\s*числа)?$`)
+
+// runRecurringScheduler charges due recurring expenses until ctx is done
+func (b *Bot) runRecurringScheduler(ctx context.Context) {
+	ticker := time.NewTicker(recurringCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		b.chargeRecurringExpenses(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			b.logger.Print(ctx, "recurring expenses scheduler stopped")
+			return
+		}
+	}
+}
+
+// chargeRecurringExpenses creates due recurring expenses and notifies ledger chats with undo button
+func (b *Bot) chargeRecurringExpenses(ctx context.Context) {
+	// charges made before error are committed, so their chats are notified anyway
+	charges, err := b.saldo.ChargeDueRecurringExpenses(ctx, time.Now())
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to charge recurring expenses", "err", err)
+	}
+
+	for _, charge := range charges {
+		expensesCreated.Inc()
+		recurringExpensesCharged.Inc()
+
+		if charge.ChatID == 0 {
+			continue
+		}
+
+		// notification is sent in language and time zone of user, there is no update to take them from
+		ctx := withLocation(i18n.WithLang(ctx, i18n.Parse(charge.Language)), saldo.LoadLocation(charge.Timezone))
+		expense := NewExpense(&charge.Expense)
+		_, _ = b.api.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: charge.ChatID,
+			Text: tr(ctx, "recurring.charged") + "\n\n" + formatSavedExpenses(ctx, []Expense{*expense}) +
+				"\n\n" + tr(ctx, "recurring.next_charge", FormatDate(ctx, charge.Recurring.NextRunAt)),
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: recurringChargeKeyboard(ctx, expense.ID),
+		})
+
+		b.notifyBudgets(ctx, b.api, charge.ChatID, &User{ID: expense.UserID, LedgerID: charge.Expense.LedgerID}, []Expense{*expense})
+	}
+}
+
+// handleRecurringCommand handles /recurring command
+func (b *Bot) handleRecurringCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("recurring").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	b.handleRecurring(ctx, botAPI, chatID, user)
+}
+
+// handleRecurring shows upcoming charges of recurring expenses
+func (b *Bot) handleRecurring(ctx context.Context, botAPI *bot.Bot, chatID int64, user *User) {
+	text, markup, err := b.recurringScreen(ctx, user)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get recurring expenses", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "recurring.load_error"),
+		})
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// recurringScreen builds text and keyboard with upcoming charges
+func (b *Bot) recurringScreen(ctx context.Context, user *User) (string, models.ReplyMarkup, error) {
+	saldoList, err := b.saldo.GetRecurringExpenses(ctx, user.LedgerID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	list := NewRecurringExpenses(saldoList)
+	text := tr(ctx, "recurring.title") + "\n\n"
+	if len(list) == 0 {
+		text += tr(ctx, "recurring.empty")
+	} else {
+		text += tr(ctx, "recurring.upcoming") + "\n"
+		for _, r := range list {
+			text += formatRecurringExpense(ctx, r) + "\n"
+		}
+	}
+
+	return strings.TrimSpace(text), recurringKeyboard(ctx, list), nil
+}
+
+// handleRecurringAction handles callbacks of recurring expenses screen
+// Callback data format: recurring:add or recurring:delete:<recurringExpenseID>
+func (b *Bot) handleRecurringAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	parts := strings.Split(value, ":")
+
+	switch parts[0] {
+	case "add":
+		callbacksProcessed.WithLabelValues("recurring_add").Inc()
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+
+		b.stateManager.SetState(ctx, userID, chatID, StateAwaitingRecurring)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "recurring.enter"),
+			ParseMode: models.ParseModeHTML,
+		})
+	case "delete":
+		callbacksProcessed.WithLabelValues("recurring_delete").Inc()
+		if len(parts) != 2 {
+			return
+		}
+		recurringID, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return
+		}
+
+		recurring, err := b.saldo.DeleteRecurringExpense(ctx, user.LedgerID, recurringID)
+		if err != nil || recurring == nil {
+			if err != nil {
+				errorsTotal.WithLabelValues("database").Inc()
+				b.logger.Error(ctx, "failed to delete recurring expense", "err", err, "recurring_expense_id", recurringID)
+			}
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "recurring.already_deleted"),
+			})
+			return
+		}
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "recurring.deleted"),
+		})
+
+		text, markup, err := b.recurringScreen(ctx, user)
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			b.logger.Error(ctx, "failed to get recurring expenses", "err", err)
+			return
+		}
+		_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      chatID,
+			MessageID:   callback.Message.Message.ID,
+			Text:        text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: markup,
+		})
+	}
+}
+
+// handleRecurringInput handles text with category, amount and day of month of new recurring expense
+func (b *Bot) handleRecurringInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, text string) {
+	title, amount, currency, day, err := parseRecurringInput(text, userDefaultCurrency(user))
+	if err != nil {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "recurring.retry", trErr(ctx, err)),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	saldoRecurring, err := b.saldo.CreateRecurringExpense(ctx, user.LedgerID, user.ID, amount, currency, title, "", day, userNow(ctx))
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to create recurring expense", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "recurring.save_error"),
+		})
+		return
+	}
+
+	b.stateManager.ClearState(ctx, userID, chatID)
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "recurring.saved") + "\n\n" + formatRecurringExpense(ctx, *NewRecurringExpense(saldoRecurring)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: mainMenuKeyboard(ctx),
+	})
+}
+
+// parseRecurringInput parses "<category> <amount> [currency], <day>" into its parts
+func parseRecurringInput(text, defaultCurrency string) (string, int64, string, int, error) {
+	i := strings.LastIndex(text, ",")
+	if i < 0 {
+		return "", 0, "", 0, i18n.Errorf("recurring.no_day")
+	}
+
+	title, amount, currency, err := parseCategoryAmount(text[:i], defaultCurrency)
+	if err != nil {
+		return "", 0, "", 0, err
+	}
+
+	matches := recurringDayRegex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(text[i+1:])))
+	if matches == nil {
+		return "", 0, "", 0, i18n.Errorf("recurring.invalid_day")
+	}
+	day, _ := strconv.Atoi(matches[1])
+	if day < 1 || day > 31 {
+		return "", 0, "", 0, i18n.Errorf("recurring.day_range")
+	}
+
+	return title, amount, currency, day, nil
+}
+
+// formatRecurringExpense formats recurring expense with its next charge date for HTML messages
+func formatRecurringExpense(ctx context.Context, r RecurringExpense) string {
+	return tr(ctx, "recurring.item", FormatDate(ctx, r.NextRunAt), html.EscapeString(formatRecurringShort(r)), r.DayOfMonth)
+}
+
+// formatRecurringShort formats recurring expense in one short line for button labels
+func formatRecurringShort(r RecurringExpense) string {
+	return formatExpenseShort(Expense{
+		Amount:      r.Amount,
+		Currency:    r.Currency,
+		Description: r.Description,
+		Category:    r.Category,
+	})
+}
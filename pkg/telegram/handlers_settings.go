@@ -0,0 +1,251 @@
+package telegram
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleSettingsCommand handles /settings command - shows time zone, default currency, week start and digests of user
+func (b *Bot) handleSettingsCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("settings").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	// typing of time zone is cancelled by command
+	if b.stateManager.GetState(ctx, update.Message.From.ID, chatID).State == StateAwaitingTimezone {
+		b.stateManager.ClearState(ctx, update.Message.From.ID, chatID)
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        settingsText(ctx, user),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: settingsKeyboard(ctx),
+	})
+}
+
+// handleSettingsAction handles settings menu
+// Callback data format: settings:<timezone|currency|week|digest|back|tz_input>, settings:tz:<name>, settings:cur:<code>,
+// settings:week:<day> or settings:digest:<weekly|monthly>
+func (b *Bot) handleSettingsAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, userID int64, user *User, value string) {
+	action, arg, _ := strings.Cut(value, ":")
+	messageID := callback.Message.Message.ID
+
+	var (
+		markup models.ReplyMarkup
+		answer string
+	)
+	switch action {
+	case "timezone":
+		markup = timezoneKeyboard(ctx, user.Timezone)
+	case "currency":
+		markup = defaultCurrencyKeyboard(ctx, userDefaultCurrency(user))
+	case "week":
+		if arg == "" {
+			markup = weekStartKeyboard(ctx, user.WeekStart)
+			break
+		}
+		callbacksProcessed.WithLabelValues("settings_week").Inc()
+		day, err := strconv.Atoi(arg)
+		if err != nil || day < int(time.Sunday) || day > int(time.Saturday) {
+			return
+		}
+		if err := b.saldo.SetUserWeekStart(ctx, user.ID, time.Weekday(day)); err != nil {
+			b.settingsSaveError(ctx, botAPI, callback, err)
+			return
+		}
+		user.WeekStart = time.Weekday(day)
+	case "digest":
+		// digests are switched on and off in their own menu, it stays open
+		if arg != "" {
+			kind := saldo.DigestKind(arg)
+			if kind != saldo.DigestWeekly && kind != saldo.DigestMonthly {
+				return
+			}
+			callbacksProcessed.WithLabelValues("settings_digest").Inc()
+			enabled := !user.WeeklyDigest
+			if kind == saldo.DigestMonthly {
+				enabled = !user.MonthlyDigest
+			}
+			if err := b.saldo.SetUserDigest(ctx, user.ID, kind, enabled, time.Now()); err != nil {
+				b.settingsSaveError(ctx, botAPI, callback, err)
+				return
+			}
+			if kind == saldo.DigestMonthly {
+				user.MonthlyDigest = enabled
+			} else {
+				user.WeeklyDigest = enabled
+			}
+			answer = tr(ctx, "settings.saved")
+		}
+		markup = digestKeyboard(ctx, user.WeeklyDigest, user.MonthlyDigest)
+	case "cur":
+		callbacksProcessed.WithLabelValues("settings_currency").Inc()
+		if !slices.Contains(supportedCurrencies, arg) {
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "currency.unsupported"),
+			})
+			return
+		}
+		if err := b.saldo.SetUserDefaultCurrency(ctx, user.ID, arg); err != nil {
+			b.settingsSaveError(ctx, botAPI, callback, err)
+			return
+		}
+		user.DefaultCurrency = arg
+	case "tz":
+		callbacksProcessed.WithLabelValues("settings_timezone").Inc()
+		if !saldo.ValidTimezone(arg) {
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "settings.timezone_unknown", arg),
+			})
+			return
+		}
+		if err := b.saldo.SetUserTimezone(ctx, user.ID, arg); err != nil {
+			b.settingsSaveError(ctx, botAPI, callback, err)
+			return
+		}
+		user.Timezone = arg
+	case "tz_input":
+		b.stateManager.SetState(ctx, userID, chatID, StateAwaitingTimezone)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "settings.timezone_prompt"),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	case "back":
+	default:
+		return
+	}
+
+	text := tr(ctx, "settings.title")
+	if action == "digest" {
+		text = tr(ctx, "settings.digests_prompt", saldo.DigestHour)
+	}
+	if markup == nil {
+		// setting is saved or user went back, settings are shown with their values
+		text = settingsText(ctx, user)
+		markup = settingsKeyboard(ctx)
+		if action != "back" {
+			answer = tr(ctx, "settings.saved")
+		}
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            answer,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// handleTimezoneInput handles IANA time zone name typed by user
+func (b *Bot) handleTimezoneInput(ctx context.Context, botAPI *bot.Bot, chatID int64, userID int64, user *User, text string) {
+	timezone := strings.TrimSpace(text)
+	if !saldo.ValidTimezone(timezone) {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "settings.timezone_unknown", html.EscapeString(timezone)) + "\n\n" + tr(ctx, "settings.timezone_prompt"),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	if err := b.saldo.SetUserTimezone(ctx, user.ID, timezone); err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to save settings", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "settings.save_error"),
+		})
+		return
+	}
+
+	b.stateManager.ClearState(ctx, userID, chatID)
+	user.Timezone = timezone
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        settingsText(ctx, user),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: settingsKeyboard(ctx),
+	})
+}
+
+// settingsSaveError tells user that setting is not saved
+func (b *Bot) settingsSaveError(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, err error) {
+	errorsTotal.WithLabelValues("database").Inc()
+	b.logger.Error(ctx, "failed to save settings", "err", err)
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            tr(ctx, "settings.save_error"),
+		ShowAlert:       true,
+	})
+}
+
+// settingsText formats user's settings, current time is shown to check the time zone
+func settingsText(ctx context.Context, user *User) string {
+	loc := saldo.LoadLocation(user.Timezone)
+	return tr(ctx, "settings.title") + "\n\n" +
+		tr(ctx, "settings.timezone", loc.String(), time.Now().In(loc).Format("15:04")) + "\n" +
+		tr(ctx, "settings.currency", getCurrencyWithFlag(userDefaultCurrency(user))) + "\n" +
+		tr(ctx, "settings.week_start", weekdayName(ctx, user.WeekStart)) + "\n" +
+		tr(ctx, "settings.digests", digestsName(ctx, user))
+}
+
+// digestsName lists digests that user is subscribed to
+func digestsName(ctx context.Context, user *User) string {
+	switch {
+	case user.WeeklyDigest && user.MonthlyDigest:
+		return tr(ctx, "settings.digests_weekly") + ", " + tr(ctx, "settings.digests_monthly")
+	case user.WeeklyDigest:
+		return tr(ctx, "settings.digests_weekly")
+	case user.MonthlyDigest:
+		return tr(ctx, "settings.digests_monthly")
+	default:
+		return tr(ctx, "settings.digests_off")
+	}
+}
+
+// weekdayName returns name of day of week in language of user
+func weekdayName(ctx context.Context, day time.Weekday) string {
+	return tr(ctx, "weekday."+strconv.Itoa(int(day)))
+}
+
+// userDefaultCurrency returns currency of user's amounts entered without currency
+func userDefaultCurrency(user *User) string {
+	if user == nil || user.DefaultCurrency == "" {
+		return saldo.CurrencyRUB
+	}
+	return user.DefaultCurrency
+}
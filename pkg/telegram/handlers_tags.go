@@ -0,0 +1,148 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// tagsLimit is max number of tags in tag cloud, the least used ones are not shown
+const tagsLimit = 30
+
+// handleTagsCommand handles /tags command - shows tag cloud of ledger
+func (b *Bot) handleTagsCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("tags").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	text, markup, err := b.tagsScreen(ctx, user)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get tags", "err", err, "ledger_id", user.LedgerID)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "tags.load_error"),
+		})
+		return
+	}
+
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// handleTagAction shows statistics of expenses marked by tag or tag cloud again
+// Callback data format: tag:<tag>, empty tag is tag cloud
+func (b *Bot) handleTagAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, tag string) {
+	var (
+		text   string
+		markup models.ReplyMarkup
+		err    error
+	)
+	if tag == "" {
+		callbacksProcessed.WithLabelValues("tag_list").Inc()
+		text, markup, err = b.tagsScreen(ctx, user)
+	} else {
+		callbacksProcessed.WithLabelValues("tag_stats").Inc()
+		text, err = b.tagStatsText(ctx, user, tag)
+		markup = tagStatsKeyboard(ctx)
+	}
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get tag statistics", "err", err, "ledger_id", user.LedgerID, "tag", tag)
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "tags.load_error"),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+	_, _ = botAPI.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+// tagsScreen builds tag cloud of ledger: tags with numbers of their expenses, most used first
+func (b *Bot) tagsScreen(ctx context.Context, user *User) (string, models.ReplyMarkup, error) {
+	usage, err := b.saldo.GetTagsUsage(ctx, user.LedgerID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tags := NewTagsUsage(usage)
+	if len(tags) == 0 {
+		return tr(ctx, "tags.empty"), nil, nil
+	}
+	if len(tags) > tagsLimit {
+		tags = tags[:tagsLimit]
+	}
+
+	cloud := make([]string, len(tags))
+	for i, t := range tags {
+		cloud[i] = fmt.Sprintf("#%s <i>%d</i>", html.EscapeString(t.Tag), t.ExpensesCount)
+	}
+
+	return tr(ctx, "tags.title") + "\n\n" + strings.Join(cloud, "  "), tagsKeyboard(tags), nil
+}
+
+// tagStatsText builds statistics of all expenses marked by tag: dates, total and categories
+func (b *Bot) tagStatsText(ctx context.Context, user *User, tag string) (string, error) {
+	saldoExpenses, err := b.saldo.GetExpensesByTag(ctx, user.LedgerID, tag)
+	if err != nil {
+		return "", err
+	}
+
+	title := tr(ctx, "tags.stats_title", html.EscapeString(tag))
+	expenses := NewExpenses(saldoExpenses)
+	if len(expenses) == 0 {
+		return title + "\n\n" + tr(ctx, "tags.stats_empty"), nil
+	}
+
+	categories, err := b.saldo.GetCategories(ctx, user.LedgerID)
+	if err != nil {
+		return "", err
+	}
+
+	// expenses are ordered by date, so the tag spans from the first one to the last one
+	first, last := expenses[0].SpentAt.In(userLocation(ctx)), expenses[len(expenses)-1].SpentAt.In(userLocation(ctx))
+	text := title + "\n"
+	text += fmt.Sprintf("<i>%s — %s</i>\n", first.Format("02.01.2006"), last.Format("02.01.2006"))
+	text += tr(ctx, "tags.stats_count", len(expenses)) + "\n\n"
+	text += b.formatStatsSummary(ctx, user, expenses, nil) + "\n"
+
+	categoryMap, currencyFrequency := groupExpensesByCategory(ctx, expenses, NewCategories(categories))
+	currencyOrder := sortCurrenciesByFrequency(currencyFrequency)
+	rates := b.currentRates(ctx)
+	for _, stats := range sortCategoriesByTotal(categoryMap, rates) {
+		text += formatCategoryTree(stats, currencyOrder, rates)
+	}
+
+	return text, nil
+}
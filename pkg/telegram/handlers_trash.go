@@ -0,0 +1,177 @@
+package telegram
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleUndo handles /undo command - moves the last added expense to trash
+func (b *Bot) handleUndo(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("undo").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	saldoExpense, err := b.saldo.DeleteLastExpense(ctx, user.LedgerID, user.ID)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to delete last expense", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "trash.delete_error"),
+		})
+		return
+	}
+
+	if saldoExpense == nil {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr(ctx, "trash.nothing_to_undo"),
+			ReplyMarkup: mainMenuKeyboard(ctx),
+		})
+		return
+	}
+
+	expensesDeleted.Inc()
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "trash.undone") + "\n\n" + formatExpenseDetails(ctx, *NewExpense(saldoExpense)),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: restoreExpenseKeyboard(ctx, saldoExpense.ID),
+	})
+}
+
+// handleTrashCommand handles /trash command
+func (b *Bot) handleTrashCommand(ctx context.Context, botAPI *bot.Bot, update *models.Update) {
+	commandsProcessed.WithLabelValues("trash").Inc()
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user, err := b.getChatUser(ctx, update.Message.Chat, update.Message.From)
+	if err != nil || user == nil {
+		errorsTotal.WithLabelValues("user_not_found").Inc()
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "use_start"),
+		})
+		return
+	}
+
+	b.handleTrash(ctx, botAPI, chatID, user)
+}
+
+// handleTrash shows deleted expenses that can be restored
+func (b *Bot) handleTrash(ctx context.Context, botAPI *bot.Bot, chatID int64, user *User) {
+	saldoExpenses, err := b.saldo.GetDeletedExpenses(ctx, user.LedgerID)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		b.logger.Error(ctx, "failed to get deleted expenses", "err", err)
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr(ctx, "expenses.load_error"),
+		})
+		return
+	}
+
+	if len(saldoExpenses) == 0 {
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      tr(ctx, "trash.title") + "\n\n" + tr(ctx, "trash.empty"),
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	expenses := NewExpenses(saldoExpenses)
+	_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        tr(ctx, "trash.title") + "\n\n" + formatSavedExpenses(ctx, expenses) + "\n\n" + tr(ctx, "trash.hint"),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: trashKeyboard(ctx, expenses),
+	})
+}
+
+// handleTrashAction handles deletion and restoring of expenses
+// Callback data format: trash:delete:<expenseID> or trash:restore:<expenseID>
+func (b *Bot) handleTrashAction(ctx context.Context, botAPI *bot.Bot, callback *models.CallbackQuery, chatID int64, user *User, value string) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return
+	}
+
+	expenseID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+
+	switch parts[0] {
+	case "delete":
+		callbacksProcessed.WithLabelValues("delete").Inc()
+		saldoExpense, err := b.saldo.DeleteExpense(ctx, user.LedgerID, expenseID)
+		if err != nil || saldoExpense == nil {
+			if err != nil {
+				errorsTotal.WithLabelValues("database").Inc()
+				b.logger.Error(ctx, "failed to delete expense", "err", err, "expense_id", expenseID)
+			}
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "trash.already_deleted"),
+			})
+			return
+		}
+
+		expensesDeleted.Inc()
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "trash.deleted"),
+		})
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr(ctx, "trash.moved") + "\n\n" + formatExpenseDetails(ctx, *NewExpense(saldoExpense)),
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: restoreExpenseKeyboard(ctx, saldoExpense.ID),
+		})
+	case "restore":
+		callbacksProcessed.WithLabelValues("restore").Inc()
+		saldoExpense, err := b.saldo.RestoreExpense(ctx, user.LedgerID, expenseID)
+		if err != nil || saldoExpense == nil {
+			if err != nil {
+				errorsTotal.WithLabelValues("database").Inc()
+				b.logger.Error(ctx, "failed to restore expense", "err", err, "expense_id", expenseID)
+			}
+			_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            tr(ctx, "trash.already_restored"),
+			})
+			return
+		}
+
+		_, _ = botAPI.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            tr(ctx, "trash.restored"),
+		})
+		_, _ = botAPI.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr(ctx, "trash.restored_details") + "\n\n" + formatExpenseDetails(ctx, *NewExpense(saldoExpense)),
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: savedExpensesKeyboard(ctx, []Expense{*NewExpense(saldoExpense)}),
+		})
+	}
+}
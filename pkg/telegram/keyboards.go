@@ -1,19 +1,33 @@
 package telegram
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/services"
+
 	"github.com/go-telegram/bot/models"
 )
 
 // mainMenuKeyboard returns main menu keyboard with quick actions
-func mainMenuKeyboard() models.ReplyMarkup {
+func mainMenuKeyboard(ctx context.Context) models.ReplyMarkup {
 	return &models.ReplyKeyboardMarkup{
 		Keyboard: [][]models.KeyboardButton{
 			{
-				{Text: "➕ Добавить расход"},
-				{Text: "📊 Статистика"},
+				{Text: tr(ctx, "btn.add_expense")},
+				{Text: tr(ctx, "btn.stats")},
+			},
+			{
+				{Text: tr(ctx, "btn.week_expenses")},
+				{Text: tr(ctx, "btn.trash")},
 			},
 			{
-				{Text: "💰 Траты за неделю"},
+				{Text: tr(ctx, "btn.recurring")},
+				{Text: tr(ctx, "btn.categories")},
 			},
 		},
 		ResizeKeyboard:  true,
@@ -24,12 +38,12 @@ func mainMenuKeyboard() models.ReplyMarkup {
 // confirmKeyboard returns confirmation keyboard (Yes/No)
 // TODO: Use when implementing confirmation dialogs
 // nolint:unused
-func confirmKeyboard(action string) models.ReplyMarkup {
+func confirmKeyboard(ctx context.Context, action string) models.ReplyMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "✅ Да", CallbackData: "confirm:" + action + ":yes"},
-				{Text: "❌ Нет", CallbackData: "confirm:" + action + ":no"},
+				{Text: tr(ctx, "kb.yes"), CallbackData: "confirm:" + action + ":yes"},
+				{Text: tr(ctx, "kb.no"), CallbackData: "confirm:" + action + ":no"},
 			},
 		},
 	}
@@ -38,11 +52,11 @@ func confirmKeyboard(action string) models.ReplyMarkup {
 // cancelKeyboard returns keyboard with cancel button
 // TODO: Use when implementing multi-step operations
 // nolint:unused
-func cancelKeyboard() models.ReplyMarkup {
+func cancelKeyboard(ctx context.Context) models.ReplyMarkup {
 	return &models.ReplyKeyboardMarkup{
 		Keyboard: [][]models.KeyboardButton{
 			{
-				{Text: "❌ Отменить"},
+				{Text: tr(ctx, "btn.cancel")},
 			},
 		},
 		ResizeKeyboard:  true,
@@ -59,28 +73,71 @@ func removeKeyboard() models.ReplyMarkup {
 	}
 }
 
-// expenseConfirmKeyboard returns keyboard to confirm expense details
-func expenseConfirmKeyboard() models.ReplyMarkup {
-	return &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: "✅ Подтвердить", CallbackData: "expense:confirm"},
-				{Text: "❌ Отменить", CallbackData: "expense:cancel"},
-			},
-		},
+// expenseConfirmKeyboard returns keyboard to confirm expense details.
+// Several expenses get a row per item to drop it, change its category or amount.
+func expenseConfirmKeyboard(ctx context.Context, expenses []ExpenseData) models.ReplyMarkup {
+	buttons := make([][]models.InlineKeyboardButton, 0, len(expenses)+1)
+	if len(expenses) > 1 {
+		for i, exp := range expenses {
+			buttons = append(buttons, []models.InlineKeyboardButton{
+				{Text: "🗑 " + formatPendingShort(exp), CallbackData: fmt.Sprintf("expense:drop:%d", i)},
+				{Text: "📂", CallbackData: fmt.Sprintf("expense:category:%d", i)},
+				{Text: "💰", CallbackData: fmt.Sprintf("expense:amount:%d", i)},
+			})
+		}
+	}
+
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: tr(ctx, "kb.confirm"), CallbackData: "expense:confirm"},
+		{Text: tr(ctx, "kb.cancel"), CallbackData: "expense:cancel"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// pendingCategoryKeyboard returns keyboard with user's categories for not yet confirmed expense
+func pendingCategoryKeyboard(ctx context.Context, index int, categories []Category) models.ReplyMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 2)
+	for _, cat := range categories {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         strings.TrimSpace(cat.Emoji + " " + cat.Title),
+			CallbackData: fmt.Sprintf("expense:setcat:%d:%d", index, cat.ID),
+		})
+		if len(row) == 2 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 2)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
 	}
+
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: tr(ctx, "kb.back"), CallbackData: "expense:back"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
 }
 
 // statisticsMenuKeyboard returns statistics type selection menu
-func statisticsMenuKeyboard() models.ReplyMarkup {
+func statisticsMenuKeyboard(ctx context.Context) models.ReplyMarkup {
 	return &models.ReplyKeyboardMarkup{
 		Keyboard: [][]models.KeyboardButton{
 			{
-				{Text: "📊 По категориям"},
-				{Text: "💸 По тратам"},
+				{Text: tr(ctx, "btn.by_categories")},
+				{Text: tr(ctx, "btn.by_expenses")},
+			},
+			{
+				{Text: tr(ctx, "btn.charts")},
+				{Text: tr(ctx, "btn.compare")},
 			},
 			{
-				{Text: "🔙 Назад"},
+				{Text: tr(ctx, "btn.budgets")},
+				{Text: tr(ctx, "btn.export")},
+			},
+			{
+				{Text: tr(ctx, "btn.back")},
 			},
 		},
 		ResizeKeyboard:  true,
@@ -89,31 +146,31 @@ func statisticsMenuKeyboard() models.ReplyMarkup {
 }
 
 // periodSelectionKeyboard returns period selection menu
-func periodSelectionKeyboard(includeAllTime bool) models.ReplyMarkup {
+func periodSelectionKeyboard(ctx context.Context, includeAllTime bool) models.ReplyMarkup {
 	buttons := [][]models.KeyboardButton{
 		{
-			{Text: "📅 За сегодня"},
-			{Text: "📅 За неделю"},
+			{Text: tr(ctx, "btn.today")},
+			{Text: tr(ctx, "btn.week")},
 		},
 	}
 
 	if includeAllTime {
 		buttons = append(buttons, []models.KeyboardButton{
-			{Text: "📅 За месяц"},
-			{Text: "📅 За всё время"},
+			{Text: tr(ctx, "btn.month")},
+			{Text: tr(ctx, "btn.all_time")},
 		})
 	} else {
 		buttons = append(buttons, []models.KeyboardButton{
-			{Text: "📅 За месяц"},
+			{Text: tr(ctx, "btn.month")},
 		})
 	}
 
 	buttons = append(buttons, []models.KeyboardButton{
-		{Text: "📅 Кастомный период"},
+		{Text: tr(ctx, "btn.custom_period")},
 	})
 
 	buttons = append(buttons, []models.KeyboardButton{
-		{Text: "🔙 Назад"},
+		{Text: tr(ctx, "btn.back")},
 	})
 
 	return &models.ReplyKeyboardMarkup{
@@ -124,3 +181,527 @@ func periodSelectionKeyboard(includeAllTime bool) models.ReplyMarkup {
 }
 
 // backToStatsKeyboard returns keyboard with back to stats button - removed, using statisticsMenuKeyboard instead
+
+// supportedCurrencies lists currencies that LLM is allowed to return
+var supportedCurrencies = []string{"RUB", "USD", "EUR", "GBP", "GEL", "JPY", "CNY", "CHF", "KZT"}
+
+// savedExpensesKeyboard returns inline keyboard with edit and delete buttons for each expense
+func savedExpensesKeyboard(ctx context.Context, expenses []Expense) models.ReplyMarkup {
+	buttons := make([][]models.InlineKeyboardButton, 0, len(expenses))
+	for _, exp := range expenses {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: tr(ctx, "kb.edit_expense", formatExpenseShort(exp)), CallbackData: fmt.Sprintf("edit:%d", exp.ID)},
+			{Text: "🗑", CallbackData: fmt.Sprintf("trash:delete:%d", exp.ID)},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// savedEntriesKeyboard returns inline keyboard for just saved expenses and incomes
+func savedEntriesKeyboard(ctx context.Context, expenses []Expense, incomes []Income) models.ReplyMarkup {
+	markup := savedExpensesKeyboard(ctx, expenses).(*models.InlineKeyboardMarkup)
+	for _, inc := range incomes {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, []models.InlineKeyboardButton{
+			{Text: tr(ctx, "kb.delete_income", formatIncomeShort(inc)), CallbackData: fmt.Sprintf("income:delete:%d", inc.ID)},
+		})
+	}
+
+	return markup
+}
+
+// restoreIncomeKeyboard returns keyboard to restore just deleted income
+func restoreIncomeKeyboard(ctx context.Context, incomeID int) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: tr(ctx, "kb.restore"), CallbackData: fmt.Sprintf("income:restore:%d", incomeID)},
+			},
+		},
+	}
+}
+
+// budgetsKeyboard returns inline keyboard to set new budget or delete existing ones
+func budgetsKeyboard(ctx context.Context, budgets []Budget) models.ReplyMarkup {
+	buttons := [][]models.InlineKeyboardButton{
+		{{Text: tr(ctx, "kb.add_budget"), CallbackData: "budget:add"}},
+	}
+	for _, budget := range budgets {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: "🗑 " + budgetLabel(ctx, budget), CallbackData: fmt.Sprintf("budget:delete:%d", budget.ID)},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// recurringKeyboard returns inline keyboard to add recurring expense or stop existing ones
+func recurringKeyboard(ctx context.Context, list []RecurringExpense) models.ReplyMarkup {
+	buttons := [][]models.InlineKeyboardButton{
+		{{Text: tr(ctx, "kb.add_recurring"), CallbackData: "recurring:add"}},
+	}
+	for _, r := range list {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: "🗑 " + formatRecurringShort(r), CallbackData: fmt.Sprintf("recurring:delete:%d", r.ID)},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// recurringChargeKeyboard returns keyboard to undo automatically created expense
+func recurringChargeKeyboard(ctx context.Context, expenseID int) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: tr(ctx, "kb.undo"), CallbackData: fmt.Sprintf("trash:delete:%d", expenseID)},
+			},
+		},
+	}
+}
+
+// editExpenseKeyboard returns keyboard with fields of saved expense to change
+func editExpenseKeyboard(ctx context.Context, expenseID int) models.ReplyMarkup {
+	prefix := fmt.Sprintf("edit:%d:", expenseID)
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: tr(ctx, "kb.amount"), CallbackData: prefix + string(EditFieldAmount)},
+				{Text: tr(ctx, "kb.currency"), CallbackData: prefix + string(EditFieldCurrency)},
+			},
+			{
+				{Text: tr(ctx, "kb.category"), CallbackData: prefix + string(EditFieldCategory)},
+				{Text: tr(ctx, "kb.description"), CallbackData: prefix + string(EditFieldDescription)},
+			},
+			{
+				{Text: tr(ctx, "kb.delete"), CallbackData: fmt.Sprintf("trash:delete:%d", expenseID)},
+				{Text: tr(ctx, "kb.done"), CallbackData: prefix + "done"},
+			},
+		},
+	}
+}
+
+// editCurrencyKeyboard returns keyboard with supported currencies for saved expense
+func editCurrencyKeyboard(ctx context.Context, expenseID int) models.ReplyMarkup {
+	prefix := fmt.Sprintf("edit:%d:", expenseID)
+
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 3)
+	for _, currency := range supportedCurrencies {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         getCurrencyWithFlag(currency),
+			CallbackData: prefix + "set:currency:" + currency,
+		})
+		if len(row) == 3 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 3)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: tr(ctx, "kb.back"), CallbackData: prefix + "back"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// baseCurrencyKeyboard returns keyboard with supported currencies to choose base one
+func baseCurrencyKeyboard(current string) models.ReplyMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 3)
+	for _, currency := range supportedCurrencies {
+		text := getCurrencyWithFlag(currency)
+		if currency == current {
+			text = "✅ " + text
+		}
+		row = append(row, models.InlineKeyboardButton{Text: text, CallbackData: "currency:" + currency})
+		if len(row) == 3 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 3)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// commonTimezones lists time zones offered in settings, any other IANA name can be typed
+var commonTimezones = []string{
+	"Europe/Kaliningrad", "Europe/Moscow", "Europe/Samara", "Asia/Yekaterinburg",
+	"Asia/Omsk", "Asia/Novosibirsk", "Asia/Krasnoyarsk", "Asia/Irkutsk",
+	"Asia/Yakutsk", "Asia/Vladivostok", "Asia/Magadan", "Asia/Kamchatka",
+	"Europe/Minsk", "Asia/Tbilisi", "Asia/Almaty", "Europe/Istanbul",
+	"Europe/London", "Europe/Berlin", "America/New_York", "UTC",
+}
+
+// settingsKeyboard returns keyboard with user settings to change
+func settingsKeyboard(ctx context.Context) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: tr(ctx, "kb.timezone"), CallbackData: "settings:timezone"}},
+			{{Text: tr(ctx, "kb.default_currency"), CallbackData: "settings:currency"}},
+			{{Text: tr(ctx, "kb.week_start"), CallbackData: "settings:week"}},
+			{{Text: tr(ctx, "kb.digests"), CallbackData: "settings:digest"}},
+		},
+	}
+}
+
+// timezoneKeyboard returns keyboard with common time zones and button to type another one
+func timezoneKeyboard(ctx context.Context, current string) models.ReplyMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 2)
+	for _, timezone := range commonTimezones {
+		text := timezone
+		if timezone == current {
+			text = "✅ " + text
+		}
+		row = append(row, models.InlineKeyboardButton{Text: text, CallbackData: "settings:tz:" + timezone})
+		if len(row) == 2 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 2)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: tr(ctx, "kb.other_timezone"), CallbackData: "settings:tz_input"},
+		{Text: tr(ctx, "kb.back"), CallbackData: "settings:back"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// defaultCurrencyKeyboard returns keyboard with supported currencies for amounts entered without currency
+func defaultCurrencyKeyboard(ctx context.Context, current string) models.ReplyMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 3)
+	for _, currency := range supportedCurrencies {
+		text := getCurrencyWithFlag(currency)
+		if currency == current {
+			text = "✅ " + text
+		}
+		row = append(row, models.InlineKeyboardButton{Text: text, CallbackData: "settings:cur:" + currency})
+		if len(row) == 3 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 3)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	buttons = append(buttons, []models.InlineKeyboardButton{{Text: tr(ctx, "kb.back"), CallbackData: "settings:back"}})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// weekStartKeyboard returns keyboard with days that week can start on
+func weekStartKeyboard(ctx context.Context, current time.Weekday) models.ReplyMarkup {
+	row := make([]models.InlineKeyboardButton, 0, 3)
+	for _, day := range []time.Weekday{time.Monday, time.Sunday, time.Saturday} {
+		text := weekdayName(ctx, day)
+		if day == current {
+			text = "✅ " + text
+		}
+		row = append(row, models.InlineKeyboardButton{Text: text, CallbackData: "settings:week:" + strconv.Itoa(int(day))})
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			row,
+			{{Text: tr(ctx, "kb.back"), CallbackData: "settings:back"}},
+		},
+	}
+}
+
+// compareKeyboard returns keyboard that switches comparison between month and week, current mode is marked
+func compareKeyboard(ctx context.Context, mode string) models.ReplyMarkup {
+	button := func(key, value string) models.InlineKeyboardButton {
+		text := tr(ctx, key)
+		if value == mode {
+			text = "✅ " + text
+		}
+		return models.InlineKeyboardButton{Text: text, CallbackData: "compare:" + value}
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{button("kb.compare_month", compareMonth), button("kb.compare_week", compareWeek)},
+		},
+	}
+}
+
+// digestKeyboard returns keyboard that subscribes user to weekly and monthly digests or unsubscribes
+func digestKeyboard(ctx context.Context, weekly, monthly bool) models.ReplyMarkup {
+	mark := func(enabled bool) string {
+		if enabled {
+			return "✅ "
+		}
+		return "▫️ "
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: mark(weekly) + tr(ctx, "kb.digest_weekly"), CallbackData: "settings:digest:weekly"}},
+			{{Text: mark(monthly) + tr(ctx, "kb.digest_monthly"), CallbackData: "settings:digest:monthly"}},
+			{{Text: tr(ctx, "kb.back"), CallbackData: "settings:back"}},
+		},
+	}
+}
+
+// languageKeyboard returns keyboard with supported languages of interface
+func languageKeyboard(current i18n.Lang) models.ReplyMarkup {
+	row := make([]models.InlineKeyboardButton, 0, len(i18n.Langs))
+	for _, lang := range i18n.Langs {
+		text := i18n.Name(lang)
+		if lang == current {
+			text = "✅ " + text
+		}
+		row = append(row, models.InlineKeyboardButton{Text: text, CallbackData: "language:" + string(lang)})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{row}}
+}
+
+// importConfirmKeyboard returns keyboard to confirm bank statement import
+func importConfirmKeyboard(ctx context.Context) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: tr(ctx, "kb.import"), CallbackData: "import:confirm"},
+				{Text: tr(ctx, "kb.cancel"), CallbackData: "import:cancel"},
+			},
+		},
+	}
+}
+
+// exportFormatKeyboard returns keyboard with file formats of expenses export for period
+func exportFormatKeyboard(period TimePeriod) models.ReplyMarkup {
+	suffix := fmt.Sprintf(":%d:%d", period.Start.Unix(), period.End.Unix())
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "📄 CSV", CallbackData: "export:" + string(services.ExportCSV) + suffix},
+				{Text: "📊 XLSX", CallbackData: "export:" + string(services.ExportXLSX) + suffix},
+			},
+		},
+	}
+}
+
+// editCategoryKeyboard returns keyboard with user's categories for saved expense
+func editCategoryKeyboard(ctx context.Context, expenseID int, categories []Category) models.ReplyMarkup {
+	prefix := fmt.Sprintf("edit:%d:", expenseID)
+
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 2)
+	for _, cat := range categories {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         strings.TrimSpace(cat.Emoji + " " + cat.Title),
+			CallbackData: fmt.Sprintf("%sset:category:%d", prefix, cat.ID),
+		})
+		if len(row) == 2 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 2)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: tr(ctx, "kb.back"), CallbackData: prefix + "back"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// restoreExpenseKeyboard returns keyboard to restore just deleted expense
+func restoreExpenseKeyboard(ctx context.Context, expenseID int) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: tr(ctx, "kb.restore"), CallbackData: fmt.Sprintf("trash:restore:%d", expenseID)},
+			},
+		},
+	}
+}
+
+// trashKeyboard returns inline keyboard with restore button for each deleted expense
+func trashKeyboard(ctx context.Context, expenses []Expense) models.ReplyMarkup {
+	buttons := make([][]models.InlineKeyboardButton, 0, len(expenses))
+	for _, exp := range expenses {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: tr(ctx, "kb.restore_expense", formatExpenseShort(exp)), CallbackData: fmt.Sprintf("trash:restore:%d", exp.ID)},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// ledgerKeyboard returns keyboard of shared ledger opened in private chat
+func ledgerKeyboard(ctx context.Context) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: tr(ctx, "kb.personal_ledger"), CallbackData: "ledger:personal"}},
+		},
+	}
+}
+
+// memberFilterKeyboard returns keyboard to show statistics of whole ledger or of one member
+func memberFilterKeyboard(ctx context.Context, members []User, selected int) models.ReplyMarkup {
+	label := func(text string, id int) string {
+		if id == selected {
+			return "✅ " + text
+		}
+		return text
+	}
+
+	buttons := [][]models.InlineKeyboardButton{
+		{{Text: label(tr(ctx, "kb.all_members"), 0), CallbackData: "member:0"}},
+	}
+	row := make([]models.InlineKeyboardButton, 0, 2)
+	for _, member := range members {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         label(member.DisplayName(), member.ID),
+			CallbackData: fmt.Sprintf("member:%d", member.ID),
+		})
+		if len(row) == 2 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 2)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// categoriesKeyboard returns inline keyboard with ledger's categories to manage
+func categoriesKeyboard(categories []CategoryUsage) models.ReplyMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 2)
+	for _, cat := range categories {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         categoryTreeTitle(cat.Category),
+			CallbackData: fmt.Sprintf("cat:open:%d", cat.ID),
+		})
+		if len(row) == 2 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 2)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// categoryKeyboard returns keyboard with actions on category
+func categoryKeyboard(ctx context.Context, categoryID int) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: tr(ctx, "kb.category_rename"), CallbackData: fmt.Sprintf("cat:rename:%d", categoryID)},
+				{Text: tr(ctx, "kb.category_emoji"), CallbackData: fmt.Sprintf("cat:emoji:%d", categoryID)},
+			},
+			{
+				{Text: tr(ctx, "kb.category_merge"), CallbackData: fmt.Sprintf("cat:merge:%d", categoryID)},
+				{Text: tr(ctx, "kb.category_delete"), CallbackData: fmt.Sprintf("cat:delete:%d", categoryID)},
+			},
+			{
+				{Text: tr(ctx, "kb.category_parent"), CallbackData: fmt.Sprintf("cat:parent:%d", categoryID)},
+			},
+			{
+				{Text: tr(ctx, "kb.back"), CallbackData: "cat:list"},
+			},
+		},
+	}
+}
+
+// categoryTargetKeyboard returns keyboard with categories that expenses of merged or deleted category move to
+// or that become parent of category. Action is "mergeto", "delto" or "parentto", noneLabel adds button with zero target.
+func categoryTargetKeyboard(ctx context.Context, action string, categoryID int, categories []CategoryUsage, noneLabel string) models.ReplyMarkup {
+	prefix := fmt.Sprintf("cat:%s:%d:", action, categoryID)
+
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 2)
+	for _, cat := range categories {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         categoryTreeTitle(cat.Category),
+			CallbackData: prefix + strconv.Itoa(cat.ID),
+		})
+		if len(row) == 2 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 2)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	if noneLabel != "" {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: noneLabel, CallbackData: prefix + "0"},
+		})
+	}
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: tr(ctx, "kb.back"), CallbackData: fmt.Sprintf("cat:open:%d", categoryID)},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// tagsKeyboard returns keyboard with button of each tag, most used first
+func tagsKeyboard(tags []TagUsage) models.ReplyMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	row := make([]models.InlineKeyboardButton, 0, 3)
+	for _, t := range tags {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         "#" + t.Tag,
+			CallbackData: "tag:" + t.Tag,
+		})
+		if len(row) == 3 {
+			buttons = append(buttons, row)
+			row = make([]models.InlineKeyboardButton, 0, 3)
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// tagStatsKeyboard returns keyboard of tag statistics with button back to tag cloud
+func tagStatsKeyboard(ctx context.Context) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: tr(ctx, "kb.back"), CallbackData: "tag:"}},
+		},
+	}
+}
+
+// findPagerKeyboard returns keyboard to turn pages of search results, nil if there is only one page
+func findPagerKeyboard(page, pages int) models.ReplyMarkup {
+	var row []models.InlineKeyboardButton
+	if page > 1 {
+		row = append(row, models.InlineKeyboardButton{Text: "◀️", CallbackData: fmt.Sprintf("find:%d", page-1)})
+	}
+	if page < pages {
+		row = append(row, models.InlineKeyboardButton{Text: "▶️", CallbackData: fmt.Sprintf("find:%d", page+1)})
+	}
+	if len(row) == 0 {
+		return nil
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{row}}
+}
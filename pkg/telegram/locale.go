@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	"saldo/pkg/i18n"
+	"saldo/pkg/saldo"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/vmkteam/embedlog"
+)
+
+// tr returns message of key in language of user from context
+func tr(ctx context.Context, key string, args ...any) string {
+	return i18n.T(i18n.FromContext(ctx), key, args...)
+}
+
+// trErr returns error message in language of user from context
+func trErr(ctx context.Context, err error) string {
+	return i18n.Message(i18n.FromContext(ctx), err)
+}
+
+type locationKey struct{}
+
+// withLocation returns context with time zone of user
+func withLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+// userLocation returns time zone of user from context or default time zone
+func userLocation(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationKey{}).(*time.Location); ok {
+		return loc
+	}
+	return saldo.LoadLocation(saldo.DefaultTimezone)
+}
+
+// userNow returns current time in time zone of user from context
+func userNow(ctx context.Context) time.Time {
+	return time.Now().In(userLocation(ctx))
+}
+
+// localeMiddleware puts language and time zone of user to context of handlers.
+// Language of users that are not registered yet is detected from their Telegram settings.
+func localeMiddleware(saldoService *saldo.Manager, logger embedlog.Logger) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			var from *models.User
+			switch {
+			case update.Message != nil:
+				from = update.Message.From
+			case update.CallbackQuery != nil:
+				from = &update.CallbackQuery.From
+			}
+
+			if from != nil {
+				lang := i18n.Detect(from.LanguageCode)
+				timezone := saldo.DefaultTimezone
+				user, err := saldoService.GetUserByTelegramID(ctx, from.ID)
+				if err != nil {
+					errorsTotal.WithLabelValues("database").Inc()
+					logger.Error(ctx, "failed to get user locale", "err", err, "telegram_id", from.ID)
+				} else if user != nil {
+					lang = i18n.Parse(user.Language)
+					timezone = user.Timezone
+				}
+				ctx = i18n.WithLang(ctx, lang)
+				ctx = withLocation(ctx, saldo.LoadLocation(timezone))
+			}
+
+			next(ctx, b, update)
+		}
+	}
+}
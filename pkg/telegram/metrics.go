@@ -13,7 +13,7 @@ var (
 			Name: "telegram_commands_processed_total",
 			Help: "Total number of processed commands by type",
 		},
-		[]string{"command"}, // start, help
+		[]string{"command"}, // start, help, undo, trash, recurring, currency, language, settings, ledger, categories, tags, find
 	)
 
 	// Счетчик обработанных сообщений по типам
@@ -22,7 +22,7 @@ var (
 			Name: "telegram_messages_processed_total",
 			Help: "Total number of processed messages by type",
 		},
-		[]string{"type"}, // text, voice
+		[]string{"type"}, // text, voice, document, photo
 	)
 
 	// Счетчик нажатий на кнопки по типам
@@ -40,7 +40,7 @@ var (
 			Name: "telegram_callbacks_processed_total",
 			Help: "Total number of processed callback queries by action",
 		},
-		[]string{"action"}, // confirm, cancel
+		[]string{"action"}, // confirm, cancel, edit, edit_done, delete, restore, income_delete, income_restore, budget_add, budget_delete, recurring_add, recurring_delete, currency, export_csv, export_xlsx, import_confirm, import_cancel, item_drop, item_category, item_amount, language, settings_timezone, settings_currency, settings_week, settings_digest, ledger_personal, stats_member, category_list, category_open, category_rename, category_emoji, category_merge, category_delete, category_merge_confirm, category_delete_confirm, category_parent, category_parent_confirm, tag_list, tag_stats, find_page, compare_month, compare_week
 	)
 
 	// Счетчик созданных расходов
@@ -51,6 +51,56 @@ var (
 		},
 	)
 
+	// Счетчик созданных доходов
+	incomesCreated = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "telegram_incomes_created_total",
+			Help: "Total number of incomes created",
+		},
+	)
+
+	// Счетчик измененных расходов
+	expensesEdited = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "telegram_expenses_edited_total",
+			Help: "Total number of expenses edited",
+		},
+	)
+
+	// Счетчик удаленных в корзину расходов
+	expensesDeleted = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "telegram_expenses_deleted_total",
+			Help: "Total number of expenses moved to trash",
+		},
+	)
+
+	// Счетчик расходов, созданных по регулярным платежам
+	recurringExpensesCharged = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "telegram_recurring_expenses_charged_total",
+			Help: "Total number of expenses created from recurring expenses",
+		},
+	)
+
+	// Счетчик отправленных сводок по типам
+	digestsSent = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "telegram_digests_sent_total",
+			Help: "Total number of sent digests by kind",
+		},
+		[]string{"kind"}, // weekly, monthly
+	)
+
+	// Счетчик предупреждений о необычных тратах по типам
+	anomalyAlerts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "telegram_anomaly_alerts_total",
+			Help: "Total number of unusual spending alerts by kind",
+		},
+		[]string{"kind"}, // expense, daily
+	)
+
 	// Счетчик созданных категорий
 	categoriesCreated = promauto.NewCounter(
 		prometheus.CounterOpts{
@@ -65,7 +115,7 @@ var (
 			Name: "telegram_errors_total",
 			Help: "Total number of errors by type",
 		},
-		[]string{"type"}, // transcription, llm_parse, llm_parse_failed, database, download_file, user_not_found, get_categories
+		[]string{"type"}, // transcription, llm_parse, llm_parse_failed, database, download_file, user_not_found, get_categories, exchange_rates, export, send_document, chart, send_photo, send_digest, statement_parse, receipt_decode
 	)
 
 	// Гистограмма времени транскрибации
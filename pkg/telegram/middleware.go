@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"saldo/pkg/i18n"
+
 	"github.com/go-telegram/bot/models"
 )
 
-// getOrCreateUser gets user by Telegram ID or creates a new one
+// getOrCreateUser gets user by Telegram ID or creates a new one with language of Telegram settings
 func (b *Bot) getOrCreateUser(ctx context.Context, tgUser *models.User) (*User, error) {
 	if tgUser == nil {
 		return nil, errors.New("telegram user is nil")
@@ -19,6 +21,7 @@ func (b *Bot) getOrCreateUser(ctx context.Context, tgUser *models.User) (*User,
 		tgUser.Username,
 		tgUser.FirstName,
 		tgUser.LastName,
+		string(i18n.Detect(tgUser.LanguageCode)),
 	)
 	if err != nil {
 		return nil, err
@@ -27,12 +30,54 @@ func (b *Bot) getOrCreateUser(ctx context.Context, tgUser *models.User) (*User,
 	return NewUser(saldoUser), nil
 }
 
-// getUserByTelegramID gets user by Telegram user ID
-func (b *Bot) getUserByTelegramID(ctx context.Context, telegramUserID int64) (*User, error) {
-	saldoUser, err := b.saldo.GetUserByTelegramID(ctx, telegramUserID)
+// getChatUser gets user and resolves ledger of chat that user's expenses are written to.
+// Private chat writes to ledger chosen by user or to personal ledger, group chat writes to shared ledger of group.
+// Members of group are registered on their first message, private chat requires /start.
+func (b *Bot) getChatUser(ctx context.Context, chat models.Chat, tgUser *models.User) (*User, error) {
+	if tgUser == nil {
+		return nil, errors.New("telegram user is nil")
+	}
+
+	if chat.Type == models.ChatTypePrivate {
+		saldoUser, err := b.saldo.GetUserByTelegramID(ctx, tgUser.ID)
+		if err != nil || saldoUser == nil {
+			return nil, err
+		}
+
+		user := NewUser(saldoUser)
+		if saldoUser.LedgerID != nil {
+			ledger, err := b.saldo.GetLedgerByID(ctx, *saldoUser.LedgerID)
+			if err != nil {
+				return nil, err
+			} else if ledger != nil {
+				user.LedgerID, user.SharedLedger = ledger.ID, true
+				return user, nil
+			}
+		}
+
+		return user, b.joinChatLedger(ctx, user, chat.ID, user.DisplayName())
+	}
+
+	user, err := b.getOrCreateUser(ctx, tgUser)
 	if err != nil {
 		return nil, err
 	}
+	user.SharedLedger = true
 
-	return NewUser(saldoUser), nil
+	return user, b.joinChatLedger(ctx, user, chat.ID, chat.Title)
+}
+
+// joinChatLedger sets ledger of chat to user and adds user to its members
+func (b *Bot) joinChatLedger(ctx context.Context, user *User, chatID int64, title string) error {
+	ledger, err := b.saldo.GetOrCreateChatLedger(ctx, chatID, title)
+	if err != nil {
+		return err
+	}
+
+	if _, err := b.saldo.AddLedgerMember(ctx, ledger.ID, user.ID); err != nil {
+		return err
+	}
+	user.LedgerID = ledger.ID
+
+	return nil
 }
@@ -1,21 +1,60 @@
 package telegram
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"saldo/pkg/i18n"
+)
 
 // User represents a user in the telegram bot layer
 type User struct {
-	ID        int
-	Username  string
-	FirstName string
-	LastName  string
+	ID              int
+	Username        string
+	FirstName       string
+	LastName        string
+	BaseCurrency    string
+	Language        i18n.Lang
+	Timezone        string
+	DefaultCurrency string       // currency of amounts entered without currency
+	WeekStart       time.Weekday // first day of week for weekly periods
+	LedgerID        int          // ledger of current chat that expenses are written to
+	SharedLedger    bool         // ledger is shared with other members, statistics can be split by member
+	WeeklyDigest    bool         // user is subscribed to summary of past week on Mondays
+	MonthlyDigest   bool         // user is subscribed to summary of past month on the 1st day of month
+}
+
+// DisplayName returns name of user shown to other members of shared ledger
+func (u User) DisplayName() string {
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	} else if u.Username != "" {
+		return "@" + u.Username
+	}
+
+	return "#" + strconv.Itoa(u.ID)
 }
 
 // Category represents an expense category in the telegram bot layer
 type Category struct {
-	ID     int
-	UserID int
-	Title  string
-	Emoji  string
+	ID       int
+	UserID   int
+	Title    string
+	Emoji    string
+	ParentID *int // parent of sub-category, nil for top level category
+}
+
+// CategoryUsage is a category with number of its expenses shown in categories menu
+type CategoryUsage struct {
+	Category
+	ExpensesCount int
+}
+
+// TagUsage is a tag with number of its expenses shown in tag cloud
+type TagUsage struct {
+	Tag           string
+	ExpensesCount int
 }
 
 // Expense represents a user expense in the telegram bot layer
@@ -27,6 +66,61 @@ type Expense struct {
 	Currency    string
 	Description string
 	CreatedAt   time.Time
+	SpentAt     time.Time // when money was spent, statistics are built by it
+	Author      string    // name of ledger member who added expense
+	Tags        []string  // hashtags, e.g. "отпуск" of "#отпуск такси 3000"
+
+	// Relations
+	Category *Category
+}
+
+// ExpenseTotal is sum of expenses of one category in one currency spent in one day
+type ExpenseTotal struct {
+	Category *Category
+	Currency string
+	Day      time.Time // date in user's time zone, at midnight UTC
+	Amount   int64     // in cents
+	Count    int       // number of summed expenses
+}
+
+// Income represents a user income in the telegram bot layer
+type Income struct {
+	ID          int
+	UserID      int
+	CategoryID  *int
+	Amount      int64 // in cents
+	Currency    string
+	Description string
+	CreatedAt   time.Time
+	ReceivedAt  time.Time // when money was received, statistics are built by it
+
+	// Relations
+	Category *Category
+}
+
+// Budget represents a monthly category budget with current month spending in the telegram bot layer
+type Budget struct {
+	ID          int
+	CategoryID  int
+	Amount      int64 // in cents
+	Currency    string
+	Spent       int64 // in cents, spent in current month
+	Percent     int64
+	CategoryIDs []int // IDs of budget category and its sub-categories
+
+	// Relations
+	Category *Category
+}
+
+// RecurringExpense represents an expense charged automatically every month in the telegram bot layer
+type RecurringExpense struct {
+	ID          int
+	CategoryID  *int
+	Amount      int64 // in cents
+	Currency    string
+	Description string
+	DayOfMonth  int
+	NextRunAt   time.Time
 
 	// Relations
 	Category *Category
@@ -1,18 +1,31 @@
 package telegram
 
 import (
-	"sync"
+	"context"
+	"encoding/json"
+	"time"
+
+	"saldo/pkg/saldo"
+
+	"github.com/vmkteam/embedlog"
 )
 
 // UserState represents the current state of a user in conversation flow
 type UserState string
 
 const (
-	StateIdle                 UserState = "idle"
-	StateAwaitingExpense      UserState = "awaiting_expense"
-	StateAwaitingCustomPeriod UserState = "awaiting_custom_period"
-	StateInStatsMenu          UserState = "in_stats_menu"
-	StateInPeriodSelection    UserState = "in_period_selection"
+	StateIdle                  UserState = "idle"
+	StateAwaitingExpense       UserState = "awaiting_expense"
+	StateAwaitingCustomPeriod  UserState = "awaiting_custom_period"
+	StateInStatsMenu           UserState = "in_stats_menu"
+	StateInPeriodSelection     UserState = "in_period_selection"
+	StateEditingExpense        UserState = "editing_expense"
+	StateAwaitingBudget        UserState = "awaiting_budget"
+	StateAwaitingRecurring     UserState = "awaiting_recurring"
+	StateAwaitingItemAmount    UserState = "awaiting_item_amount"
+	StateAwaitingTimezone      UserState = "awaiting_timezone"
+	StateAwaitingCategoryName  UserState = "awaiting_category_name"
+	StateAwaitingCategoryEmoji UserState = "awaiting_category_emoji"
 )
 
 type StatsType string
@@ -20,86 +33,152 @@ type StatsType string
 const (
 	StatsByCategories StatsType = "categories"
 	StatsByExpenses   StatsType = "expenses"
+	StatsExport       StatsType = "export"
+	StatsCharts       StatsType = "charts"
 )
 
-// UserStateData holds temporary data for user's current operation
+// EditField is a field of saved expense that user is changing
+type EditField string
+
+const (
+	EditFieldAmount      EditField = "amount"
+	EditFieldCurrency    EditField = "currency"
+	EditFieldCategory    EditField = "category"
+	EditFieldDescription EditField = "description"
+)
+
+// UserStateData holds temporary data for user's current operation, it is persisted as JSON
 type UserStateData struct {
-	State        UserState
-	ExpensesData []ExpenseData
-	StatsType    StatsType // "categories" or "expenses"
+	State         UserState     `json:"state"`
+	ExpensesData  []ExpenseData `json:"expenses,omitempty"`
+	StatsType     StatsType     `json:"statsType,omitempty"`     // "categories", "expenses", "export" or "charts"
+	EditExpenseID int           `json:"editExpenseId,omitempty"` // saved expense being edited
+	EditField     EditField     `json:"editField,omitempty"`     // field awaiting text input
+	EditItem      int           `json:"editItem,omitempty"`      // index of pending expense awaiting new amount
+	MemberID      int           `json:"memberId,omitempty"`      // member of shared ledger whose statistics are shown, zero is all members
+	MemberName    string        `json:"memberName,omitempty"`
+	EditCategory  int           `json:"editCategory,omitempty"` // category being renamed or getting new emoji
+	FindQuery     string        `json:"findQuery,omitempty"`    // last /find query, its results are paged
 }
 
-// ExpenseData holds parsed expense information
+// ExpenseData holds parsed expense or income information
 type ExpenseData struct {
-	Amount      int64 // in cents
-	Currency    string
-	Category    string
-	Description string
+	Amount      int64     `json:"amount"` // in cents
+	Currency    string    `json:"currency"`
+	Category    string    `json:"category"`
+	Description string    `json:"description"`
+	Income      bool      `json:"income,omitempty"` // money came in, saved as income
+	Date        time.Time `json:"date,omitzero"`    // operation date of bank statement, receipt or mentioned in text
+	Tags        []string  `json:"tags,omitempty"`   // hashtags of user's message
 }
 
-// StateManager manages user states across conversations
+const (
+	// stateTTL is how long state lives after its last change, pending confirmations expire after it
+	stateTTL = 24 * time.Hour
+
+	// stateCleanupInterval is how often expired states are deleted from database
+	stateCleanupInterval = time.Hour
+)
+
+// StateManager manages user states across conversations.
+// States are stored in database, so pending confirmations survive bot restarts.
 type StateManager struct {
-	mu     sync.RWMutex
-	states map[int64]*UserStateData
+	saldo  *saldo.Manager
+	logger embedlog.Logger
 }
 
 // NewStateManager creates a new state manager
-func NewStateManager() *StateManager {
+func NewStateManager(saldoService *saldo.Manager, logger embedlog.Logger) *StateManager {
 	return &StateManager{
-		states: make(map[int64]*UserStateData),
+		saldo:  saldoService,
+		logger: logger,
 	}
 }
 
-// GetState returns the current state for a user
-func (sm *StateManager) GetState(telegramUserID int64) *UserStateData {
-	sm.mu.RLock()
-	defer sm.mu.RUnlock()
+// GetState returns the current state for a user in chat, idle state is returned if it is absent or expired.
+// State is kept per chat, so user of group ledger may confirm expenses in group and private chat independently.
+func (sm *StateManager) GetState(ctx context.Context, telegramUserID, chatID int64) *UserStateData {
+	idle := &UserStateData{State: StateIdle}
+
+	data, err := sm.saldo.GetUserState(ctx, telegramUserID, chatID)
+	if err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		sm.logger.Error(ctx, "failed to get user state", "err", err, "telegram_id", telegramUserID, "chat_id", chatID)
+		return idle
+	} else if data == "" {
+		return idle
+	}
 
-	if state, exists := sm.states[telegramUserID]; exists {
-		return state
+	state := &UserStateData{}
+	if err := json.Unmarshal([]byte(data), state); err != nil {
+		sm.logger.Error(ctx, "failed to decode user state", "err", err, "telegram_id", telegramUserID, "chat_id", chatID)
+		return idle
 	}
-	return &UserStateData{State: StateIdle}
+	return state
 }
 
-// SetState sets the state for a user
-func (sm *StateManager) SetState(telegramUserID int64, state UserState) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
-	if existing, exists := sm.states[telegramUserID]; exists {
-		existing.State = state
-	} else {
-		sm.states[telegramUserID] = &UserStateData{State: state}
-	}
+// SetState sets the state for a user in chat
+func (sm *StateManager) SetState(ctx context.Context, telegramUserID, chatID int64, state UserState) {
+	data := sm.GetState(ctx, telegramUserID, chatID)
+	data.State = state
+	sm.SetStateData(ctx, telegramUserID, chatID, data)
 }
 
-// SetStateData sets complete state data for a user
-func (sm *StateManager) SetStateData(telegramUserID int64, data *UserStateData) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
+// SetStateData sets complete state data for a user in chat and prolongs its expiration
+func (sm *StateManager) SetStateData(ctx context.Context, telegramUserID, chatID int64, data *UserStateData) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		sm.logger.Error(ctx, "failed to encode user state", "err", err, "telegram_id", telegramUserID, "chat_id", chatID)
+		return
+	}
 
-	sm.states[telegramUserID] = data
+	if err := sm.saldo.SaveUserState(ctx, telegramUserID, chatID, string(b), time.Now().Add(stateTTL)); err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		sm.logger.Error(ctx, "failed to save user state", "err", err, "telegram_id", telegramUserID, "chat_id", chatID)
+	}
 }
 
-// ClearState clears the state for a user
-func (sm *StateManager) ClearState(telegramUserID int64) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
+// ClearState clears the state for a user in chat
+func (sm *StateManager) ClearState(ctx context.Context, telegramUserID, chatID int64) {
+	if err := sm.saldo.DeleteUserState(ctx, telegramUserID, chatID); err != nil {
+		errorsTotal.WithLabelValues("database").Inc()
+		sm.logger.Error(ctx, "failed to clear user state", "err", err, "telegram_id", telegramUserID, "chat_id", chatID)
+	}
+}
 
-	delete(sm.states, telegramUserID)
+// runCleanup periodically deletes expired states until context is done
+func (sm *StateManager) runCleanup(ctx context.Context) {
+	ticker := time.NewTicker(stateCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := sm.saldo.DeleteExpiredUserStates(ctx, time.Now())
+		if err != nil {
+			errorsTotal.WithLabelValues("database").Inc()
+			sm.logger.Error(ctx, "failed to delete expired user states", "err", err)
+		} else if count > 0 {
+			sm.logger.Print(ctx, "expired user states deleted", "count", count)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 // GetCurrentKeyboard returns appropriate keyboard based on current state
-func (sm *StateManager) GetCurrentKeyboard(telegramUserID int64) interface{} {
-	state := sm.GetState(telegramUserID)
+func (sm *StateManager) GetCurrentKeyboard(ctx context.Context, telegramUserID, chatID int64) interface{} {
+	state := sm.GetState(ctx, telegramUserID, chatID)
 
 	switch state.State {
-	case StateInStatsMenu:
-		return statisticsMenuKeyboard()
+	case StateInStatsMenu, StateAwaitingBudget:
+		return statisticsMenuKeyboard(ctx)
 	case StateInPeriodSelection, StateAwaitingCustomPeriod:
-		includeAllTime := state.StatsType == StatsByCategories
-		return periodSelectionKeyboard(includeAllTime)
+		includeAllTime := state.StatsType != StatsByExpenses
+		return periodSelectionKeyboard(ctx, includeAllTime)
 	default:
-		return mainMenuKeyboard()
+		return mainMenuKeyboard(ctx)
 	}
 }